@@ -0,0 +1,53 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#include "src/charset.hpp"
+#include <stdint.h>
+
+// A character is at most 4 bytes in all the charsets, and at least 1 byte
+#define MAX_CHAR_GROWTH 4
+
+const char *ConvertCharset(const CHARSET_INFO *toCS, const CHARSET_INFO *fromCS, const char *from,
+                           size_t fromLen, std::string *out) {
+  if (toCS == nullptr || fromCS == nullptr) {
+    out->assign(from, fromLen);
+    return nullptr;
+  }
+
+  const char *wellFormedErrorPos;
+  const char *cannotConvertErrorPos;
+  const char *fromEndPos;
+
+  out->resize(fromLen * MAX_CHAR_GROWTH);
+  size_t bytes = well_formed_copy_nchars(toCS, &(*out)[0], out->size(), fromCS, from, fromLen,
+                                         UINT32_MAX, &wellFormedErrorPos, &cannotConvertErrorPos,
+                                         &fromEndPos);
+  out->resize(bytes);
+
+  if (wellFormedErrorPos != nullptr) {
+    return wellFormedErrorPos;
+  }
+  if (cannotConvertErrorPos != nullptr) {
+    return cannotConvertErrorPos;
+  }
+  if (fromEndPos < from + fromLen) {
+    return fromEndPos;
+  }
+  return nullptr;
+}
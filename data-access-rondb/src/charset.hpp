@@ -0,0 +1,46 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#ifndef DATA_ACCESS_RONDB_SRC_CHARSET_HPP_
+#define DATA_ACCESS_RONDB_SRC_CHARSET_HPP_
+
+#include <string>
+#include <NdbApi.hpp>
+#include "src/rondb-lib/rdrs_string.hpp"
+
+// JSON requests and responses are UTF-8
+#define JSON_CHARSET (&my_charset_utf8mb4_bin)
+
+/**
+ * Convert a string from one charset to another, e.g., a key from UTF-8 to
+ * the latin1 charset of the column. Strings are copied as they are if
+ * either of the charsets is missing, e.g., for binary columns
+ *
+ * @param[in] toCS the charset of the converted string
+ * @param[in] fromCS the charset of the string
+ * @param[in] from the string
+ * @param[in] fromLen length of the string in bytes
+ * @param[out] out the converted string
+ * @return nullptr on success. Otherwise, the position of the first
+ * character that is not valid in fromCS or has no mapping in toCS
+ */
+const char *ConvertCharset(const CHARSET_INFO *toCS, const CHARSET_INFO *fromCS, const char *from,
+                           size_t fromLen, std::string *out);
+
+#endif  // DATA_ACCESS_RONDB_SRC_CHARSET_HPP_
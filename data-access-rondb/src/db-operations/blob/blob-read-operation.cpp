@@ -0,0 +1,258 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#include "src/db-operations/blob/blob-read-operation.hpp"
+#include <cstring>
+#include <string>
+#include "src/db-operations/pk/common.hpp"
+#include "src/error-strs.h"
+#include "src/logger.hpp"
+#include "src/rdrs-const.h"
+#include "src/status.hpp"
+
+BlobReadOperation::BlobReadOperation(Ndb *ndb_object) {
+  this->ndb_object = ndb_object;
+}
+
+BlobReadOperation::~BlobReadOperation() {
+  Close();
+}
+
+Ndb *BlobReadOperation::GetNdbObject() {
+  return ndb_object;
+}
+
+RS_Status BlobReadOperation::Init(BlobRequest *request) {
+  if (ndb_object->setCatalogName(request->DB()) != 0) {
+    return RS_CLIENT_ERROR(ERROR_011 + std::string(" Database: ") + std::string(request->DB()) +
+                           " Table: " + request->Table());
+  }
+  const NdbDictionary::Dictionary *dict = ndb_object->getDictionary();
+  table_dict                            = dict->getTable(request->Table());
+  if (table_dict == nullptr) {
+    return RS_CLIENT_ERROR(ERROR_011 + std::string(" Database: ") + std::string(request->DB()) +
+                           " Table: " + request->Table());
+  }
+  return RS_OK;
+}
+
+RS_Status BlobReadOperation::ValidateRequest(BlobRequest *request, RS_Buffer *resp_buff) {
+  // the key must contain all the primary key columns
+  if (request->PKColumnsCount() != static_cast<Uint32>(table_dict->getNoOfPrimaryKeys())) {
+    return RS_CLIENT_ERROR(ERROR_013 + std::string(" Expecting: ") +
+                           std::to_string(table_dict->getNoOfPrimaryKeys()) +
+                           " Got: " + std::to_string(request->PKColumnsCount()));
+  }
+
+  for (Uint32 i = 0; i < request->PKColumnsCount(); i++) {
+    const NdbDictionary::Column *col = table_dict->getColumn(request->PKName(i));
+    if (col == nullptr || !col->getPrimaryKey()) {
+      return RS_CLIENT_ERROR(ERROR_014 + std::string(" Column: ") +
+                             std::string(request->PKName(i)));
+    }
+  }
+
+  if (request->ReadColumnsCount() != 1) {
+    return RS_CLIENT_ERROR(ERROR_049);
+  }
+
+  const NdbDictionary::Column *col = table_dict->getColumn(request->ReadColumnName(0));
+  if (col == nullptr) {
+    return RS_CLIENT_ERROR(ERROR_012 + std::string(" Column: ") +
+                           std::string(request->ReadColumnName(0)));
+  }
+
+  if (col->getType() != NdbDictionary::Column::Blob &&
+      col->getType() != NdbDictionary::Column::Text) {
+    return RS_CLIENT_ERROR(ERROR_049 + std::string(" Column: ") +
+                           std::string(request->ReadColumnName(0)));
+  }
+
+  if (resp_buff->size <= BLOB_RESP_HEADER_END) {
+    return RS_SERVER_ERROR(ERROR_016);
+  }
+  return RS_OK;
+}
+
+RS_Status BlobReadOperation::SetupTransaction() {
+  transaction = ndb_object->startTransaction(table_dict);
+  if (transaction == nullptr) {
+    return RS_RONDB_SERVER_ERROR(ndb_object->getNdbError(), ERROR_005);
+  }
+  return RS_OK;
+}
+
+RS_Status BlobReadOperation::SetupOperation(BlobRequest *request) {
+  operation = transaction->getNdbOperation(table_dict);
+  if (operation == nullptr) {
+    return RS_RONDB_SERVER_ERROR(transaction->getNdbError(), ERROR_007);
+  }
+
+  // the shared lock is held until the reader is closed, so the value
+  // can not be changed between the reads of its parts
+  if (operation->readTuple(NdbOperation::LM_Read) != 0) {
+    return RS_SERVER_ERROR(ERROR_022);
+  }
+
+  for (Uint32 i = 0; i < request->PKColumnsCount(); i++) {
+    RS_Status status =
+        SetOperationPKCol(table_dict->getColumn(request->PKName(i)), operation, request, i);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  }
+
+  blob = operation->getBlobHandle(request->ReadColumnName(0));
+  if (blob == nullptr) {
+    return RS_RONDB_SERVER_ERROR(operation->getNdbError(), ERROR_019);
+  }
+  return RS_OK;
+}
+
+RS_Status BlobReadOperation::Execute() {
+  int ret = transaction->execute(NdbTransaction::NoCommit);
+  if (operation->getNdbError().classification == NdbError::NoDataFound) {
+    return RS_CLIENT_404_ERROR();
+  }
+  if (ret != 0) {
+    return RS_RONDB_SERVER_ERROR(transaction->getNdbError(), ERROR_009);
+  }
+  return RS_OK;
+}
+
+RS_Status BlobReadOperation::CreateResponse(Uint32 offset, RS_Buffer *resp_buff) {
+  Uint32 *header               = reinterpret_cast<Uint32 *>(resp_buff->buffer);
+  header[BLOB_RESP_NULL_IDX]   = 0;
+  header[BLOB_RESP_LENGTH_IDX] = 0;
+  header[BLOB_RESP_BYTES_IDX]  = 0;
+  header[BLOB_RESP_TEXT_IDX]   = blob->getColumn()->getType() == NdbDictionary::Column::Text;
+
+  int isNull;
+  if (blob->getNull(isNull) != 0) {
+    return RS_RONDB_SERVER_ERROR(blob->getNdbError(), ERROR_048);
+  }
+  if (isNull == 1) {
+    header[BLOB_RESP_NULL_IDX] = 1;
+    return RS_OK;
+  }
+
+  Uint64 length = 0;
+  if (blob->getLength(length) != 0) {
+    return RS_RONDB_SERVER_ERROR(blob->getNdbError(), ERROR_048);
+  }
+  header[BLOB_RESP_LENGTH_IDX] = static_cast<Uint32>(length);
+
+  // nothing to read if the offset is past the end of the value.
+  // The caller uses the length to find out
+  if (offset >= length) {
+    return RS_OK;
+  }
+
+  if (blob->setPos(offset) != 0) {
+    return RS_RONDB_SERVER_ERROR(blob->getNdbError(), ERROR_048);
+  }
+
+  // readData stops at the end of the value and sets the number of bytes read
+  Uint32 bytes = resp_buff->size - BLOB_RESP_HEADER_END;
+  if (blob->readData(resp_buff->buffer + BLOB_RESP_HEADER_END, bytes) != 0) {
+    return RS_RONDB_SERVER_ERROR(blob->getNdbError(), ERROR_048);
+  }
+  header[BLOB_RESP_BYTES_IDX] = bytes;
+  return RS_OK;
+}
+
+RS_Status BlobReadOperation::Open(RS_Buffer *req_buff, RS_Buffer *resp_buff) {
+  BlobRequest request(req_buff);
+
+  RS_Status status = Init(&request);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  status = ValidateRequest(&request, resp_buff);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  status = SetupTransaction();
+  if (status.http_code != SUCCESS) {
+    Abort();
+    return status;
+  }
+
+  status = SetupOperation(&request);
+  if (status.http_code != SUCCESS) {
+    Abort();
+    return status;
+  }
+
+  status = Execute();
+  if (status.http_code != SUCCESS) {
+    Abort();
+    return status;
+  }
+
+  status = CreateResponse(request.BlobOffset(), resp_buff);
+  if (status.http_code != SUCCESS) {
+    Abort();
+    return status;
+  }
+  return RS_OK;
+}
+
+RS_Status BlobReadOperation::Read(RS_Buffer *req_buff, RS_Buffer *resp_buff) {
+  if (blob == nullptr) {
+    return RS_SERVER_ERROR(ERROR_048 + std::string(" The reader is not open"));
+  }
+  if (resp_buff->size <= BLOB_RESP_HEADER_END) {
+    return RS_SERVER_ERROR(ERROR_016);
+  }
+
+  BlobRequest request(req_buff);
+  RS_Status status = CreateResponse(request.BlobOffset(), resp_buff);
+  if (status.http_code != SUCCESS) {
+    Abort();
+    return status;
+  }
+  return RS_OK;
+}
+
+void BlobReadOperation::Close() {
+  if (transaction != nullptr) {
+    // nothing was written, so the commit only releases the lock
+    transaction->execute(NdbTransaction::Commit);
+    ndb_object->closeTransaction(transaction);
+  }
+  transaction = nullptr;
+  operation   = nullptr;
+  blob        = nullptr;
+}
+
+void BlobReadOperation::Abort() {
+  if (transaction != nullptr) {
+    NdbTransaction::CommitStatusType status = transaction->commitStatus();
+    if (status == NdbTransaction::CommitStatusType::Started) {
+      transaction->execute(NdbTransaction::Rollback);
+    }
+    ndb_object->closeTransaction(transaction);
+  }
+  transaction = nullptr;
+  operation   = nullptr;
+  blob        = nullptr;
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#ifndef DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_BLOB_BLOB_READ_OPERATION_HPP_
+#define DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_BLOB_BLOB_READ_OPERATION_HPP_
+
+#include <stdint.h>
+#include <NdbApi.hpp>
+#include "src/db-operations/blob/blob-request.hpp"
+#include "src/rdrs-dal.h"
+
+/**
+ * Reads a BLOB/TEXT value of a row in parts. Every part is copied as it is
+ * to the response buffer after a small binary header, see rdrs-const.h.
+ * All the parts are read through the same blob handle in one transaction
+ * that holds a shared lock on the row, so the value can not change while
+ * it is read. The reader must be closed to release the lock
+ */
+class BlobReadOperation {
+ private:
+  Ndb *ndb_object                        = nullptr;
+  NdbTransaction *transaction            = nullptr;
+  NdbOperation *operation                = nullptr;
+  NdbBlob *blob                          = nullptr;
+  const NdbDictionary::Table *table_dict = nullptr;
+
+ public:
+  explicit BlobReadOperation(Ndb *ndb_object);
+
+  ~BlobReadOperation();
+
+  /**
+   * lock the row and read the first part of the value
+   *
+   * @param req_buff[in]. request
+   * @param resp_buff[out]. response
+   * @return status
+   */
+  RS_Status Open(RS_Buffer *req_buff, RS_Buffer *resp_buff);
+
+  /**
+   * read the part of the value that starts at the offset of the request
+   *
+   * @param req_buff[in]. request. Only the offset is read
+   * @param resp_buff[out]. response
+   * @return status
+   */
+  RS_Status Read(RS_Buffer *req_buff, RS_Buffer *resp_buff);
+
+  /**
+   * end the transaction and release the lock on the row
+   */
+  void Close();
+
+  /**
+   * Get the Ndb object used by the reader
+   */
+  Ndb *GetNdbObject();
+
+ private:
+  /**
+   * initialize data structures
+   * @return status
+   */
+  RS_Status Init(BlobRequest *request);
+
+  /**
+   * Validate request
+   * @return status
+   */
+  RS_Status ValidateRequest(BlobRequest *request, RS_Buffer *resp_buff);
+
+  /**
+   * start a transaction
+   *
+   * @return status
+   */
+  RS_Status SetupTransaction();
+
+  /**
+   * setup the read operation and the blob handle
+   * @return status
+   */
+  RS_Status SetupOperation(BlobRequest *request);
+
+  /**
+   * Execute transaction. The transaction is not committed as the
+   * blob handle can only be read before the commit
+   *
+   * @return status
+   */
+  RS_Status Execute();
+
+  /**
+   * copy a part of the value to the response
+   *
+   * @param offset[in]. position in the value where the part starts
+   * @param resp_buff[out]. response
+   * @return status
+   */
+  RS_Status CreateResponse(Uint32 offset, RS_Buffer *resp_buff);
+
+  /**
+   * abort the transaction
+   */
+  void Abort();
+};
+#endif  // DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_BLOB_BLOB_READ_OPERATION_HPP_
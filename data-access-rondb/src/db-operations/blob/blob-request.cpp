@@ -0,0 +1,28 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#include "src/db-operations/blob/blob-request.hpp"
+#include "src/rdrs-const.h"
+
+BlobRequest::BlobRequest(const RS_Buffer *request) : PKRRequest(request) {
+}
+
+Uint32 BlobRequest::BlobOffset() {
+  return (reinterpret_cast<Uint32 *>(req->buffer))[PKR_BLOB_OFFSET_IDX];
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#ifndef DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_BLOB_BLOB_REQUEST_HPP_
+#define DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_BLOB_BLOB_REQUEST_HPP_
+
+#include <stdint.h>
+#include <NdbApi.hpp>
+#include "src/rdrs-dal.h"
+#include "src/db-operations/pk/pkr-request.hpp"
+
+/**
+ * Blob read request. The header, DB, table and primary key columns are the
+ * same as for the primary key read request. The only read column is the
+ * BLOB/TEXT column
+ */
+class BlobRequest : public PKRRequest {
+ public:
+  explicit BlobRequest(const RS_Buffer *request);
+
+  /**
+   * Get the position in the BLOB/TEXT value where the read starts
+   *
+   * @return offset in bytes
+   */
+  Uint32 BlobOffset();
+};
+
+#endif  // DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_BLOB_BLOB_REQUEST_HPP_
@@ -18,7 +18,8 @@
  */
 
 #include "src/db-operations/pk/common.hpp"
-#include <boost/date_time/posix_time/posix_time.hpp>
+#include "src/db-operations/pk/type-conversions.hpp"
+#include <atomic>
 #include <boost/beast/core/detail/base64.hpp>
 #include "src/error-strs.h"
 #include "src/status.hpp"
@@ -26,26 +27,262 @@
 #include "src/rondb-lib/rdrs_string.hpp"
 #include "src/rondb-lib/decimal_utils.hpp"
 #include "src/mystring.hpp"
+#include "src/json-binary.hpp"
+#include "src/charset.hpp"
+
+// BLOB/TEXT columns larger than this are not read. Set by the REST server
+static std::atomic<Uint32> maxBlobReadSize(128 * 1024);
+
+// Default trimming of the padding of CHAR and BINARY columns. Set by the REST server
+static std::atomic<bool> trimCharPadding(true);
+static std::atomic<bool> trimBinaryPadding(false);
+
+static bool IsInteger(const char *str, size_t len) {
+  size_t i = len > 0 && str[0] == '-' ? 1 : 0;
+  if (i == len) {
+    return false;
+  }
+  for (; i < len; i++) {
+    if (str[i] < '0' || str[i] > '9') {
+      return false;
+    }
+  }
+  return true;
+}
+
+/**
+ * Parse a DATE, DATETIME or TIMESTAMP value sent by the user. MySQL style
+ * strings and RFC 3339 strings, e.g., 2021-01-01T10:00:00.5+02:00, are
+ * accepted, and integers if the request uses epoch milliseconds. Values
+ * with a zone offset are converted to the time zone of the request
+ *
+ * @param[out] l_time the time in the time zone of the request
+ * @return true if the value is valid
+ */
+static bool ParseDateTime(const char *str, size_t len, const TemporalFormat &fmt,
+                          MYSQL_TIME *l_time) {
+  if (fmt.format == RDRS_TF_EPOCH_MILLIS && IsInteger(str, len)) {
+    Int64 millis = 0;
+    try {
+      millis = std::stoll(std::string(str, len));
+    } catch (...) {
+      return false;
+    }
+    Int64 ms = millis % 1000;
+    if (ms < 0) {
+      ms += 1000;
+    }
+    SecondsToTime((millis - ms) / 1000 + fmt.tzOffset, ms * 1000, l_time);
+    return true;
+  }
+
+  // zone offset of RFC 3339 strings, Z or +HH:MM
+  Int32 offset   = 0;
+  bool hasOffset = false;
+  if (len > 16 && (str[10] == 'T' || str[10] == 't' || str[10] == ' ')) {
+    const char *zone = str + len - 6;
+    if (str[len - 1] == 'Z' || str[len - 1] == 'z') {
+      hasOffset = true;
+      len -= 1;
+    } else if ((zone[0] == '+' || zone[0] == '-') && zone[3] == ':' && isdigit(zone[1]) &&
+               isdigit(zone[2]) && isdigit(zone[4]) && isdigit(zone[5])) {
+      offset = ((zone[1] - '0') * 10 + (zone[2] - '0')) * 3600 +
+               ((zone[4] - '0') * 10 + (zone[5] - '0')) * 60;
+      if (zone[0] == '-') {
+        offset = -offset;
+      }
+      hasOffset = true;
+      len -= 6;
+    }
+  }
+
+  MYSQL_TIME_STATUS status;
+  if (str_to_datetime(str, len, l_time, 0, &status) != 0) {
+    return false;
+  }
+
+  if (hasOffset) {
+    if (l_time->month == 0 || l_time->day == 0) {
+      return false;
+    }
+    SecondsToTime(TimeToSeconds(*l_time) - offset + fmt.tzOffset, l_time->second_part, l_time);
+  }
+  return true;
+}
+
+/**
+ * Parse a TIME value sent by the user. Integers are accepted as
+ * milliseconds if the request uses epoch milliseconds
+ *
+ * @return true if the value is valid
+ */
+static bool ParseTime(const char *str, size_t len, const TemporalFormat &fmt,
+                      MYSQL_TIME *l_time) {
+  if (fmt.format == RDRS_TF_EPOCH_MILLIS && IsInteger(str, len)) {
+    Int64 millis = 0;
+    try {
+      millis = std::stoll(std::string(str, len));
+    } catch (...) {
+      return false;
+    }
+    *l_time             = {};
+    l_time->neg         = millis < 0;
+    millis              = std::abs(millis);
+    l_time->hour        = millis / 3600000;
+    l_time->minute      = millis / 60000 % 60;
+    l_time->second      = millis / 1000 % 60;
+    l_time->second_part = millis % 1000 * 1000;
+    l_time->time_type   = MYSQL_TIMESTAMP_TIME;
+    return l_time->hour <= 838;
+  }
+
+  MYSQL_TIME_STATUS status;
+  return str_to_time(str, len, l_time, &status, 0) == 0;
+}
+
+/**
+ * Write a DATE, DATETIME, TIME or TIMESTAMP value in the format of the
+ * request. The time is in the time zone of the request. Only TIMESTAMP
+ * values are instants, so only they get a zone offset in RFC 3339 strings
+ */
+static RS_Status WriteTemporal(const MYSQL_TIME &l_time, uint precision, bool isTimestamp,
+                               const TemporalFormat &fmt, ResponseWriter *writer) {
+  const bool isTime   = l_time.time_type == MYSQL_TIMESTAMP_TIME;
+  const bool zeroDate = !isTime && (l_time.month == 0 || l_time.day == 0);
+  char to[MAX_DATE_STRING_REP_LENGTH];
+
+  switch (fmt.format) {
+  case RDRS_TF_EPOCH_MILLIS: {
+    if (zeroDate) {
+      return writer->Null();
+    }
+
+    Int64 millis = 0;
+    if (isTime) {
+      millis = (l_time.hour * 3600LL + l_time.minute * 60 + l_time.second) * 1000 +
+               l_time.second_part / 1000;
+      millis = l_time.neg ? -millis : millis;
+    } else {
+      millis = (TimeToSeconds(l_time) - fmt.tzOffset) * 1000 + l_time.second_part / 1000;
+    }
+    return writer->Int(millis);
+  }
+  case RDRS_TF_RFC3339: {
+    my_TIME_to_str(l_time, to, precision);
+    std::string str(to);
+    if (l_time.time_type == MYSQL_TIMESTAMP_DATETIME) {
+      str[10] = 'T';
+    }
+    if (isTimestamp && !zeroDate) {
+      if (fmt.tzOffset == 0) {
+        str += "Z";
+      } else {
+        Int32 offset = std::abs(fmt.tzOffset) / 60;
+        snprintf(to, sizeof(to), "%c%02d:%02d", fmt.tzOffset < 0 ? '-' : '+', offset / 60,
+                 offset % 60);
+        str += to;
+      }
+    }
+    return writer->String(str);
+  }
+  default:
+    my_TIME_to_str(l_time, to, precision);
+    return writer->String(to);
+  }
+}
+
+TemporalFormat GetTemporalFormat(PKRRequest *request) {
+  TemporalFormat fmt;
+  fmt.format   = request->TimeFormat();
+  fmt.tzOffset = request->TimeZoneOffset();
+  return fmt;
+}
 
 RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *operation,
                             PKRRequest *request, Uint32 colIdx) {
-  // validate the data and set data according to column type
-  char *data;
+  return SetOperationCol(col, operation, request, colIdx, true);
+}
+
+RS_Status SetOperationValueCol(const NdbDictionary::Column *col, NdbOperation *operation,
+                               PKRRequest *request, Uint32 colIdx) {
+  if (request->IsNullValue(colIdx)) {
+    if (!col->getNullable()) {
+      return RS_CLIENT_ERROR(ERROR_029 + std::string(" Column: ") +
+                             std::string(request->ValueName(colIdx)));
+    }
+    if (operation->setValue(request->ValueName(colIdx), static_cast<char *>(nullptr)) != 0) {
+      return RS_SERVER_ERROR(ERROR_032);
+    }
+    return RS_OK;
+  }
+
+  // numbers, objects and arrays are not stored as text. Binary values are
+  // base64 strings
+  switch (col->getType()) {
+  case NdbDictionary::Column::Char:
+  case NdbDictionary::Column::Varchar:
+  case NdbDictionary::Column::Longvarchar:
+  case NdbDictionary::Column::Binary:
+  case NdbDictionary::Column::Varbinary:
+  case NdbDictionary::Column::Longvarbinary:
+    if (!request->IsStringValue(colIdx)) {
+      return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting a string. Column: ") +
+                             std::string(request->ValueName(colIdx)));
+    }
+    break;
+  default:
+    break;
+  }
+  return SetOperationCol(col, operation, request, colIdx, false);
+}
+
+RS_Status SetOperationCol(const NdbDictionary::Column *col, NdbOperation *operation,
+                          PKRRequest *request, Uint32 colIdx, bool isPK) {
+  const char *colName   = isPK ? request->PKName(colIdx) : request->ValueName(colIdx);
+  const char *valueCStr = isPK ? request->PKValueCStr(colIdx) : request->ValueCStr(colIdx);
+  const Uint16 valueLen = isPK ? request->PKValueLen(colIdx) : request->ValueLen(colIdx);
+  const char *setErr    = isPK ? ERROR_023 : ERROR_032;
+
+  // primary key columns are bound using NdbOperation::equal() and
+  // all other columns using NdbOperation::setValue()
+  return ConvertColValue(col, valueCStr, valueLen, GetTemporalFormat(request), setErr,
+                         [&](const char *value, Uint32 len) -> int {
+                           if (isPK) {
+                             return operation->equal(colName, value, len);
+                           } else {
+                             return operation->setValue(colName, value, len);
+                           }
+                         });
+}
+
+RS_Status ConvertColValue(const NdbDictionary::Column *col, const char *valueCStr,
+                          Uint16 valueLen, const TemporalFormat &fmt,
+                          const char *setErr, const ColValueSetter &setter) {
+  const char *colName = col->getName();
+
+  // numbers are passed to the setter in their native byte order
+  auto setColValue = [&](auto value, auto... len) -> int {
+    if constexpr (sizeof...(len) == 0) {
+      return setter(reinterpret_cast<const char *>(&value), sizeof(value));
+    } else {
+      return setter(value, len...);
+    }
+  };
 
   switch (col->getType()) {
   case NdbDictionary::Column::Undefined: {
     ///< 4 bytes + 0-3 fraction
     return RS_CLIENT_ERROR(ERROR_018 + std::string(" Column: ") +
-                           std::string(request->PKName(colIdx)));
+                           std::string(colName));
   }
   case NdbDictionary::Column::Tinyint: {
     ///< 8 bit. 1 byte signed integer, can be used in array
     bool success = false;
     try {
-      int num = std::stoi(request->PKValueCStr(colIdx));
+      int num = std::stoi(valueCStr);
       if (num >= -128 && num <= 127) {
-        if (operation->equal(request->PKName(colIdx), static_cast<char>(num)) != 0) {
-          return RS_SERVER_ERROR(ERROR_023);
+        if (setColValue(static_cast<char>(num)) != 0) {
+          return RS_SERVER_ERROR(setErr);
         }
         success = true;
       }
@@ -53,7 +290,7 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     }
     if (!success) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting TINYINT. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     } else {
       return RS_OK;
     }
@@ -62,10 +299,10 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     ///< 8 bit. 1 byte unsigned integer, can be used in array
     bool success = false;
     try {
-      int num = std::stoi(request->PKValueCStr(colIdx));
+      int num = std::stoi(valueCStr);
       if (num >= 0 && num <= 255) {
-        if (operation->equal(request->PKName(colIdx), static_cast<char>(num)) != 0) {
-          return RS_SERVER_ERROR(ERROR_023);
+        if (setColValue(static_cast<char>(num)) != 0) {
+          return RS_SERVER_ERROR(setErr);
         }
         success = true;
       }
@@ -73,7 +310,7 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     }
     if (!success) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting TINYINT. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     } else {
       return RS_OK;
     }
@@ -82,10 +319,10 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     ///< 16 bit. 2 byte signed integer, can be used in array
     bool success = false;
     try {
-      int num = std::stoi(request->PKValueCStr(colIdx));
+      int num = std::stoi(valueCStr);
       if (num >= -32768 && num <= 32767) {
-        if (operation->equal(request->PKName(colIdx), (Int16)num) != 0) {
-          return RS_SERVER_ERROR(ERROR_023);
+        if (setColValue((Int16)num) != 0) {
+          return RS_SERVER_ERROR(setErr);
         }
         success = true;
       }
@@ -93,7 +330,7 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     }
     if (!success) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting SMALLINT. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     } else {
       return RS_OK;
     }
@@ -102,10 +339,10 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     ///< 16 bit. 2 byte unsigned integer, can be used in array
     bool success = false;
     try {
-      int num = std::stoi(request->PKValueCStr(colIdx));
+      int num = std::stoi(valueCStr);
       if (num >= 0 && num <= 65535) {
-        if (operation->equal(request->PKName(colIdx), (Uint16)num) != 0) {
-          return RS_SERVER_ERROR(ERROR_023);
+        if (setColValue((Uint16)num) != 0) {
+          return RS_SERVER_ERROR(setErr);
         }
         success = true;
       }
@@ -113,7 +350,7 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     }
     if (!success) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting TINYINT UNSIGNED. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     } else {
       return RS_OK;
     }
@@ -122,10 +359,10 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     ///< 24 bit. 3 byte signed integer, can be used in array
     bool success = false;
     try {
-      int num = std::stoi(request->PKValueCStr(colIdx));
+      int num = std::stoi(valueCStr);
       if (num >= -8388608 && num <= 8388607) {
-        if (operation->equal(request->PKName(colIdx), static_cast<int>(num)) != 0) {
-          return RS_SERVER_ERROR(ERROR_023);
+        if (setColValue(static_cast<int>(num)) != 0) {
+          return RS_SERVER_ERROR(setErr);
         }
         success = true;
       }
@@ -133,7 +370,7 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     }
     if (!success) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting MEDIUMINT. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     } else {
       return RS_OK;
     }
@@ -142,10 +379,10 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     ///< 24 bit. 3 byte unsigned integer, can be used in array
     bool success = false;
     try {
-      int num = std::stoi(request->PKValueCStr(colIdx));
+      int num = std::stoi(valueCStr);
       if (num >= 0 && num <= 16777215) {
-        if (operation->equal(request->PKName(colIdx), (unsigned int)num)) {
-          return RS_SERVER_ERROR(ERROR_023);
+        if (setColValue((unsigned int)num)) {
+          return RS_SERVER_ERROR(setErr);
         }
         success = true;
       }
@@ -153,7 +390,7 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     }
     if (!success) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting MEDIUMINT UNSIGNED. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     } else {
       return RS_OK;
     }
@@ -161,13 +398,13 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
   case NdbDictionary::Column::Int: {
     ///< 32 bit. 4 byte signed integer, can be used in array
     try {
-      Int32 num = std::stoi(request->PKValueCStr(colIdx));
-      if (operation->equal(request->PKName(colIdx), num) != 0) {
-        return RS_SERVER_ERROR(ERROR_023);
+      Int32 num = std::stoi(valueCStr);
+      if (setColValue(num) != 0) {
+        return RS_SERVER_ERROR(setErr);
       }
     } catch (...) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting Int. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     }
     return RS_OK;
   }
@@ -175,11 +412,11 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     ///< 32 bit. 4 byte unsigned integer, can be used in array
     bool success = false;
     try {
-      Int64 lresult = std::stoll(request->PKValueCStr(colIdx));
+      Int64 lresult = std::stoll(valueCStr);
       Uint32 result = lresult;
       if (result == lresult) {
-        if (operation->equal(request->PKName(colIdx), result) != 0) {
-          return RS_SERVER_ERROR(ERROR_023);
+        if (setColValue(result) != 0) {
+          return RS_SERVER_ERROR(setErr);
         }
         success = true;
       }
@@ -188,7 +425,7 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
 
     if (!success) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting Unsigned Int. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     } else {
       return RS_OK;
     }
@@ -196,13 +433,13 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
   case NdbDictionary::Column::Bigint: {
     ///< 64 bit. 8 byte signed integer, can be used in array
     try {
-      Int64 num = std::stoll(request->PKValueCStr(colIdx));
-      if (operation->equal(request->PKName(colIdx), num) != 0) {
-        return RS_SERVER_ERROR(ERROR_023);
+      Int64 num = std::stoll(valueCStr);
+      if (setColValue(num) != 0) {
+        return RS_SERVER_ERROR(setErr);
       }
     } catch (...) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting BIGINT. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     }
     return RS_OK;
   }
@@ -210,12 +447,12 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     ///< 64 Bit. 8 byte signed integer, can be used in array
     bool success = false;
     try {
-      const char *numCStr      = request->PKValueCStr(colIdx);
+      const char *numCStr      = valueCStr;
       const std::string numStr = std::string(numCStr);
       if (numStr.find('-') == std::string::npos) {
         Uint64 num = std::stoul(numCStr);
-        if (operation->equal(request->PKName(colIdx), num) != 0) {
-          return RS_SERVER_ERROR(ERROR_023);
+        if (setColValue(num) != 0) {
+          return RS_SERVER_ERROR(setErr);
         }
         success = true;
       }
@@ -223,37 +460,62 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     }
     if (!success) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting BIGINT UNSIGNED. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     } else {
       return RS_OK;
     }
   }
   case NdbDictionary::Column::Float: {
     ///< 32-bit float. 4 bytes float, can be used in array
-    return RS_CLIENT_ERROR(ERROR_017 + std::string(" Column: ") +
-                           std::string(request->PKName(colIdx)));
+    try {
+      float num = std::stof(valueCStr);
+      if (setColValue(num) != 0) {
+        return RS_SERVER_ERROR(setErr);
+      }
+    } catch (...) {
+      return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting FLOAT. Column: ") +
+                             std::string(colName));
+    }
+    return RS_OK;
   }
   case NdbDictionary::Column::Double: {
     ///< 64-bit float. 8 byte float, can be used in array
-    return RS_CLIENT_ERROR(ERROR_017 + std::string(" Column: ") +
-                           std::string(request->PKName(colIdx)));
+    try {
+      double num = std::stod(valueCStr);
+      if (setColValue(num) != 0) {
+        return RS_SERVER_ERROR(setErr);
+      }
+    } catch (...) {
+      return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting DOUBLE. Column: ") +
+                             std::string(colName));
+    }
+    return RS_OK;
   }
-  case NdbDictionary::Column::Olddecimal: {
+  case NdbDictionary::Column::Olddecimal:
     ///< MySQL < 5.0 signed decimal,  Precision, Scale
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
-  }
+    [[fallthrough]];
   case NdbDictionary::Column::Olddecimalunsigned: {
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
+    std::string decStr;
+    if (!ToOldDecimalStr(valueCStr, valueLen, col->getPrecision(), col->getScale(),
+                         col->getSizeInBytes(),
+                         col->getType() == NdbDictionary::Column::Olddecimalunsigned, &decStr)) {
+      return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting Decimal with Precision: ") +
+                             std::to_string(col->getPrecision()) + std::string(" and Scale: ") +
+                             std::to_string(col->getScale()) + " Column: " + std::string(colName));
+    }
+
+    if (setColValue(decStr.data(), static_cast<Uint32>(decStr.size())) != 0) {
+      return RS_SERVER_ERROR(setErr);
+    }
+    return RS_OK;
   }
   case NdbDictionary::Column::Decimalunsigned: {
     ///< MySQL >= 5.0 signed decimal,  Precision, Scale
-    const std::string decStr = std::string(request->PKValueCStr(colIdx));
+    const std::string decStr = std::string(valueCStr);
     if (decStr.find('-') != std::string::npos) {
       return RS_CLIENT_ERROR(ERROR_015 +
                              std::string(" Expecting Decimalunsigned UNSIGNED. Column: ") +
-                             std::string(request->PKName(colIdx)));
+                             std::string(colName));
     }
     [[fallthrough]];
   }
@@ -261,7 +523,7 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     int precision      = col->getPrecision();
     int scale          = col->getScale();
     int bytesNeeded    = getDecimalColumnSpace(precision, scale);
-    const char *decStr = request->PKValueCStr(colIdx);
+    const char *decStr = valueCStr;
     char decBin[bytesNeeded];
     if (decimal_str2bin(decStr, strlen(decStr), precision, scale, decBin, bytesNeeded) != 0) {
       return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting Decimal with Precision: ") +
@@ -269,28 +531,36 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
                              std::to_string(scale));
     }
 
-    if (operation->equal(request->PKName(colIdx), decBin, bytesNeeded) != 0) {
-      return RS_SERVER_ERROR(ERROR_023);
+    if (setColValue(decBin, bytesNeeded) != 0) {
+      return RS_SERVER_ERROR(setErr);
     }
     return RS_OK;
   }
   case NdbDictionary::Column::Char: {
     ///< Len. A fixed array of 1-byte chars
+    std::string value;
+    if (ConvertCharset(col->getCharset(), JSON_CHARSET, valueCStr, valueLen, &value) != nullptr) {
+      return RS_CLIENT_ERROR(ERROR_051 + std::string(" Column: ") + std::string(colName));
+    }
 
-    const int len = request->PKValueLen(colIdx);
+    const int len = value.size();
     if (len > col->getLength()) {
       return RS_CLIENT_ERROR(std::string(ERROR_008)+" Data len is greater than column length. Column: "+std::string(col->getName()));
     }
 
-    const char *charStr = request->PKValueCStr(colIdx);
+    // CHAR columns are stored padded with spaces, as MySQL does. The space
+    // is two bytes in ucs2 and utf16, and four bytes in utf32
+    std::string pad(" ");
+    ConvertCharset(col->getCharset(), JSON_CHARSET, " ", 1, &pad);
+
     char pk[col->getLength()];
-    for (int i = 0; i < col->getLength(); i++) {
-      pk[i] = 0;
+    for (int i = len; i < col->getLength(); i++) {
+      pk[i] = pad[(i - len) % pad.size()];
     }
-    memcpy(pk, charStr, len);
+    memcpy(pk, value.data(), len);
 
-    if (operation->equal(request->PKName(colIdx), pk, col->getLength()) != 0) {
-      return RS_SERVER_ERROR(ERROR_023);
+    if (setColValue(pk, col->getLength()) != 0) {
+      return RS_SERVER_ERROR(setErr);
     }
     return RS_OK;
   }
@@ -299,24 +569,37 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     [[fallthrough]];
   case NdbDictionary::Column::Longvarchar: {
     ///< Length bytes: 2, little-endian
-    const int len = request->PKValueLen(colIdx);
+    std::string value;
+    if (ConvertCharset(col->getCharset(), JSON_CHARSET, valueCStr, valueLen, &value) != nullptr) {
+      return RS_CLIENT_ERROR(ERROR_051 + std::string(" Column: ") + std::string(colName));
+    }
+
+    const int len = value.size();
     if (len > col->getLength()) {
       return RS_CLIENT_ERROR(std::string(ERROR_008)+" Data len is greater than column length. Column: "+std::string(col->getName()));
     }
-    char *charStr;
-    if (request->PKValueNDBStr(colIdx, col, &charStr) != 0) {
+    if (len > col->getSizeInBytes()) {
       return RS_CLIENT_ERROR(ERROR_019);
     }
-    if (operation->equal(request->PKName(colIdx), charStr, len) != 0) {
-      return RS_SERVER_ERROR(ERROR_023);
+
+    // the length of the string is stored in the first one or two bytes
+    const int prefixLen = col->getArrayType() == NdbDictionary::Column::ArrayTypeShortVar ? 1 : 2;
+    char charStr[len + prefixLen];
+    charStr[0] = static_cast<char>(len % 256);
+    if (prefixLen == 2) {
+      charStr[1] = static_cast<char>(len / 256);
+    }
+    memcpy(charStr + prefixLen, value.data(), len);
+    if (setColValue(charStr, len + prefixLen) != 0) {
+      return RS_SERVER_ERROR(setErr);
     }
     return RS_OK;
   }
   case NdbDictionary::Column::Binary: {
     ///< Len
     // we get the data in base64
-    const char *encodedStr = request->PKValueCStr(colIdx);
-    size_t decoded_size = boost::beast::detail::base64::decoded_size(request->PKValueLen(colIdx));
+    const char *encodedStr = valueCStr;
+    size_t decoded_size = boost::beast::detail::base64::decoded_size(valueLen);
     int maxlen          = std::max(col->getLength(), static_cast<int>(decoded_size));
 
     char pk[maxlen];
@@ -325,14 +608,14 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     }
 
     std::pair<std::size_t, std::size_t> ret =
-        boost::beast::detail::base64::decode(pk, encodedStr, request->PKValueLen(colIdx));
+        boost::beast::detail::base64::decode(pk, encodedStr, valueLen);
 
     if (static_cast<int>(ret.first) > col->getLength()) {
       return RS_CLIENT_ERROR(std::string(ERROR_008)+" Data len is greater than column length. Column: "+std::string(col->getName()));
     }
 
-    if (operation->equal(request->PKName(colIdx), pk, col->getLength()) != 0) {
-      return RS_SERVER_ERROR(ERROR_023);
+    if (setColValue(pk, col->getLength()) != 0) {
+      return RS_SERVER_ERROR(setErr);
     }
     return RS_OK;
   }
@@ -342,8 +625,8 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
   case NdbDictionary::Column::Longvarbinary: {
     ///< Length bytes: 2, little-endian
 
-    const char *encodedStr = request->PKValueCStr(colIdx);
-    size_t decoded_size = boost::beast::detail::base64::decoded_size(request->PKValueLen(colIdx));
+    const char *encodedStr = valueCStr;
+    size_t decoded_size = boost::beast::detail::base64::decoded_size(valueLen);
     int additional_len  = 1;
     if (col->getType() == NdbDictionary::Column::Longvarbinary) {
       additional_len = 2;
@@ -356,7 +639,7 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     }
 
     std::pair<std::size_t, std::size_t> ret = boost::beast::detail::base64::decode(
-        pk + additional_len, encodedStr, request->PKValueLen(colIdx));
+        pk + additional_len, encodedStr, valueLen);
 
     if (static_cast<int>(ret.first) > col->getLength()) {
       return RS_CLIENT_ERROR(std::string(ERROR_008)+" Data len is greater than column length. Column: "+std::string(col->getName()));
@@ -372,25 +655,34 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
       return RS_SERVER_ERROR(ERROR_015);
     }
 
-    if (operation->equal(request->PKName(colIdx), pk, ret.first + additional_len) != 0) {
-      return RS_SERVER_ERROR(ERROR_023);
+    if (setColValue(pk, ret.first + additional_len) != 0) {
+      return RS_SERVER_ERROR(setErr);
     }
     return RS_OK;
   }
   case NdbDictionary::Column::Datetime: {
     ///< Precision down to 1 sec (sizeof(Datetime) == 8 bytes )
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
+    MYSQL_TIME l_time;
+    if (!ParseDateTime(valueCStr, valueLen, fmt, &l_time)) {
+      return RS_CLIENT_ERROR(std::string(ERROR_027) + std::string(" Column: ") +
+                             std::string(col->getName()))
+    }
+
+    if (l_time.second_part != 0) {
+      return RS_CLIENT_ERROR(std::string(ERROR_008) +
+                             " Fractional seconds are not supported. Column: " +
+                             std::string(col->getName()));
+    }
+
+    if (setColValue(PackOldDatetime(l_time)) != 0) {
+      return RS_SERVER_ERROR(setErr);
+    }
+    return RS_OK;
   }
   case NdbDictionary::Column::Date: {
     ///< Precision down to 1 day(sizeof(Date) == 4 bytes )
-    const char *date_str = request->PKValueCStr(colIdx);
-    size_t date_str_len  = request->PKValueLen(colIdx);
-
     MYSQL_TIME l_time;
-    MYSQL_TIME_STATUS status;
-    bool ret = str_to_datetime(date_str, date_str_len, &l_time, 0, &status);
-    if (ret != 0) {
+    if (!ParseDateTime(valueCStr, valueLen, fmt, &l_time)) {
       return RS_CLIENT_ERROR(std::string(ERROR_027) + std::string(" Column: ") +
                              std::string(col->getName()))
     }
@@ -402,41 +694,80 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     unsigned char packed[col->getSizeInBytes()];
     my_date_to_binary(&l_time, packed);
 
-    if (operation->equal(request->PKName(colIdx), reinterpret_cast<char *>(packed),
-                         col->getSizeInBytes()) != 0) {
-      return RS_SERVER_ERROR(ERROR_023);
+    if (setColValue(reinterpret_cast<char *>(packed), col->getSizeInBytes()) != 0) {
+      return RS_SERVER_ERROR(setErr);
     }
     return RS_OK;
   }
-  case NdbDictionary::Column::Blob: {
+  case NdbDictionary::Column::Blob:
     ///< Binary large object (see NdbBlob)
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
-  }
+    [[fallthrough]];
   case NdbDictionary::Column::Text: {
     ///< Text blob
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
+    return RS_CLIENT_ERROR(ERROR_017 + std::string(" Column: ") + std::string(col->getName()));
   }
   case NdbDictionary::Column::Bit: {
     ///< Bit, length specifies no of bits
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
+    // the bits are sent base64 encoded, most significant byte first, in the
+    // same way as they are returned
+    const Uint32 bytes  = (col->getLength() + 7) / 8;
+    size_t decoded_size = boost::beast::detail::base64::decoded_size(valueLen);
+    std::string decoded(decoded_size, 0);
+    std::pair<std::size_t, std::size_t> ret =
+        boost::beast::detail::base64::decode(&decoded[0], valueCStr, valueLen);
+
+    // NDB stores the bits in little-endian words
+    std::string bits(col->getSizeInBytes(), 0);
+    for (size_t i = 0; i < ret.first && i < bits.size(); i++) {
+      bits[i] = decoded[ret.first - 1 - i];
+    }
+
+    // decoding stops at the padding or at the first invalid character
+    const std::string rest(valueCStr + ret.second, valueLen - ret.second);
+    const Uint32 unusedBits = bytes * 8 - col->getLength();
+    if (rest.find_first_not_of('=') != std::string::npos || ret.first > bytes ||
+        (ret.first == bytes && static_cast<Uint8>(bits[bytes - 1]) >> (8 - unusedBits) != 0)) {
+      return RS_CLIENT_ERROR(ERROR_015 + std::string(" Expecting BIT(") +
+                             std::to_string(col->getLength()) + ") base64 encoded. Column: " +
+                             std::string(colName));
+    }
+
+    if (setColValue(bits.data(), static_cast<Uint32>(bits.size())) != 0) {
+      return RS_SERVER_ERROR(setErr);
+    }
+    return RS_OK;
   }
   case NdbDictionary::Column::Time: {
     ///< Time without date
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
+    MYSQL_TIME l_time;
+    if (!ParseTime(valueCStr, valueLen, fmt, &l_time)) {
+      return RS_CLIENT_ERROR(std::string(ERROR_027) + std::string(" Column: ") +
+                             std::string(col->getName()))
+    }
+
+    if (l_time.second_part != 0) {
+      return RS_CLIENT_ERROR(std::string(ERROR_008) +
+                             " Fractional seconds are not supported. Column: " +
+                             std::string(col->getName()));
+    }
+
+    char packed[3];
+    PackOldTime(l_time, packed);
+
+    if (setColValue(packed, 3) != 0) {
+      return RS_SERVER_ERROR(setErr);
+    }
+    return RS_OK;
   }
   case NdbDictionary::Column::Year: {
     ///< Year 1901-2155 (1 byte)
     bool success = false;
     try {
-      Int32 year = std::stoi(request->PKValueCStr(colIdx));
+      Int32 year = std::stoi(valueCStr);
       if (year >= 1901 && year <= 2155) {
         Uint8 year_char = (year - 1900);
-        if (operation->equal(request->PKName(colIdx), year_char) != 0) {
-          return RS_SERVER_ERROR(ERROR_023);
+        if (setColValue(year_char) != 0) {
+          return RS_SERVER_ERROR(setErr);
         }
         success = true;
       }
@@ -445,15 +776,31 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     if (!success) {
       return RS_CLIENT_ERROR(
           ERROR_015 + std::string(" Expecting YEAR column. Possible values [1901-2155]. Column: ") +
-          std::string(request->PKName(colIdx)));
+          std::string(colName));
     } else {
       return RS_OK;
     }
   }
   case NdbDictionary::Column::Timestamp: {
     ///< Unix time
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
+    MYSQL_TIME l_time;
+    Int64 epoch = 0;
+    if (!ParseDateTime(valueCStr, valueLen, fmt, &l_time) ||
+        !TimeToEpoch(l_time, fmt.tzOffset, &epoch)) {
+      return RS_CLIENT_ERROR(std::string(ERROR_027) + std::string(" Column: ") +
+                             std::string(col->getName()))
+    }
+
+    if (l_time.second_part != 0) {
+      return RS_CLIENT_ERROR(std::string(ERROR_008) +
+                             " Fractional seconds are not supported. Column: " +
+                             std::string(col->getName()));
+    }
+
+    if (setColValue(static_cast<Uint32>(epoch)) != 0) {
+      return RS_SERVER_ERROR(setErr);
+    }
+    return RS_OK;
   }
   ///**
   // * Time types in MySQL 5.6 add microsecond fraction.
@@ -464,13 +811,8 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
   // */
   case NdbDictionary::Column::Time2: {
     ///< 3 bytes + 0-3 fraction
-    const char *time_str = request->PKValueCStr(colIdx);
-    size_t time_str_len  = request->PKValueLen(colIdx);
-
     MYSQL_TIME l_time;
-    MYSQL_TIME_STATUS status;
-    bool ret = str_to_time(time_str, time_str_len, &l_time, &status, 0);
-    if (ret != 0) {
+    if (!ParseTime(valueCStr, valueLen, fmt, &l_time)) {
       return RS_CLIENT_ERROR(std::string(ERROR_027) + std::string(" Column: ") +
                              std::string(col->getName()))
     }
@@ -482,21 +824,15 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
     longlong numaric_date_time = TIME_to_longlong_time_packed(l_time);
     my_time_packed_to_binary(numaric_date_time, packed, precision);
 
-    if (operation->equal(request->PKName(colIdx), reinterpret_cast<char *>(packed), packed_len) !=
-        0) {
-      return RS_SERVER_ERROR(ERROR_023);
+    if (setColValue(reinterpret_cast<char *>(packed), packed_len) != 0) {
+      return RS_SERVER_ERROR(setErr);
     }
     return RS_OK;
   }
   case NdbDictionary::Column::Datetime2: {
     ///< 5 bytes plus 0-3 fraction
-    const char *date_str = request->PKValueCStr(colIdx);
-    size_t date_str_len  = request->PKValueLen(colIdx);
-
     MYSQL_TIME l_time;
-    MYSQL_TIME_STATUS status;
-    bool ret = str_to_datetime(date_str, date_str_len, &l_time, 0, &status);
-    if (ret != 0) {
+    if (!ParseDateTime(valueCStr, valueLen, fmt, &l_time)) {
       return RS_CLIENT_ERROR(std::string(ERROR_027) + std::string(" Column: ") +
                              std::string(col->getName()))
     }
@@ -509,70 +845,36 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
 
     my_datetime_packed_to_binary(numaric_date_time, packed, precision);
 
-    if (operation->equal(request->PKName(colIdx), reinterpret_cast<char *>(packed), packed_len) !=
-        0) {
-      return RS_SERVER_ERROR(ERROR_023);
+    if (setColValue(reinterpret_cast<char *>(packed), packed_len) != 0) {
+      return RS_SERVER_ERROR(setErr);
     }
     return RS_OK;
   }
   case NdbDictionary::Column::Timestamp2: {
     // epoch range 0 , 2147483647
     /// < 4 bytes + 0-3 fraction
-    const char *ts_str = request->PKValueCStr(colIdx);
-    size_t ts_str_len  = request->PKValueLen(colIdx);
-    size_t packed_len  = col->getSizeInBytes();
+    size_t packed_len = col->getSizeInBytes();
     unsigned char packed[packed_len];
     uint precision = col->getPrecision();
 
     MYSQL_TIME l_time;
-    MYSQL_TIME_STATUS status;
-    bool ret = str_to_datetime(ts_str, ts_str_len, &l_time, 0, &status);
-    if (ret != 0) {
-      return RS_CLIENT_ERROR(std::string(ERROR_027) + std::string(" Column: ") +
-                             std::string(col->getName()))
-    }
-
-    time_t epoch = 0;
-    try {
-      char bts_str[MAX_DATE_STRING_REP_LENGTH];
-      snprintf(bts_str, MAX_DATE_STRING_REP_LENGTH, "%d-%d-%d %d:%d:%d", l_time.year, l_time.month,
-               l_time.day, l_time.hour, l_time.minute, l_time.second);
-      boost::posix_time::ptime bt(boost::posix_time::time_from_string(std::string(bts_str)));
-      boost::posix_time::ptime start(boost::gregorian::date(1970, 1, 1));
-      boost::posix_time::time_duration dur = bt - start;
-      epoch                                = dur.total_seconds();
-    } catch (...) {
+    if (!ParseDateTime(valueCStr, valueLen, fmt, &l_time)) {
       return RS_CLIENT_ERROR(std::string(ERROR_027) + std::string(" Column: ") +
                              std::string(col->getName()))
     }
 
-    // 1970-01-01 00:00:01' UTC to '2038-01-19 03:14:07' UTC.
-    if (epoch <= 0 || epoch > 2147483647) {
+    // the time is in the time zone of the request, UTC by default
+    Int64 epoch = 0;
+    if (!TimeToEpoch(l_time, fmt.tzOffset, &epoch)) {
       return RS_CLIENT_ERROR(std::string(ERROR_027) + std::string(" Column: ") +
                              std::string(col->getName()))
     }
 
-    // TODO(salman) 1 apply timezone changes
-    // https://dev.mysql.com/doc/refman/8.0/en/datetime.html
-    // iMySQL converts TIMESTAMP values from the current time zone to UTC for storage, and back from
-    // UTC to the current time zone for retrieval. (This does not occur for other types such as
-    // DATETIME.) By default, the current time zone for each connection is the server's time. The
-    // time zone can be set on a per-connection basis. As long as the time zone setting remains
-    // constant, you get back the same value you store. If you store a TIMESTAMP value, and then
-    // change the time zone and retrieve the value, the retrieved value is different from the value
-    // you stored. This occurs because the same time zone was not used for conversion in both
-    // directions. The current time zone is available as the value of the time_zone system variable.
-    // For more information, see Section 5.1.15, “MySQL Server Time Zone Support”.
-    // TODO(salman) 2 Investigate how clusterj inserts time stamps. Does it apply time zone changes
-    // TODO(salman) how to deal with time zone setting in mysql server
-    //
-
     my_timeval my_tv{epoch, (Int64)l_time.second_part};
     my_timestamp_to_binary(&my_tv, packed, precision);
 
-    if (operation->equal(request->PKName(colIdx), reinterpret_cast<char *>(packed), packed_len) !=
-        0) {
-      return RS_SERVER_ERROR(ERROR_023);
+    if (setColValue(reinterpret_cast<char *>(packed), packed_len) != 0) {
+      return RS_SERVER_ERROR(setErr);
     }
     return RS_OK;
   }
@@ -580,10 +882,218 @@ RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *oper
   return RS_OK;
 }
 
-RS_Status WriteColToRespBuff(const NdbRecAttr *attr, PKRResponse *response, bool appendComma) {
+/**
+ * Encode bytes as a base64 or hex string
+ */
+static std::string EncodeBytes(const char *data, size_t len, DataReturnType drt) {
+  if (drt == HEX_DRT) {
+    static const char digits[] = "0123456789abcdef";
+    std::string hex(len * 2, 0);
+    for (size_t i = 0; i < len; i++) {
+      unsigned char c = static_cast<unsigned char>(data[i]);
+      hex[2 * i]      = digits[c >> 4];
+      hex[2 * i + 1]  = digits[c & 0x0F];
+    }
+    return hex;
+  }
+
+  std::string encoded(boost::beast::detail::base64::encoded_size(len), 0);
+  size_t ret = boost::beast::detail::base64::encode(&encoded[0], data, len);
+  encoded.resize(ret);
+  return encoded;
+}
+
+/**
+ * Bytes of a bit column. NDB stores the bits in little-endian words, the
+ * bytes are returned most significant byte first
+ */
+static std::string GetBitBytes(const NdbRecAttr *attr) {
+  Uint32 words = attr->getColumn()->getLength() / 8;
+  if (attr->getColumn()->getLength() % 8 != 0) {
+    words += 1;
+  }
+
+  // change endieness
+  std::string reversed(words, 0);
+  int i = 0;
+  for (int j = words - 1; j >= 0; j--) {
+    reversed[i++] = attr->aRef()[j];
+  }
+  return reversed;
+}
+
+bool IsReturnTypeSupported(const NdbDictionary::Column *col, DataReturnType drt) {
+  if (drt == DEFAULT_DRT) {
+    return true;
+  }
+
+  switch (col->getType()) {
+  case NdbDictionary::Column::Char:
+  case NdbDictionary::Column::Varchar:
+  case NdbDictionary::Column::Longvarchar:
+  case NdbDictionary::Column::Binary:
+  case NdbDictionary::Column::Varbinary:
+  case NdbDictionary::Column::Longvarbinary:
+  case NdbDictionary::Column::Bit:
+  case NdbDictionary::Column::Blob:
+  case NdbDictionary::Column::Text:
+    return drt == BASE64_DRT || drt == HEX_DRT;
+  case NdbDictionary::Column::Bigint:
+  case NdbDictionary::Column::Bigunsigned:
+  case NdbDictionary::Column::Olddecimal:
+  case NdbDictionary::Column::Olddecimalunsigned:
+  case NdbDictionary::Column::Decimal:
+  case NdbDictionary::Column::Decimalunsigned:
+    return drt == STRING_DRT;
+  default:
+    return false;
+  }
+}
+
+DataReturnType GetReturnType(PKRRequest *request, Uint32 n, const NdbDictionary::Column *col) {
+  if (request->ReadColumnsCount() > 0 && request->ReadColumnReturnType(n) != DEFAULT_DRT) {
+    return request->ReadColumnReturnType(n);
+  }
+
+  if ((request->ResponseFlags() & RDRS_RF_BIG_NUMBERS_AS_STRINGS) != 0 &&
+      IsReturnTypeSupported(col, STRING_DRT)) {
+    return STRING_DRT;
+  }
+  return DEFAULT_DRT;
+}
+
+bool GetTrimPadding(PKRRequest *request, Uint32 n, const NdbDictionary::Column *col) {
+  if (col->getType() != NdbDictionary::Column::Char &&
+      col->getType() != NdbDictionary::Column::Binary) {
+    return false;
+  }
+
+  if (request->ReadColumnsCount() > 0 &&
+      request->ReadColumnTrimPadding(n) != RDRS_TRIM_DEFAULT) {
+    return request->ReadColumnTrimPadding(n) == RDRS_TRIM_PADDING;
+  }
+  return col->getType() == NdbDictionary::Column::Char ? trimCharPadding : trimBinaryPadding;
+}
+
+/**
+ * BINARY columns are padded with NUL bytes
+ */
+static int TrimNulPadding(const char *data, int bytes) {
+  while (bytes > 0 && data[bytes - 1] == 0) {
+    bytes--;
+  }
+  return bytes;
+}
+
+/**
+ * Write a CHAR or VARCHAR value. The string is converted from the charset
+ * of the column to UTF-8. Trimming removes the trailing spaces
+ */
+static RS_Status WriteString(const char *data, Uint32 len, CHARSET_INFO *cs, bool trimPadding,
+                             ResponseWriter *writer) {
+  std::string wellFormedString;
+  const char *error_pos = ConvertCharset(JSON_CHARSET, cs, data, len, &wellFormedString);
+  if (error_pos) {
+    char printable_buff[32];
+    convert_to_printable(printable_buff, sizeof(printable_buff), error_pos,
+                         data + len - error_pos, cs, 6);
+    return RS_SERVER_ERROR(ERROR_008 + std::string(" Invalid string: ") +
+                           std::string(printable_buff));
+  }
+
+  // remove blank spaces that are padded to the string
+  if (trimPadding) {
+    size_t endpos = wellFormedString.find_last_not_of(" ");
+    wellFormedString.resize(std::string::npos != endpos ? endpos + 1 : 0);
+  }
+  return writer->String(wellFormedString);
+}
+
+MySQLColumnType GetMySQLColumnType(PKRRequest *request, const char *colName) {
+  MySQLColumnType colType;
+  for (Uint32 i = 0; i < request->ColumnTypesCount(); i++) {
+    if (strcmp(request->ColumnTypeName(i), colName) == 0) {
+      colType.type = request->ColumnType(i);
+      request->ColumnTypeLabels(i, &colType.labels);
+      break;
+    }
+  }
+  return colType;
+}
+
+/**
+ * ENUM columns store the number of the label, starting from 1, and SET
+ * columns a bitmap of the labels. Both are stored little-endian
+ */
+static RS_Status WriteEnumOrSetCol(const NdbRecAttr *attr, const MySQLColumnType &colType,
+                                   ResponseWriter *writer) {
+  const NdbDictionary::Column *col = attr->getColumn();
+  const unsigned char *data        = reinterpret_cast<const unsigned char *>(attr->aRef());
+  Uint64 value                     = 0;
+  for (Uint32 i = 0; i < attr->get_size_in_bytes() && i < 8; i++) {
+    value |= static_cast<Uint64>(data[i]) << (8 * i);
+  }
+
+  if (colType.type == RDRS_CT_ENUM) {
+    // 0 is the empty string MySQL stores for invalid values
+    if (value == 0) {
+      return writer->String("");
+    }
+    if (value > colType.labels.size()) {
+      return RS_SERVER_ERROR(ERROR_050 + std::string(" Column: ") + col->getName());
+    }
+    return writer->String(colType.labels[value - 1]);
+  }
+
+  RS_Status status = writer->BeginArray();
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+  for (size_t i = 0; value != 0; i++, value >>= 1) {
+    if ((value & 1) == 0) {
+      continue;
+    }
+    if (i >= colType.labels.size()) {
+      return RS_SERVER_ERROR(ERROR_050 + std::string(" Column: ") + col->getName());
+    }
+    status = writer->String(colType.labels[i]);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  }
+  return writer->EndArray();
+}
+
+RS_Status WriteColToRespBuff(const NdbRecAttr *attr, ResponseWriter *writer, DataReturnType drt,
+                             const TemporalFormat &fmt, const MySQLColumnType &colType,
+                             bool trimPadding) {
   const NdbDictionary::Column *col = attr->getColumn();
   if (attr->isNULL()) {
-    return response->Append_string("null", false, appendComma);
+    return writer->Null();
+  }
+
+  // ENUM and SET columns are stored as CHAR columns
+  if (drt == DEFAULT_DRT && (colType.type == RDRS_CT_ENUM || colType.type == RDRS_CT_SET) &&
+      col->getType() == NdbDictionary::Column::Char) {
+    return WriteEnumOrSetCol(attr, colType, writer);
+  }
+
+  // the bytes of the column as they are stored
+  if (drt == BASE64_DRT || drt == HEX_DRT) {
+    if (col->getType() == NdbDictionary::Column::Bit) {
+      std::string bytes = GetBitBytes(attr);
+      return writer->String(EncodeBytes(bytes.data(), bytes.size(), drt));
+    }
+
+    int attr_bytes;
+    const char *data_start = nullptr;
+    if (GetByteArray(attr, &data_start, &attr_bytes) != 0) {
+      return RS_CLIENT_ERROR(ERROR_019);
+    }
+    if (trimPadding && col->getType() == NdbDictionary::Column::Binary) {
+      attr_bytes = TrimNulPadding(data_start, attr_bytes);
+    }
+    return writer->String(EncodeBytes(data_start, attr_bytes, drt));
   }
 
   switch (col->getType()) {
@@ -593,61 +1103,64 @@ RS_Status WriteColToRespBuff(const NdbRecAttr *attr, PKRResponse *response, bool
   }
   case NdbDictionary::Column::Tinyint: {
     ///< 8 bit. 1 byte signed integer, can be used in array
-    return response->Append_i8(attr->int8_value(), appendComma);
+    return writer->Int(attr->int8_value());
   }
   case NdbDictionary::Column::Tinyunsigned: {
     ///< 8 bit. 1 byte unsigned integer, can be used in array
-    return response->Append_iu8(attr->u_8_value(), appendComma);
+    return writer->Uint(attr->u_8_value());
   }
   case NdbDictionary::Column::Smallint: {
     ///< 16 bit. 2 byte signed integer, can be used in array
-    return response->Append_i16(attr->short_value(), appendComma);
+    return writer->Int(attr->short_value());
   }
   case NdbDictionary::Column::Smallunsigned: {
     ///< 16 bit. 2 byte unsigned integer, can be used in array
-    return response->Append_iu16(attr->u_short_value(), appendComma);
+    return writer->Uint(attr->u_short_value());
   }
   case NdbDictionary::Column::Mediumint: {
     ///< 24 bit. 3 byte signed integer, can be used in array
-    return response->Append_i24(attr->medium_value(), appendComma);
+    return writer->Int(attr->medium_value());
   }
   case NdbDictionary::Column::Mediumunsigned: {
     ///< 24 bit. 3 byte unsigned integer, can be used in array
-    return response->Append_iu24(attr->u_medium_value(), appendComma);
+    return writer->Uint(attr->u_medium_value());
   }
   case NdbDictionary::Column::Int: {
     ///< 32 bit. 4 byte signed integer, can be used in array
-    return response->Append_i32(attr->int32_value(), appendComma);
+    return writer->Int(attr->int32_value());
   }
   case NdbDictionary::Column::Unsigned: {
     ///< 32 bit. 4 byte unsigned integer, can be used in array
-    return response->Append_iu32(attr->u_32_value(), appendComma);
+    return writer->Uint(attr->u_32_value());
   }
   case NdbDictionary::Column::Bigint: {
     ///< 64 bit. 8 byte signed integer, can be used in array
-    return response->Append_i64(attr->int64_value(), appendComma);
+    if (drt == STRING_DRT) {
+      return writer->String(std::to_string(attr->int64_value()));
+    }
+    return writer->Int(attr->int64_value());
   }
   case NdbDictionary::Column::Bigunsigned: {
     ///< 64 Bit. 8 byte signed integer, can be used in array
-    return response->Append_iu64(attr->u_64_value(), appendComma);
+    if (drt == STRING_DRT) {
+      return writer->String(std::to_string(attr->u_64_value()));
+    }
+    return writer->Uint(attr->u_64_value());
   }
   case NdbDictionary::Column::Float: {
     ///< 32-bit float. 4 bytes float, can be used in array
-    return response->Append_f32(attr->float_value(), appendComma);
+    return writer->Float(attr->float_value());
   }
   case NdbDictionary::Column::Double: {
     ///< 64-bit float. 8 byte float, can be used in array
-    return response->Append_d64(attr->double_value(), appendComma);
+    return writer->Double(attr->double_value());
   }
-  case NdbDictionary::Column::Olddecimal: {
+  case NdbDictionary::Column::Olddecimal:
     ///< MySQL < 5.0 signed decimal,  Precision, Scale
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
-  }
+    [[fallthrough]];
   case NdbDictionary::Column::Olddecimalunsigned: {
-    ///< MySQL < 5.0 signed decimal,  Precision, Scale
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
+    std::string decStr = FromOldDecimalStr(attr->aRef(), attr->get_size_in_bytes());
+    return drt == STRING_DRT ? writer->String(decStr) : writer->Decimal(decStr);
   }
   case NdbDictionary::Column::Decimal:
     ///< MySQL >= 5.0 signed decimal,  Precision, Scale
@@ -659,7 +1172,7 @@ RS_Status WriteColToRespBuff(const NdbRecAttr *attr, PKRResponse *response, bool
     void *bin     = attr->aRef();
     int bin_len   = attr->get_size_in_bytes();
     decimal_bin2str(bin, bin_len, precision, scale, decStr, MaxDecimalStrLen);
-    return response->Append_string(decStr, false, appendComma);
+    return drt == STRING_DRT ? writer->String(decStr) : writer->Decimal(decStr);
   }
   case NdbDictionary::Column::Char:
     ///< Len. A fixed array of 1-byte chars
@@ -674,8 +1187,9 @@ RS_Status WriteColToRespBuff(const NdbRecAttr *attr, PKRResponse *response, bool
     if (GetByteArray(attr, &data_start, &attr_bytes) != 0) {
       return RS_CLIENT_ERROR(ERROR_019);
     } else {
-      return response->Append_char(data_start, attr_bytes, attr->getColumn()->getCharset(),
-                                   appendComma);
+      // only CHAR columns are padded
+      return WriteString(data_start, attr_bytes, attr->getColumn()->getCharset(), trimPadding,
+                         writer);
     }
   }
   case NdbDictionary::Column::Binary:
@@ -690,25 +1204,23 @@ RS_Status WriteColToRespBuff(const NdbRecAttr *attr, PKRResponse *response, bool
     if (GetByteArray(attr, &data_start, &attr_bytes) != 0) {
       return RS_CLIENT_ERROR(ERROR_019);
     } else {
-      size_t encoded_str_size = boost::beast::detail::base64::encoded_size(attr_bytes);
-      char buffer[encoded_str_size];
-      size_t ret = boost::beast::detail::base64::encode(reinterpret_cast<void *>(buffer),
-                                                        data_start, attr_bytes);
-      return response->Append_string(std::string(buffer, ret), true, appendComma);
+      if (trimPadding) {
+        attr_bytes = TrimNulPadding(data_start, attr_bytes);
+      }
+      return writer->Bytes(data_start, attr_bytes);
     }
   }
   case NdbDictionary::Column::Datetime: {
     ///< Precision down to 1 sec (sizeof(Datetime) == 8 bytes )
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
+    MYSQL_TIME l_time;
+    UnpackOldDatetime(attr->u_64_value(), &l_time);
+    return WriteTemporal(l_time, 0, false, fmt, writer);
   }
   case NdbDictionary::Column::Date: {
     ///< Precision down to 1 day(sizeof(Date) == 4 bytes )
-    MYSQL_TIME l_time;
+    MYSQL_TIME l_time = {};
     my_unpack_date(&l_time, attr->aRef());
-    char to[MAX_DATE_STRING_REP_LENGTH];
-    my_date_to_str(l_time, to);
-    return response->Append_string(std::string(to), true, appendComma);
+    return WriteTemporal(l_time, 0, false, fmt, writer);
   }
   case NdbDictionary::Column::Blob: {
     ///< Binary large object (see NdbBlob)
@@ -722,39 +1234,25 @@ RS_Status WriteColToRespBuff(const NdbRecAttr *attr, PKRResponse *response, bool
   }
   case NdbDictionary::Column::Bit: {
     //< Bit, length specifies no of bits
-    int32 attr_bytes = col->getSizeInBytes();
-    Uint32 words     = attr->getColumn()->getLength() / 8;
-    if (attr->getColumn()->getLength() % 8 != 0) {
-      words += 1;
-    }
-
-    // change endieness
-    int i = 0;
-    char reversed[words];
-    for (int j = words - 1; j >= 0; j--) {
-      reversed[i++] = attr->aRef()[j];
-    }
-
-    size_t encoded_str_size = boost::beast::detail::base64::encoded_size(words);
-    char buffer[encoded_str_size];
-    size_t ret =
-        boost::beast::detail::base64::encode(reinterpret_cast<void *>(buffer), reversed, words);
-    return response->Append_string(std::string(buffer, ret), true, appendComma);
+    std::string bytes = GetBitBytes(attr);
+    return writer->Bytes(bytes.data(), bytes.size());
   }
   case NdbDictionary::Column::Time: {
     ///< Time without date
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
+    MYSQL_TIME l_time;
+    UnpackOldTime(attr->aRef(), &l_time);
+    return WriteTemporal(l_time, 0, false, fmt, writer);
   }
   case NdbDictionary::Column::Year: {
     ///< Year 1901-2155 (1 byte)
     Int32 year = (uint)(1900 + attr->aRef()[0]);
-    return response->Append_i32(year, appendComma);
+    return writer->Int(year);
   }
   case NdbDictionary::Column::Timestamp: {
     ///< Unix time
-    return RS_SERVER_ERROR(ERROR_028 + std::string(" Column: ") + std::string(col->getName()) +
-                           " Type: " + std::to_string(col->getType()));
+    MYSQL_TIME l_time;
+    EpochToTime(attr->u_32_value(), 0, fmt.tzOffset, &l_time);
+    return WriteTemporal(l_time, 0, true, fmt, writer);
   }
   ///**
   // * Time types in MySQL 5.6 add microsecond fraction.
@@ -772,11 +1270,7 @@ RS_Status WriteColToRespBuff(const NdbRecAttr *attr, PKRResponse *response, bool
 
     MYSQL_TIME l_time;
     TIME_from_longlong_time_packed(&l_time, numeric_time);
-
-    char to[MAX_DATE_STRING_REP_LENGTH];
-    my_TIME_to_str(l_time, to, precision);
-
-    return response->Append_string(std::string(to), true, appendComma);
+    return WriteTemporal(l_time, precision, false, fmt, writer);
   }
   case NdbDictionary::Column::Datetime2: {
     ///< 5 bytes plus 0-3 fraction
@@ -787,11 +1281,7 @@ RS_Status WriteColToRespBuff(const NdbRecAttr *attr, PKRResponse *response, bool
 
     MYSQL_TIME l_time;
     TIME_from_longlong_datetime_packed(&l_time, numeric_date);
-
-    char to[MAX_DATE_STRING_REP_LENGTH];
-    my_TIME_to_str(l_time, to, precision);
-
-    return response->Append_string(std::string(to), true, appendComma);
+    return WriteTemporal(l_time, precision, false, fmt, writer);
   }
   case NdbDictionary::Column::Timestamp2: {
     ///< 4 bytes + 0-3 fraction
@@ -800,24 +1290,9 @@ RS_Status WriteColToRespBuff(const NdbRecAttr *attr, PKRResponse *response, bool
     my_timeval my_tv{};
     my_timestamp_from_binary(&my_tv, (const unsigned char *)attr->aRef(), precision);
 
-    Int64 epoch_in = my_tv.m_tv_sec;
-    std::time_t stdtime(epoch_in);
-    boost::posix_time::ptime ts = boost::posix_time::from_time_t(stdtime);
-
-    MYSQL_TIME l_time  = {};
-    l_time.year        = ts.date().year();
-    l_time.month       = ts.date().month();
-    l_time.day         = ts.date().day();
-    l_time.hour        = ts.time_of_day().hours();
-    l_time.minute      = ts.time_of_day().minutes();
-    l_time.second      = ts.time_of_day().seconds();
-    l_time.second_part = my_tv.m_tv_usec;
-    l_time.time_type   = MYSQL_TIMESTAMP_DATETIME;
-
-    char to[MAX_DATE_STRING_REP_LENGTH];
-    my_TIME_to_str(l_time, to, precision);
-
-    return response->Append_string(std::string(to), true, appendComma);
+    MYSQL_TIME l_time;
+    EpochToTime(my_tv.m_tv_sec, my_tv.m_tv_usec, fmt.tzOffset, &l_time);
+    return WriteTemporal(l_time, precision, true, fmt, writer);
   }
   }
 
@@ -862,3 +1337,207 @@ int GetByteArray(const NdbRecAttr *attr, const char **first_byte, int *bytes) {
     return -1;
   }
 }
+
+RS_Status SetupReadColumn(NdbOperation *operation, const NdbDictionary::Column *col,
+                          std::vector<NdbRecAttr *> *recs, std::vector<NdbBlob *> *blobs) {
+  if (col->getType() == NdbDictionary::Column::Blob ||
+      col->getType() == NdbDictionary::Column::Text) {
+    NdbBlob *blob = operation->getBlobHandle(col->getName());
+    if (blob == nullptr) {
+      return RS_RONDB_SERVER_ERROR(operation->getNdbError(),
+                                   ERROR_048 + std::string(" Column: ") + col->getName());
+    }
+    recs->push_back(nullptr);
+    blobs->push_back(blob);
+  } else {
+    recs->push_back(operation->getValue(col->getName(), nullptr));
+    blobs->push_back(nullptr);
+  }
+  return RS_OK;
+}
+
+RS_Status IsNullCol(const NdbRecAttr *attr, NdbBlob *blob, bool *isNull) {
+  if (blob == nullptr) {
+    *isNull = attr->isNULL() == 1;
+    return RS_OK;
+  }
+
+  int blobIsNull = 0;
+  if (blob->getNull(blobIsNull) != 0) {
+    return RS_RONDB_SERVER_ERROR(blob->getNdbError(), ERROR_048 + std::string(" Column: ") +
+                                                          blob->getColumn()->getName());
+  }
+  *isNull = blobIsNull == 1;
+  return RS_OK;
+}
+
+void SetMaxBlobReadSize(Uint32 size) {
+  maxBlobReadSize = size;
+}
+
+void SetTrimPadding(bool trimChar, bool trimBinary) {
+  trimCharPadding   = trimChar;
+  trimBinaryPadding = trimBinary;
+}
+
+RS_Status WriteBlobColToRespBuff(NdbBlob *blob, ResponseWriter *writer, DataReturnType drt,
+                                 const MySQLColumnType &colType) {
+  const NdbDictionary::Column *col = blob->getColumn();
+
+  int isNull = 0;
+  if (blob->getNull(isNull) != 0) {
+    return RS_RONDB_SERVER_ERROR(blob->getNdbError(),
+                                 ERROR_048 + std::string(" Column: ") + col->getName());
+  }
+  if (isNull == 1) {
+    return writer->Null();
+  }
+
+  Uint64 length = 0;
+  if (blob->getLength(length) != 0) {
+    return RS_RONDB_SERVER_ERROR(blob->getNdbError(),
+                                 ERROR_048 + std::string(" Column: ") + col->getName());
+  }
+
+  Uint32 maxSize = maxBlobReadSize;
+  if (length > maxSize) {
+    return RS_CLIENT_ERROR(ERROR_047 + std::string(" Column: ") + col->getName() +
+                           " Size: " + std::to_string(length) +
+                           " Max size: " + std::to_string(maxSize));
+  }
+
+  std::string data(length, 0);
+  Uint32 bytes = static_cast<Uint32>(length);
+  if (blob->readData(&data[0], bytes) != 0 || bytes != length) {
+    return RS_RONDB_SERVER_ERROR(blob->getNdbError(),
+                                 ERROR_048 + std::string(" Column: ") + col->getName());
+  }
+
+  if (col->getType() == NdbDictionary::Column::Text && drt == DEFAULT_DRT) {
+    std::string text;
+    if (ConvertCharset(JSON_CHARSET, col->getCharset(), data.data(), data.size(), &text) !=
+        nullptr) {
+      return RS_SERVER_ERROR(ERROR_008 + std::string(" Invalid string. Column: ") +
+                             col->getName());
+    }
+    return writer->String(text);
+  }
+
+  // JSON columns are stored in the MySQL binary JSON format
+  if (colType.type == RDRS_CT_JSON && drt == DEFAULT_DRT) {
+    std::string json;
+    if (!JsonBinaryToText(data.data(), data.size(), &json)) {
+      return RS_SERVER_ERROR(ERROR_050 + std::string(" Column: ") + col->getName());
+    }
+    return writer->JsonDocument(json);
+  }
+
+  if (drt == DEFAULT_DRT) {
+    return writer->Bytes(data.data(), bytes);
+  }
+  return writer->String(EncodeBytes(data.data(), bytes, drt));
+}
+
+std::string ArrowType(const NdbDictionary::Column *col, DataReturnType drt,
+                      const TemporalFormat &fmt, const MySQLColumnType &colType) {
+  // encoded bytes, and numbers returned as strings
+  if (drt != DEFAULT_DRT) {
+    return "u";
+  }
+
+  const bool epochMillis = fmt.format == RDRS_TF_EPOCH_MILLIS;
+  switch (col->getType()) {
+  case NdbDictionary::Column::Tinyint:
+    return "c";
+  case NdbDictionary::Column::Tinyunsigned:
+    return "C";
+  case NdbDictionary::Column::Smallint:
+  case NdbDictionary::Column::Year:
+    return "s";
+  case NdbDictionary::Column::Smallunsigned:
+    return "S";
+  case NdbDictionary::Column::Mediumint:
+  case NdbDictionary::Column::Int:
+    return "i";
+  case NdbDictionary::Column::Mediumunsigned:
+  case NdbDictionary::Column::Unsigned:
+    return "I";
+  case NdbDictionary::Column::Bigint:
+    return "l";
+  case NdbDictionary::Column::Bigunsigned:
+    return "L";
+  case NdbDictionary::Column::Float:
+    return "f";
+  case NdbDictionary::Column::Double:
+    return "g";
+  case NdbDictionary::Column::Olddecimal:
+  case NdbDictionary::Column::Olddecimalunsigned:
+  case NdbDictionary::Column::Decimal:
+  case NdbDictionary::Column::Decimalunsigned: {
+    // 128 bit decimals have up to 38 digits, MySQL decimals up to 65
+    std::string type =
+        "d:" + std::to_string(col->getPrecision()) + "," + std::to_string(col->getScale());
+    return col->getPrecision() > 38 ? type + ",256" : type;
+  }
+  case NdbDictionary::Column::Char:
+    return colType.type == RDRS_CT_SET ? "+l" : "u";
+  case NdbDictionary::Column::Binary:
+  case NdbDictionary::Column::Varbinary:
+  case NdbDictionary::Column::Longvarbinary:
+  case NdbDictionary::Column::Bit:
+    return "z";
+  case NdbDictionary::Column::Blob:
+    return colType.type == RDRS_CT_JSON ? "u" : "z";
+  case NdbDictionary::Column::Date:
+    return epochMillis ? "tdm" : "u";
+  case NdbDictionary::Column::Datetime:
+  case NdbDictionary::Column::Datetime2:
+    return epochMillis ? "tsm:" : "u";
+  case NdbDictionary::Column::Timestamp:
+  case NdbDictionary::Column::Timestamp2:
+    return epochMillis ? "tsm:UTC" : "u";
+  case NdbDictionary::Column::Time:
+  case NdbDictionary::Column::Time2:
+    // TIME values can be negative and longer than a day
+    return epochMillis ? "tDm" : "u";
+  default:
+    return "u";
+  }
+}
+
+RS_Status WriteArrowSchema(PKRRequest *request, const std::vector<NdbRecAttr *> &recs,
+                           const std::vector<NdbBlob *> &blobs, ResponseWriter *writer) {
+  RS_Status status = writer->BeginArray();
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  const TemporalFormat fmt = GetTemporalFormat(request);
+  for (Uint32 i = 0; i < recs.size(); i++) {
+    const NdbDictionary::Column *col =
+        blobs[i] != nullptr ? blobs[i]->getColumn() : recs[i]->getColumn();
+    std::string type = ArrowType(col, GetReturnType(request, i, col), fmt,
+                                 GetMySQLColumnType(request, col->getName()));
+
+    status = writer->BeginObject();
+    if (status.http_code == SUCCESS) {
+      status = writer->Key("name");
+    }
+    if (status.http_code == SUCCESS) {
+      status = writer->String(col->getName());
+    }
+    if (status.http_code == SUCCESS) {
+      status = writer->Key("type");
+    }
+    if (status.http_code == SUCCESS) {
+      status = writer->String(type);
+    }
+    if (status.http_code == SUCCESS) {
+      status = writer->EndObject();
+    }
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  }
+  return writer->EndArray();
+}
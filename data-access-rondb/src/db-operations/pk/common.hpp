@@ -21,9 +21,14 @@
 #define DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_PK_COMMON_H_
 
 #include <NdbDictionary.hpp>
+#include <functional>
+#include <string>
+#include <vector>
+#include "src/rdrs-const.h"
 #include "src/rdrs-dal.h"
 #include "src/db-operations/pk/pkr-request.hpp"
 #include "src/db-operations/pk/pkr-response.hpp"
+#include "src/db-operations/pk/response-writer.hpp"
 
 /**
  * Set up read operation
@@ -37,10 +42,199 @@
 RS_Status SetOperationPKCol(const NdbDictionary::Column *col, NdbOperation *operation,
                             PKRRequest *request, Uint32 colIdx);
 
+/**
+ * Set the value of a non primary key column for write operations
+ *
+ * @return status
+ */
+RS_Status SetOperationValueCol(const NdbDictionary::Column *col, NdbOperation *operation,
+                               PKRRequest *request, Uint32 colIdx);
+
+/**
+ * Validate and bind the value of a column to the operation.
+ * Primary key columns are bound using equal() and others using setValue()
+ *
+ * @return status
+ */
+RS_Status SetOperationCol(const NdbDictionary::Column *col, NdbOperation *operation,
+                          PKRRequest *request, Uint32 colIdx, bool isPK);
+
+/**
+ * Format of the DATE, DATETIME, TIME and TIMESTAMP values of a request
+ */
+typedef struct TemporalFormat {
+  Uint32 format  = RDRS_TF_MYSQL;  // RDRS_TF_*
+  Int32 tzOffset = 0;              // offset of the time zone from UTC in seconds
+} TemporalFormat;
+
+/**
+ * Get the temporal format of the request
+ *
+ * @return temporal format
+ */
+TemporalFormat GetTemporalFormat(PKRRequest *request);
+
+/**
+ * MySQL type of an ENUM, SET or JSON column. NDB does not know these
+ * types, so the REST server gets them from MySQL and sends them with the
+ * request
+ */
+typedef struct MySQLColumnType {
+  Uint32 type = 0;                  // RDRS_CT_*. 0 if the column has the NDB type
+  std::vector<std::string> labels;  // ENUM and SET labels
+} MySQLColumnType;
+
+/**
+ * Get the MySQL type of a column of the request
+ *
+ * @return MySQL type. The type is 0 if the request has no type for the column
+ */
+MySQLColumnType GetMySQLColumnType(PKRRequest *request, const char *colName);
+
+/**
+ * Receives a column value converted to the NDB format
+ *
+ * @return 0 if successfull
+ */
+typedef std::function<int(const char *value, Uint32 len)> ColValueSetter;
+
+/**
+ * Validate a column value sent by the user and convert it to the format
+ * NDB stores the column in. Variable length values are prefixed with their length.
+ *
+ * @param[in] col column
+ * @param[in] valueCStr value as sent by the user
+ * @param[in] valueLen length of the value
+ * @param[in] fmt format of temporal values
+ * @param[in] setErr error message used if the setter fails
+ * @param[in] setter receives the converted value
+ *
+ * @return status
+ */
+RS_Status ConvertColValue(const NdbDictionary::Column *col, const char *valueCStr,
+                          Uint16 valueLen, const TemporalFormat &fmt,
+                          const char *setErr, const ColValueSetter &setter);
+
 /**
  * it stores the data read from the DB into the response buffer
+ *
+ * @param[in] writer writes the value in the format of the response
+ * @param[in] drt data return type. The base64 and hex types return the bytes
+ * of the column as an encoded string
+ * @param[in] fmt format of temporal values
+ * @param[in] colType MySQL type of the column. ENUM columns are returned
+ * as their label and SET columns as an array of labels
+ * @param[in] trimPadding remove the trailing spaces of CHAR columns and
+ * the trailing NUL bytes of BINARY columns
+ */
+RS_Status WriteColToRespBuff(const NdbRecAttr *attr, ResponseWriter *writer, DataReturnType drt,
+                             const TemporalFormat &fmt, const MySQLColumnType &colType,
+                             bool trimPadding);
+
+/**
+ * Check that the data return type can be used for the column. Only the
+ * char, binary, bit and BLOB/TEXT columns support the base64 and hex
+ * return types, and only the 64 bit integer and decimal columns support
+ * the string return type
+ *
+ * @return true if the return type is supported
+ */
+bool IsReturnTypeSupported(const NdbDictionary::Column *col, DataReturnType drt);
+
+/**
+ * Get the data return type of the nth column of the response. Columns
+ * without a return type use the response flags of the request
+ *
+ * @param[in] n index of the read column. All the columns use the default
+ * return type if the request has no read columns
+ *
+ * @return data return type
+ */
+DataReturnType GetReturnType(PKRRequest *request, Uint32 n, const NdbDictionary::Column *col);
+
+/**
+ * Get whether the padding of the n-th read column is trimmed. The read
+ * column can override the server default. Only CHAR and BINARY columns
+ * are padded
+ */
+bool GetTrimPadding(PKRRequest *request, Uint32 n, const NdbDictionary::Column *col);
+
+/**
+ * Add a column to the columns read by the operation. BLOB/TEXT columns are
+ * read using blob handles. For every column either the record or the blob
+ * handle is set, the other one is nullptr
+ *
+ * @return status
+ */
+RS_Status SetupReadColumn(NdbOperation *operation, const NdbDictionary::Column *col,
+                          std::vector<NdbRecAttr *> *recs, std::vector<NdbBlob *> *blobs);
+
+/**
+ * Set the max size of the BLOB/TEXT columns that can be read. Reading a
+ * larger column fails the operation
+ *
+ * @param[in] size max size in bytes
+ */
+void SetMaxBlobReadSize(Uint32 size);
+
+/**
+ * Check if a read column is NULL
+ *
+ * @param[in] attr the column. Not used if blob is set
+ * @param[in] blob the BLOB/TEXT column, or nullptr
+ * @param[out] isNull true if the column is NULL
+ *
+ * @return status
+ */
+RS_Status IsNullCol(const NdbRecAttr *attr, NdbBlob *blob, bool *isNull);
+
+/**
+ * Set whether the padding of CHAR and BINARY columns is trimmed by default
+ *
+ * @param[in] trimChar remove the trailing spaces of CHAR columns
+ * @param[in] trimBinary remove the trailing NUL bytes of BINARY columns
+ */
+void SetTrimPadding(bool trimChar, bool trimBinary);
+
+/**
+ * Read a BLOB/TEXT column and store it in the response buffer. By default
+ * TEXT columns are returned as strings, BLOB columns as bytes and JSON
+ * columns as JSON. JSON responses encode bytes as base64 strings. The blob
+ * can only be read before the transaction is committed
+ *
+ * @return status
+ */
+RS_Status WriteBlobColToRespBuff(NdbBlob *blob, ResponseWriter *writer, DataReturnType drt,
+                                 const MySQLColumnType &colType);
+
+/**
+ * Get the Arrow type of a read column in the format of the Arrow C data
+ * interface, e.g., "i" for int32 or "u" for UTF-8 strings. The type
+ * matches the value that WriteColToRespBuff or WriteBlobColToRespBuff
+ * write for the column. SET columns are lists of UTF-8 labels, "+l"
+ *
+ * @param[in] col column
+ * @param[in] drt data return type
+ * @param[in] fmt format of temporal values
+ * @param[in] colType MySQL type of the column
+ *
+ * @return Arrow format string
+ */
+std::string ArrowType(const NdbDictionary::Column *col, DataReturnType drt,
+                      const TemporalFormat &fmt, const MySQLColumnType &colType);
+
+/**
+ * Write the names and the Arrow types of the read columns of a request as
+ * an array of {"name": ..., "type": ...} objects. The REST server uses
+ * them as the schema of Arrow responses
+ *
+ * @param[in] recs the read columns
+ * @param[in] blobs the BLOB/TEXT read columns, or nullptr for other columns
+ *
+ * @return status
  */
-RS_Status WriteColToRespBuff(const NdbRecAttr *attr, PKRResponse *response, bool appendComma);
+RS_Status WriteArrowSchema(PKRRequest *request, const std::vector<NdbRecAttr *> &recs,
+                           const std::vector<NdbBlob *> &blobs, ResponseWriter *writer);
 
   /**
    * return data for array columns
@@ -20,6 +20,7 @@
 #include "src/db-operations/pk/pkr-operation.hpp"
 #include <mysql_time.h>
 #include <algorithm>
+#include <memory>
 #include <utility>
 #include <NdbDictionary.hpp>
 #include "src/db-operations/pk/pkr-request.hpp"
@@ -42,8 +43,15 @@ PKROperation::PKROperation(RS_Buffer *req_buff, RS_Buffer *resp_buff, Ndb *ndb_o
   this->no_ops     = 1;
 }
 
+PKROperation::PKROperation(RS_Buffer *req_buff, RS_Buffer *resp_buff, Ndb *ndb_object,
+                           NdbTransaction *transaction)
+    : PKROperation(req_buff, resp_buff, ndb_object) {
+  this->transaction  = transaction;
+  this->isExternalTx = true;
+}
+
 PKROperation::PKROperation(Uint32 no_ops, RS_Buffer *req_buffs, RS_Buffer *resp_buffs,
-                           Ndb *ndb_object) {
+                           Ndb *ndb_object, bool atomic) {
 
   this->no_ops = no_ops;
   for (Uint32 i = 0; i < no_ops; i++) {
@@ -52,6 +60,7 @@ PKROperation::PKROperation(Uint32 no_ops, RS_Buffer *req_buffs, RS_Buffer *resp_
   }
   this->ndb_object = ndb_object;
   this->isBatch    = true;
+  this->isAtomic   = atomic;
 }
 
 PKROperation::~PKROperation() {
@@ -71,6 +80,9 @@ PKROperation::~PKROperation() {
  */
 
 RS_Status PKROperation::SetupTransaction() {
+  if (isExternalTx) {
+    return RS_OK;
+  }
   const NdbDictionary::Table *table_dict = all_table_dicts[0];
   transaction                            = ndb_object->startTransaction(table_dict);
   if (transaction == nullptr) {
@@ -80,11 +92,11 @@ RS_Status PKROperation::SetupTransaction() {
 }
 
 /**
- * Set up read operation
+ * Set up read/write operations
  *
  * @return status
  */
-RS_Status PKROperation::SetupReadOperation() {
+RS_Status PKROperation::SetupOperations() {
   if (operations.size() != 0) {
     return RS_CLIENT_ERROR(ERROR_006);
   }
@@ -92,15 +104,43 @@ RS_Status PKROperation::SetupReadOperation() {
   for (size_t i = 0; i < no_ops; i++) {
     PKRRequest *req                        = requests[i];
     const NdbDictionary::Table *table_dict = all_table_dicts[i];
-    NdbOperation *op                       = transaction->getNdbOperation(table_dict);
+    const NdbDictionary::Index *index_dict = all_index_dicts[i];
+    NdbOperation *op                       = nullptr;
+    if (index_dict != nullptr) {
+      op = transaction->getNdbIndexOperation(index_dict);
+    } else {
+      op = transaction->getNdbOperation(table_dict);
+    }
     if (op == nullptr) {
       return RS_RONDB_SERVER_ERROR(transaction->getNdbError(), ERROR_007);
     } else {
       operations.push_back(op);
     }
 
-    if (op->readTuple(NdbOperation::LM_CommittedRead) != 0) {
-      return RS_SERVER_ERROR(ERROR_022)
+    int ret;
+    switch (req->OperationType()) {
+    case RDRS_PK_INSERT_REQ_ID:
+      ret = op->insertTuple();
+      break;
+    case RDRS_PK_UPDATE_REQ_ID:
+      ret = op->updateTuple();
+      break;
+    case RDRS_PK_UPSERT_REQ_ID:
+      ret = op->writeTuple();
+      break;
+    case RDRS_PK_DELETE_REQ_ID:
+      ret = op->deleteTuple();
+      break;
+    default: {
+      NdbOperation::LockMode lock_mode;
+      if (req->ReadLockMode(&lock_mode) != 0) {
+        return RS_CLIENT_ERROR(ERROR_038);
+      }
+      ret = op->readTuple(lock_mode);
+    }
+    }
+    if (ret != 0) {
+      return RS_SERVER_ERROR(req->IsWriteOperation() ? ERROR_031 : ERROR_022)
     }
 
     for (Uint32 i = 0; i < req->PKColumnsCount(); i++) {
@@ -111,10 +151,33 @@ RS_Status PKROperation::SetupReadOperation() {
     }
 
     std::vector<NdbRecAttr *> recs;
+    std::vector<NdbBlob *> blobs;
+    if (req->IsWriteOperation()) {
+      // failure of one write operation, e.g., duplicate key, should not
+      // fail other operations in the same transaction unless the batch is atomic
+      if (!isAtomic && op->setAbortOption(NdbOperation::AO_IgnoreError) != 0) {
+        return RS_RONDB_SERVER_ERROR(op->getNdbError(), ERROR_031);
+      }
+
+      for (Uint32 i = 0; i < req->ValuesCount(); i++) {
+        RS_Status status =
+            SetOperationValueCol(table_dict->getColumn(req->ValueName(i)), op, req, i);
+        if (status.http_code != SUCCESS) {
+          return status;
+        }
+      }
+      all_recs.push_back(recs);
+      all_blobs.push_back(blobs);
+      continue;
+    }
+
     if (req->ReadColumnsCount() > 0) {
       for (Uint32 i = 0; i < req->ReadColumnsCount(); i++) {
-        NdbRecAttr *rec = op->getValue(req->ReadColumnName(i), nullptr);
-        recs.push_back(rec);
+        RS_Status status =
+            SetupReadColumn(op, table_dict->getColumn(req->ReadColumnName(i)), &recs, &blobs);
+        if (status.http_code != SUCCESS) {
+          return status;
+        }
       }
     } else {
       std::unordered_map<std::string, const NdbDictionary::Column *> non_pk_cols =
@@ -122,149 +185,319 @@ RS_Status PKROperation::SetupReadOperation() {
       std::unordered_map<std::string, const NdbDictionary::Column *>::const_iterator it =
           non_pk_cols.begin();
       while (it != non_pk_cols.end()) {
-        NdbRecAttr *rec = op->getValue(it->first.c_str(), nullptr);
+        RS_Status status = SetupReadColumn(op, it->second, &recs, &blobs);
+        if (status.http_code != SUCCESS) {
+          return status;
+        }
         it++;
-        recs.push_back(rec);
       }
     }
+
+    for (NdbBlob *blob : blobs) {
+      hasBlobs = hasBlobs || blob != nullptr;
+    }
     all_recs.push_back(recs);
+    all_blobs.push_back(blobs);
   }
 
   return RS_OK;
 }
 
 RS_Status PKROperation::Execute() {
-  if (transaction->execute(NdbTransaction::Commit) != 0) {
+  // blobs can only be read before the transaction is committed. See Commit()
+  NdbTransaction::ExecType execType =
+      isExternalTx || hasBlobs ? NdbTransaction::NoCommit : NdbTransaction::Commit;
+  if (transaction->execute(execType) != 0) {
+    if (isAtomic) {
+      return AtomicBatchError();
+    }
     return RS_RONDB_SERVER_ERROR(transaction->getNdbError(), ERROR_009);
   }
 
   return RS_OK;
 }
 
+RS_Status PKROperation::Commit() {
+  if (isExternalTx || !hasBlobs) {
+    return RS_OK;
+  }
+
+  if (transaction->execute(NdbTransaction::Commit) != 0) {
+    if (isAtomic) {
+      return AtomicBatchError();
+    }
+    return RS_RONDB_SERVER_ERROR(transaction->getNdbError(), ERROR_009);
+  }
+  return RS_OK;
+}
+
+RS_Status PKROperation::AtomicBatchError() {
+  const NdbError &error = transaction->getNdbError();
+  std::string msg       = std::string(ERROR_033);
+
+  // find the operation that caused the transaction to abort
+  const NdbOperation *failedOp = transaction->getNdbErrorOperation();
+  for (size_t i = 0; i < operations.size(); i++) {
+    if (operations[i] == failedOp) {
+      msg += " Operation: " + std::to_string(i);
+      if (requests[i]->OperationId() != nullptr) {
+        msg += " Operation ID: " + std::string(requests[i]->OperationId());
+      }
+      break;
+    }
+  }
+  msg += " Error: " + std::string(error.message);
+
+  if (error.classification == NdbError::NoDataFound) {
+    return RS_CLIENT_404_WITH_MSG_ERROR(msg);
+  } else if (error.classification == NdbError::ConstraintViolation) {
+    return RS_CLIENT_409_ERROR(msg);
+  }
+  return RS_RONDB_SERVER_ERROR(error, msg);
+}
+
+Int32 PKROperation::OpStatusCode(PKRRequest *req, const NdbOperation *op) {
+  const NdbError &error = op->getNdbError();
+  if (error.classification == NdbError::NoDataFound) {
+    return NOT_FOUND;
+  }
+
+  if (req->IsWriteOperation() && error.code != 0) {
+    if (error.classification == NdbError::ConstraintViolation) {
+      return CONFLICT;
+    }
+    return SERVER_ERROR;
+  }
+  return SUCCESS;
+}
+
 RS_Status PKROperation::CreateResponse() {
-  bool found = true;
+  Int32 code = SUCCESS;
   for (size_t i = 0; i < no_ops; i++) {
     PKRRequest *req                = requests[i];
     PKRResponse *resp              = responses[i];
     const NdbOperation *op         = operations[i];
     std::vector<NdbRecAttr *> recs = all_recs[i];
+    std::vector<NdbBlob *> blobs   = all_blobs[i];
 
-    found = true;
-    if (op->getNdbError().classification == NdbError::NoDataFound) {
-      found = false;
-    }
+    code       = OpStatusCode(req, op);
+    bool found = code != NOT_FOUND;
 
-    // iterate over all columns
-    RS_Status ret;
-    ret = resp->Append_string("{", false, false);
+    // the operations of a batch can ask for different formats
+    RS_Status ret = AppendResponse(found, code, req, resp, op, &recs, &blobs);
     if (ret.http_code != SUCCESS) {
       return ret;
     }
+  }
 
-    // Append status
-    if (isBatch) {
-      ret = AppendStatus(req, resp, found ? SUCCESS : NOT_FOUND);
-      if (ret.http_code != SUCCESS) {
-        return ret;
-      }
+  if (!isBatch) {
+    if (code == NOT_FOUND) {
+      return RS_CLIENT_404_ERROR();
+    } else if (code == CONFLICT) {
+      return RS_CLIENT_409_ERROR(operations[0]->getNdbError().message);
+    } else if (code != SUCCESS) {
+      return RS_RONDB_SERVER_ERROR(operations[0]->getNdbError(), ERROR_009);
+    }
+  }
+  return RS_OK;
+}
 
-      ret = resp->Append_string("\"body\": {", false, false);
-      if (ret.http_code != SUCCESS) {
-        return ret;
-      }
+RS_Status PKROperation::AppendResponse(bool found, Int32 code, PKRRequest *req,
+                                       PKRResponse *resp, const NdbOperation *op,
+                                       std::vector<NdbRecAttr *> *recs,
+                                       std::vector<NdbBlob *> *blobs) {
+  std::unique_ptr<ResponseWriter> writer = NewResponseWriter(req->ResponseFormat(), resp);
+
+  RS_Status ret = writer->Start();
+  if (ret.http_code != SUCCESS) {
+    return ret;
+  }
+
+  ret = writer->BeginObject();
+  if (ret.http_code != SUCCESS) {
+    return ret;
+  }
+
+  // Append status
+  if (isBatch) {
+    ret = AppendStatus(req, writer.get(), code);
+    if (ret.http_code != SUCCESS) {
+      return ret;
     }
 
-    // Append Operation ID
-    ret = AppendOpId(req, resp);
+    ret = writer->Key("body");
     if (ret.http_code != SUCCESS) {
       return ret;
     }
 
-    ret = AppendOpRecs(found, req, resp, &recs);
+    ret = writer->BeginObject();
     if (ret.http_code != SUCCESS) {
       return ret;
     }
+  }
 
-    if (isBatch) {
-      ret = resp->Append_string("}", false, false);
+  ret = AppendOpId(req, writer.get());
+  if (ret.http_code != SUCCESS) {
+    return ret;
+  }
+
+  if (req->IsWriteOperation()) {
+    // write operations do not return any data
+    if (code != SUCCESS) {
+      ret = AppendOpError(op, writer.get());
       if (ret.http_code != SUCCESS) {
         return ret;
       }
     }
+  } else {
+    ret = AppendOpRecs(found, req, writer.get(), recs, blobs);
+    if (ret.http_code != SUCCESS) {
+      return ret;
+    }
+  }
 
-    resp->Append_string("} ", false, false);
-    resp->Append_NULL();
+  if (isBatch) {
+    ret = writer->EndObject();
+    if (ret.http_code != SUCCESS) {
+      return ret;
+    }
   }
 
-  if (!found && !isBatch) {
-    return RS_CLIENT_404_ERROR();
+  ret = writer->EndObject();
+  if (ret.http_code != SUCCESS) {
+    return ret;
   }
-  return RS_OK;
+
+  return writer->Finish();
 }
 
-RS_Status PKROperation::AppendOpRecs(bool found, PKRRequest *req, PKRResponse *resp,
-                                     std::vector<NdbRecAttr *> *recs) {
-  if (!found) {
-    RS_Status status = resp->Append_string("\"data\": null", false, false);
+RS_Status PKROperation::AppendOpRecs(bool found, PKRRequest *req, ResponseWriter *writer,
+                                     std::vector<NdbRecAttr *> *recs,
+                                     std::vector<NdbBlob *> *blobs) {
+  RS_Status status = RS_OK;
+  if (req->ResponseFormat() == RDRS_FORMAT_ARROW) {
+    // the schema is also needed when the row is not found
+    status = writer->Key("schema");
+    if (status.http_code == SUCCESS) {
+      status = WriteArrowSchema(req, *recs, *blobs, writer);
+    }
     if (status.http_code != SUCCESS) {
       return status;
     }
-  } else {
-    RS_Status status = resp->Append_string("\"data\": {", false, false);
+  }
+
+  status = writer->Key("data");
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  if (!found) {
+    return writer->Null();
+  }
+
+  const bool nullMask = (req->ResponseFlags() & RDRS_RF_INCLUDE_NULL_MASK) != 0;
+  std::vector<std::string> nullCols;
+  status = AppendCols(req, writer, recs, blobs, &nullCols);
+  if (status.http_code != SUCCESS || !nullMask) {
+    return status;
+  }
+
+  status = writer->Key("nullColumns");
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+  status = writer->BeginArray();
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+  for (const std::string &name : nullCols) {
+    status = writer->String(name);
     if (status.http_code != SUCCESS) {
       return status;
     }
+  }
+  return writer->EndArray();
+}
 
-    for (Uint32 i = 0; i < recs->size(); i++) {
-      status = resp->Append_string(std::string("\"") + (*recs)[i]->getColumn()->getName() +
-                                       std::string("\":"),
-                                   false, false);
-      if (status.http_code != SUCCESS) {
-        return status;
-      }
+RS_Status PKROperation::AppendCols(PKRRequest *req, ResponseWriter *writer,
+                                   std::vector<NdbRecAttr *> *recs, std::vector<NdbBlob *> *blobs,
+                                   std::vector<std::string> *nullCols) {
+  RS_Status status = writer->BeginRow();
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
 
-      status = WriteColToRespBuff((*recs)[i], resp, i == (recs->size() - 1) ? false : true);
-      if (status.http_code != SUCCESS) {
-        return status;
+  const bool omitNulls = (req->ResponseFlags() & RDRS_RF_OMIT_NULLS) != 0;
+  for (Uint32 i = 0; i < recs->size(); i++) {
+    NdbBlob *blob = (*blobs)[i];
+    const NdbDictionary::Column *col =
+        blob != nullptr ? blob->getColumn() : (*recs)[i]->getColumn();
+
+    bool isNull = false;
+    status      = IsNullCol((*recs)[i], blob, &isNull);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    if (isNull) {
+      nullCols->push_back(col->getName());
+      if (omitNulls) {
+        continue;
       }
     }
 
-    resp->Append_string("} ", false, false);
+    status = writer->Key(col->getName());
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+
+    DataReturnType drt      = GetReturnType(req, i, col);
+    MySQLColumnType colType = GetMySQLColumnType(req, col->getName());
+    if (blob != nullptr) {
+      status = WriteBlobColToRespBuff(blob, writer, drt, colType);
+    } else {
+      status = WriteColToRespBuff((*recs)[i], writer, drt, GetTemporalFormat(req), colType,
+                                  GetTrimPadding(req, i, col));
+    }
     if (status.http_code != SUCCESS) {
       return status;
     }
   }
 
-  return RS_OK;
+  return writer->EndRow();
 }
 
-RS_Status PKROperation::AppendOpId(PKRRequest *req, PKRResponse *resp) {
+RS_Status PKROperation::AppendOpId(PKRRequest *req, ResponseWriter *writer) {
   if (req->OperationId() != nullptr) {
-    RS_Status ret = resp->Append_string("\"operationId\": ", false, false);
-    if (ret.http_code != SUCCESS) {
-      return ret;
-    }
-    ret = resp->Append_string(std::string("\"") + req->OperationId() + std::string("\""), false,
-                              true);
+    RS_Status ret = writer->Key("operationId");
     if (ret.http_code != SUCCESS) {
       return ret;
     }
+    return writer->String(req->OperationId());
   }
   return RS_OK;
 }
 
-RS_Status PKROperation::AppendStatus(PKRRequest *req, PKRResponse *resp, Int32 code) {
-  if (req->OperationId() != nullptr) {
-    RS_Status ret = resp->Append_string("\"code\": ", false, false);
-    if (ret.http_code != SUCCESS) {
-      return ret;
-    }
-    ret = resp->Append_i32(code, true);
-    if (ret.http_code != SUCCESS) {
-      return ret;
-    }
+RS_Status PKROperation::AppendStatus(PKRRequest *req, ResponseWriter *writer, Int32 code) {
+  // JSON responses only have the code if the operation has an ID. The binary
+  // formats always have it, e.g., the Arrow batch response and the gRPC API
+  // need it
+  if (req->OperationId() == nullptr && req->ResponseFormat() == RDRS_FORMAT_JSON) {
+    return RS_OK;
   }
-  return RS_OK;
+
+  RS_Status ret = writer->Key("code");
+  if (ret.http_code != SUCCESS) {
+    return ret;
+  }
+  return writer->Int(code);
+}
+
+RS_Status PKROperation::AppendOpError(const NdbOperation *op, ResponseWriter *writer) {
+  RS_Status ret = writer->Key("error");
+  if (ret.http_code != SUCCESS) {
+    return ret;
+  }
+  return writer->String(op->getNdbError().message);
 }
 
 RS_Status PKROperation::Init() {
@@ -287,10 +520,35 @@ RS_Status PKROperation::Init() {
     }
     all_table_dicts.push_back(table_dict);
 
-    // get all primary key columnns
-    for (int i = 0; i < table_dict->getNoOfPrimaryKeys(); i++) {
-      const char *priName           = table_dict->getPrimaryKey(i);
-      pk_cols[std::string(priName)] = table_dict->getColumn(priName);
+    const NdbDictionary::Index *index_dict = nullptr;
+    if (req->IndexName() != nullptr) {
+      // MySQL stores unique indexes as <name>$unique in NDB
+      std::string uniqueName = std::string(req->IndexName()) + "$unique";
+      index_dict             = dict->getIndex(uniqueName.c_str(), req->Table());
+      if (index_dict == nullptr) {
+        index_dict = dict->getIndex(req->IndexName(), req->Table());
+      }
+      if (index_dict == nullptr) {
+        return RS_CLIENT_ERROR(ERROR_043 + std::string(" Index: ") + req->IndexName());
+      }
+      if (index_dict->getType() != NdbDictionary::Index::UniqueHashIndex) {
+        return RS_CLIENT_ERROR(ERROR_046 + std::string(" Index: ") + req->IndexName());
+      }
+    }
+    all_index_dicts.push_back(index_dict);
+
+    if (index_dict != nullptr) {
+      // get all unique index columns
+      for (unsigned i = 0; i < index_dict->getNoOfColumns(); i++) {
+        const char *colName           = index_dict->getColumn(i)->getName();
+        pk_cols[std::string(colName)] = table_dict->getColumn(colName);
+      }
+    } else {
+      // get all primary key columnns
+      for (int i = 0; i < table_dict->getNoOfPrimaryKeys(); i++) {
+        const char *priName           = table_dict->getPrimaryKey(i);
+        pk_cols[std::string(priName)] = table_dict->getColumn(priName);
+      }
     }
 
     // get all non primary key columnns
@@ -316,7 +574,6 @@ RS_Status PKROperation::ValidateRequest() {
     PKRRequest *req                                                            = requests[i];
     std::unordered_map<std::string, const NdbDictionary::Column *> pk_cols     = all_pk_cols[i];
     std::unordered_map<std::string, const NdbDictionary::Column *> non_pk_cols = all_non_pk_cols[i];
-    const NdbDictionary::Table *table_dict                                     = all_table_dicts[i];
 
     if (req->PKColumnsCount() != pk_cols.size()) {
       return RS_CLIENT_ERROR(ERROR_013 + std::string(" Expecting: ") +
@@ -335,8 +592,23 @@ RS_Status PKROperation::ValidateRequest() {
     // Check non primary key columns
     // check that all columns exist
     // check that data return type is supported
-    // check for reading blob columns
-    if (req->ReadColumnsCount() > 0) {
+    // check for writing blob columns
+    if (req->IsWriteOperation()) {
+      for (Uint32 i = 0; i < req->ValuesCount(); i++) {
+        std::unordered_map<std::string, const NdbDictionary::Column *>::const_iterator got =
+            non_pk_cols.find(std::string(req->ValueName(i)));
+        if (got == non_pk_cols.end()) {  // not found
+          return RS_CLIENT_ERROR(ERROR_012 + std::string(" Column: ") +
+                                 std::string(req->ValueName(i)));
+        }
+
+        NdbDictionary::Column::Type type = got->second->getType();
+        if (type == NdbDictionary::Column::Blob || type == NdbDictionary::Column::Text) {
+          return RS_CLIENT_ERROR(ERROR_030 + std::string(" Column: ") +
+                                 std::string(req->ValueName(i)));
+        }
+      }
+    } else if (req->ReadColumnsCount() > 0) {
       for (Uint32 i = 0; i < req->ReadColumnsCount(); i++) {
         std::unordered_map<std::string, const NdbDictionary::Column *>::const_iterator got =
             non_pk_cols.find(std::string(req->ReadColumnName(i)));
@@ -346,32 +618,16 @@ RS_Status PKROperation::ValidateRequest() {
         }
 
         // check that the data return type is supported
-        // for now we only support DataReturnType.DEFAULT
         if (req->ReadColumnReturnType(i) > __MAX_TYPE_NOT_A_DRT ||
-            DEFAULT_DRT != req->ReadColumnReturnType(i)) {
+            req->ReadColumnReturnType(i) < DEFAULT_DRT) {
           return RS_SERVER_ERROR(ERROR_025 + std::string(" Column: ") +
                                  std::string(req->ReadColumnName(i)));
         }
-
-        if (table_dict->getColumn(req->ReadColumnName(i))->getType() ==
-                NdbDictionary::Column::Blob ||
-            table_dict->getColumn(req->ReadColumnName(i))->getType() ==
-                NdbDictionary::Column::Text) {
-          return RS_SERVER_ERROR(ERROR_026 + std::string(" Column: ") +
+        if (!IsReturnTypeSupported(got->second, req->ReadColumnReturnType(i))) {
+          return RS_CLIENT_ERROR(ERROR_025 + std::string(" Column: ") +
                                  std::string(req->ReadColumnName(i)));
         }
       }
-    } else {
-      // user wants to read all columns. make sure that we are not reading Blobs
-      std::unordered_map<std::string, const NdbDictionary::Column *>::const_iterator it =
-          non_pk_cols.begin();
-      while (it != non_pk_cols.end()) {
-        NdbDictionary::Column::Type type = it->second->getType();
-        if (type == NdbDictionary::Column::Blob || type == NdbDictionary::Column::Text) {
-          return RS_SERVER_ERROR(ERROR_026 + std::string(" Column: ") + it->first);
-        }
-        it++;
-      }
     }
   }
 
@@ -379,6 +635,9 @@ RS_Status PKROperation::ValidateRequest() {
 }
 
 void PKROperation::CloseTransaction() {
+  if (isExternalTx) {
+    return;
+  }
   ndb_object->closeTransaction(transaction);
 }
 
@@ -399,14 +658,16 @@ RS_Status PKROperation::PerformOperation() {
     return status;
   }
 
-  status = SetupReadOperation();
+  status = SetupOperations();
   if (status.http_code != SUCCESS) {
+    txFailed = isExternalTx;
     this->Abort();
     return status;
   }
 
   status = Execute();
   if (status.http_code != SUCCESS) {
+    txFailed = isExternalTx;
     this->Abort();
     return status;
   }
@@ -417,12 +678,23 @@ RS_Status PKROperation::PerformOperation() {
     return status;
   }
 
+  status = Commit();
+  if (status.http_code != SUCCESS) {
+    this->Abort();
+    return status;
+  }
+
   CloseTransaction();
   return RS_OK;
 }
 
+bool PKROperation::TxFailed() {
+  return txFailed;
+}
+
 RS_Status PKROperation::Abort() {
-  if (transaction != nullptr) {
+  // explicit transactions are rolled back by the TxManager, see TxFailed()
+  if (transaction != nullptr && !isExternalTx) {
     NdbTransaction::CommitStatusType status = transaction->commitStatus();
     if (status == NdbTransaction::CommitStatusType::Started) {
       transaction->execute(NdbTransaction::Rollback);
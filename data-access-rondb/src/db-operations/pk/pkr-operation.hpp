@@ -26,6 +26,7 @@
 #include <NdbApi.hpp>
 #include "src/db-operations/pk/pkr-request.hpp"
 #include "src/db-operations/pk/pkr-response.hpp"
+#include "src/db-operations/pk/response-writer.hpp"
 #include "src/rdrs-dal.h"
 
 class PKROperation {
@@ -34,19 +35,34 @@ class PKROperation {
   NdbTransaction *transaction = nullptr;
   Ndb *ndb_object             = nullptr;
   bool isBatch = false;
+  bool isAtomic = false;
+  bool isExternalTx = false;  // transaction is owned by the TxManager
+  bool txFailed     = false;  // the explicit transaction can not be used any more
 
   std::vector<PKRRequest *> requests;
   std::vector<PKRResponse *> responses;
   std::vector<NdbOperation *> operations;
   std::vector<std::vector<NdbRecAttr *>> all_recs;  // records that will be read from DB
+  std::vector<std::vector<NdbBlob *>> all_blobs;     // BLOB/TEXT columns. nullptr for other columns
+  bool hasBlobs = false;  // blobs are read before the transaction is committed
   std::vector<const NdbDictionary::Table *> all_table_dicts;
+  std::vector<const NdbDictionary::Index *> all_index_dicts;  // nullptr for primary key operations
+  // key columns are the columns of the unique index for unique reads
   std::vector<std::unordered_map<std::string, const NdbDictionary::Column *>> all_non_pk_cols;
   std::vector<std::unordered_map<std::string, const NdbDictionary::Column *>> all_pk_cols;
 
  public:
   PKROperation(RS_Buffer *req_buff, RS_Buffer *resp_buff, Ndb *ndb_object);
 
-  PKROperation(Uint32 noOps, RS_Buffer *req_buffs, RS_Buffer *resp_buffs, Ndb *ndb_object);
+  /**
+   * Operation that is part of an explicit transaction. The operation is
+   * executed without committing and the transaction is left open
+   */
+  PKROperation(RS_Buffer *req_buff, RS_Buffer *resp_buff, Ndb *ndb_object,
+               NdbTransaction *transaction);
+
+  PKROperation(Uint32 noOps, RS_Buffer *req_buffs, RS_Buffer *resp_buffs, Ndb *ndb_object,
+               bool atomic = false);
 
   ~PKROperation();
 
@@ -55,6 +71,13 @@ class PKROperation {
    */
   RS_Status PerformOperation();
 
+  /**
+   * The operation of an explicit transaction failed after it was defined
+   * in the transaction. The transaction must be rolled back as it has
+   * operations that were only partly defined or it was aborted by RonDB
+   */
+  bool TxFailed();
+
  private:
   /**
    * start a transaction
@@ -64,10 +87,10 @@ class PKROperation {
   RS_Status SetupTransaction();
 
   /**
-   * setup pk read operation
+   * setup pk read/write operations
    * @returns status
    */
-  RS_Status SetupReadOperation();
+  RS_Status SetupOperations();
 
   /**
    * Set primary key column values
@@ -82,6 +105,21 @@ class PKROperation {
    */
   RS_Status Execute();
 
+  /**
+   * Commit the transaction if it was not committed when it was executed.
+   * Transactions that read blobs are committed after the response is created
+   *
+   * @return status
+   */
+  RS_Status Commit();
+
+  /**
+   * Create error status for a failed atomic batch
+   *
+   * @return status
+   */
+  RS_Status AtomicBatchError();
+
   /**
    * Close transaction
    */
@@ -111,23 +149,52 @@ class PKROperation {
    */
   RS_Status ValidateRequest();
 
+  /**
+   * Append the response of an operation in the format of the request
+   * @return status
+   */
+  RS_Status AppendResponse(bool found, Int32 code, PKRRequest *req, PKRResponse *resp,
+                           const NdbOperation *op, std::vector<NdbRecAttr *> *recs,
+                           std::vector<NdbBlob *> *blobs);
+
+  /**
+   * Append the columns of a row as an object
+   * @param[out] nullCols the names of the NULL columns
+   * @return status
+   */
+  RS_Status AppendCols(PKRRequest *req, ResponseWriter *writer, std::vector<NdbRecAttr *> *recs,
+                       std::vector<NdbBlob *> *blobs, std::vector<std::string> *nullCols);
+
   /**
    * Append operation records to response buffer 
    * @return status
    */
-  RS_Status AppendOpRecs(bool found, PKRRequest *req, PKRResponse *resp,  std::vector<NdbRecAttr *> *recs);
+  RS_Status AppendOpRecs(bool found, PKRRequest *req, ResponseWriter *writer,
+                         std::vector<NdbRecAttr *> *recs, std::vector<NdbBlob *> *blobs);
   
   /**
    * Append operation ID to response buffer 
    * @return status
    */
-  RS_Status AppendOpId(PKRRequest *req, PKRResponse *resp);
+  RS_Status AppendOpId(PKRRequest *req, ResponseWriter *writer);
 
   /**
    * Append status of the operation to response buffer 
    * @return status
    */
-  RS_Status AppendStatus(PKRRequest *req, PKRResponse *resp, Int32 code);
+  RS_Status AppendStatus(PKRRequest *req, ResponseWriter *writer, Int32 code);
+
+  /**
+   * Append error message of a failed write operation to response buffer
+   * @return status
+   */
+  RS_Status AppendOpError(const NdbOperation *op, ResponseWriter *writer);
+
+  /**
+   * Get HTTP status code for the executed operation
+   * @return status code
+   */
+  Int32 OpStatusCode(PKRRequest *req, const NdbOperation *op);
 
 };
 #endif  // DATA_ACCESS_RONDB_SRC_PK_READ_PKR_OPERATION_HPP_
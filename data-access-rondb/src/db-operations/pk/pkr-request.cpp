@@ -18,6 +18,7 @@
  */
 
 #include "src/db-operations/pk/pkr-request.hpp"
+#include <cstring>
 #include "src/logger.hpp"
 #include "src/rdrs-const.h"
 #include "src/status.hpp"
@@ -30,6 +31,12 @@ Uint32 PKRRequest::OperationType() {
   return (reinterpret_cast<Uint32 *>(req->buffer))[PKR_OP_TYPE_IDX];
 }
 
+bool PKRRequest::IsWriteOperation() {
+  Uint32 opType = OperationType();
+  return opType == RDRS_PK_INSERT_REQ_ID || opType == RDRS_PK_UPDATE_REQ_ID ||
+         opType == RDRS_PK_UPSERT_REQ_ID || opType == RDRS_PK_DELETE_REQ_ID;
+}
+
 Uint32 PKRRequest::Length() {
   return (reinterpret_cast<Uint32 *>(req->buffer))[PKR_LENGTH_IDX];
 }
@@ -88,42 +95,6 @@ Uint16 PKRRequest::PKValueLen(Uint32 index) {
   return len;
 }
 
-int PKRRequest::PKValueNDBStr(Uint32 index, const NdbDictionary::Column *col, char **data) {
-  Uint32 kvOffset  = PKTupleOffset(index);
-  Uint32 vOffset   = (reinterpret_cast<Uint32 *>(req->buffer))[(kvOffset / 4) + 1];
-  char *data_start = req->buffer + vOffset;
-
-  // The Go layer sets the length of the string in the first two bytes of the string
-  const NdbDictionary::Column::ArrayType array_type = col->getArrayType();
-  const size_t max_size                             = col->getSizeInBytes();
-  const size_t user_size                            = data_start[1] * 256 + data_start[0];
-
-  if (user_size > max_size) {
-    *data = nullptr;
-    return -1;
-  }
-
-  switch (array_type) {
-  case NdbDictionary::Column::ArrayTypeFixed:
-    // No prefix length is stored in string
-    *data = data_start + 2;  // skip the first two bytes that contain the length of the string
-    return 0;
-  case NdbDictionary::Column::ArrayTypeShortVar:
-    data_start[1] = data_start[0];
-    *data         = data_start + 1;
-    return 0;
-  case NdbDictionary::Column::ArrayTypeMediumVar:
-    // First two bytes of str has the length of data stored
-
-    // the length of the string is already set in the first two bytes of the string
-    *data = data_start;
-    return 0;
-  default:
-    *data = nullptr;
-    return -1;
-  }
-}
-
 Uint32 PKRRequest::ReadColumnsCount() {
   Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_READ_COLS_IDX];
   if (offset == 0) {
@@ -135,22 +106,20 @@ Uint32 PKRRequest::ReadColumnsCount() {
 }
 
 const char *PKRRequest::ReadColumnName(const Uint32 n) {
-  // [count][rc offset1]...[rc offset n] [ return type ] [ bytes ... ] [ return type ] [ bytes ... ]
-  //                                                         ^
-  //          ...............................................|                                 ^
-  //                         ..................................................................|
+  // [count][rc offset1]...[rc offset n] [ return type ] [ trim ] [ bytes ... ] [ return type ] ...
+  //                                                               ^
+  //          .....................................................|
 
   Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_READ_COLS_IDX];
   Uint32 r_offset =
       (reinterpret_cast<Uint32 *>(req->buffer))[(offset / ADDRESS_SIZE) + 1 + n];  // +1 for count
-  return req->buffer + r_offset + ADDRESS_SIZE;
+  return req->buffer + r_offset + 2 * ADDRESS_SIZE;
 }
 
 DataReturnType PKRRequest::ReadColumnReturnType(const Uint32 n) {
-  // [count][rc offset1]...[rc offset n] [ return type ] [ bytes ... ] [ return type ] [ bytes ... ]
+  // [count][rc offset1]...[rc offset n] [ return type ] [ trim ] [ bytes ... ] [ return type ] ...
   //                                      ^
-  //          ............................|                                 ^
-  //                         ...............................................|
+  //          ............................|
 
   Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_READ_COLS_IDX];
   Uint32 c_offset =
@@ -159,6 +128,70 @@ DataReturnType PKRRequest::ReadColumnReturnType(const Uint32 n) {
   return static_cast<DataReturnType>(type);
 }
 
+Uint32 PKRRequest::ReadColumnTrimPadding(const Uint32 n) {
+  // [count][rc offset1]...[rc offset n] [ return type ] [ trim ] [ bytes ... ] [ return type ] ...
+  //                                                      ^
+  //          ............................................|
+
+  Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_READ_COLS_IDX];
+  Uint32 c_offset =
+      (reinterpret_cast<Uint32 *>(req->buffer))[(offset / ADDRESS_SIZE) + 1 + n];  // +1 for count
+  return (reinterpret_cast<Uint32 *>(req->buffer))[(c_offset / ADDRESS_SIZE) + 1];
+}
+
+Uint32 PKRRequest::ValuesCount() {
+  Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_VALUES_IDX];
+  if (offset == 0) {
+    return 0;
+  } else {
+    Uint32 count = (reinterpret_cast<Uint32 *>(req->buffer))[offset / ADDRESS_SIZE];
+    return count;
+  }
+}
+
+Uint32 PKRRequest::ValueTupleOffset(const int n) {
+  // same layout as the primary key columns, with the flags of the value
+  // [count][kv offset1]...[kv offset n][k offset][v offset][v flags] [ bytes ... ] [koffset]...
+  Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_VALUES_IDX];
+  Uint32 kvOffset =
+      (reinterpret_cast<Uint32 *>(req->buffer))[(offset / ADDRESS_SIZE) + 1 + n];  // +1 for count
+  return kvOffset;
+}
+
+const char *PKRRequest::ValueName(Uint32 index) {
+  Uint32 kvOffset = ValueTupleOffset(index);
+  Uint32 kOffset  = (reinterpret_cast<Uint32 *>(req->buffer))[kvOffset / 4];
+  return req->buffer + kOffset;
+}
+
+bool PKRRequest::IsNullValue(Uint32 index) {
+  // value offset is set to 0 for NULL values
+  Uint32 kvOffset = ValueTupleOffset(index);
+  Uint32 vOffset  = (reinterpret_cast<Uint32 *>(req->buffer))[(kvOffset / 4) + 1];
+  return vOffset == 0;
+}
+
+bool PKRRequest::IsStringValue(Uint32 index) {
+  Uint32 kvOffset = ValueTupleOffset(index);
+  Uint32 vFlags   = (reinterpret_cast<Uint32 *>(req->buffer))[(kvOffset / 4) + 2];
+  return (vFlags & RDRS_VF_STRING) != 0;
+}
+
+const char *PKRRequest::ValueCStr(Uint32 index) {
+  Uint32 kvOffset = ValueTupleOffset(index);
+  Uint32 vOffset  = (reinterpret_cast<Uint32 *>(req->buffer))[(kvOffset / 4) + 1];
+
+  return req->buffer + vOffset + 2;  // skip first 2 bytes that contain size of string
+}
+
+Uint16 PKRRequest::ValueLen(Uint32 index) {
+  Uint32 kvOffset           = ValueTupleOffset(index);
+  Uint32 vOffset            = (reinterpret_cast<Uint32 *>(req->buffer))[(kvOffset / 4) + 1];
+  unsigned char *data_start = (unsigned char *)req->buffer + vOffset;
+  Uint16 len                = ((Uint16)data_start[1] * (Uint16)256) + (Uint16)data_start[0];
+  return len;
+}
+
 const char *PKRRequest::OperationId() {
   Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_OP_ID_IDX];
   if (offset != 0) {
@@ -167,3 +200,91 @@ const char *PKRRequest::OperationId() {
     return nullptr;
   }
 }
+
+const char *PKRRequest::TxId() {
+  Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_TX_ID_IDX];
+  if (offset != 0) {
+    return req->buffer + offset;
+  } else {
+    return nullptr;
+  }
+}
+
+const char *PKRRequest::IndexName() {
+  Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_INDEX_IDX];
+  if (offset == 0) {
+    return nullptr;
+  }
+  return req->buffer + offset;
+}
+
+int PKRRequest::ReadLockMode(NdbOperation::LockMode *lock_mode) {
+  Uint32 lm = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_LOCK_MODE_IDX];
+  switch (lm) {
+  case 0:
+  case RDRS_LM_COMMITTED:
+    *lock_mode = NdbOperation::LM_CommittedRead;
+    return 0;
+  case RDRS_LM_SHARED:
+    *lock_mode = NdbOperation::LM_Read;
+    return 0;
+  case RDRS_LM_EXCLUSIVE:
+    *lock_mode = NdbOperation::LM_Exclusive;
+    return 0;
+  default:
+    return -1;
+  }
+}
+
+Uint32 PKRRequest::ResponseFlags() {
+  return (reinterpret_cast<Uint32 *>(req->buffer))[PKR_RESP_FLAGS_IDX];
+}
+
+Uint32 PKRRequest::ResponseFormat() {
+  return (reinterpret_cast<Uint32 *>(req->buffer))[PKR_RESP_FORMAT_IDX];
+}
+
+Uint32 PKRRequest::TimeFormat() {
+  return (reinterpret_cast<Uint32 *>(req->buffer))[PKR_TIME_FORMAT_IDX];
+}
+
+Int32 PKRRequest::TimeZoneOffset() {
+  return (reinterpret_cast<Int32 *>(req->buffer))[PKR_TIME_ZONE_IDX];
+}
+
+Uint32 PKRRequest::ColumnTypesCount() {
+  Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_COL_TYPES_IDX];
+  if (offset == 0) {
+    return 0;
+  }
+  return (reinterpret_cast<Uint32 *>(req->buffer))[offset / ADDRESS_SIZE];
+}
+
+Uint32 PKRRequest::ColumnTypeOffset(Uint32 n) {
+  // [count][ct offset1]...[ct offset n] [ type ] [ label count ] [ name ] [ labels ... ] ...
+  //                                      ^
+  //          ............................|
+  Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_COL_TYPES_IDX];
+  return (reinterpret_cast<Uint32 *>(req->buffer))[(offset / ADDRESS_SIZE) + 1 + n];
+}
+
+const char *PKRRequest::ColumnTypeName(Uint32 n) {
+  return req->buffer + ColumnTypeOffset(n) + 2 * ADDRESS_SIZE;
+}
+
+Uint32 PKRRequest::ColumnType(Uint32 n) {
+  return (reinterpret_cast<Uint32 *>(req->buffer))[ColumnTypeOffset(n) / ADDRESS_SIZE];
+}
+
+void PKRRequest::ColumnTypeLabels(Uint32 n, std::vector<std::string> *labels) {
+  Uint32 offset = ColumnTypeOffset(n);
+  Uint32 count  = (reinterpret_cast<Uint32 *>(req->buffer))[(offset / ADDRESS_SIZE) + 1];
+
+  // the null terminated labels follow the column name
+  const char *label = ColumnTypeName(n);
+  labels->clear();
+  for (Uint32 i = 0; i < count; i++) {
+    label += strlen(label) + 1;
+    labels->push_back(label);
+  }
+}
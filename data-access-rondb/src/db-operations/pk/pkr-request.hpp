@@ -20,13 +20,16 @@
 #define DATA_ACCESS_RONDB_SRC_PK_READ_PKR_REQUEST_HPP_
 
 #include <stdint.h>
+#include <string>
+#include <vector>
 #include <NdbApi.hpp>
 #include "src/rdrs-dal.h"
 
 class PKRRequest {
- private:
+ protected:
   const RS_Buffer *req;
 
+ private:
   /**
    * Get offset of nth primary key/value pair
    *
@@ -35,6 +38,22 @@ class PKRRequest {
    */
   Uint32 PKTupleOffset(const int n);
 
+  /**
+   * Get offset of nth column/value pair that is written
+   *
+   * @param n nth column/value pair
+   * @return offset
+   */
+  Uint32 ValueTupleOffset(const int n);
+
+  /**
+   * Get offset of the MySQL type of nth column
+   *
+   * @param n nth column
+   * @return offset
+   */
+  Uint32 ColumnTypeOffset(Uint32 n);
+
  public:
   explicit PKRRequest(const RS_Buffer *request);
 
@@ -44,6 +63,12 @@ class PKRRequest {
    */
   Uint32 OperationType();
 
+  /**
+   * Check if the operation modifies the data
+   * @return true for insert, update, upsert and delete operations
+   */
+  bool IsWriteOperation();
+
   /**
    * Get length of the data
    * @return data length
@@ -98,16 +123,6 @@ class PKRRequest {
    */
   const char *PKValueCStr(Uint32 n);
 
-  /**
-   * Get PK column value
-   *
-   * @param n[in]. index
-   * @param col[in]. ndb column
-   * @param data[out]. data
-   * @return 0 if successfull
-   */
-  int PKValueNDBStr(Uint32 index, const NdbDictionary::Column *col, char **data);
-
   /**
    * Get number of read columns
    * @return number of read columns
@@ -130,12 +145,147 @@ class PKRRequest {
    */
   DataReturnType ReadColumnReturnType(const Uint32 n);
 
+  /**
+   * Get whether the padding of a CHAR or BINARY read column is trimmed
+   *
+   * @param n. index
+   * @return RDRS_TRIM_DEFAULT, RDRS_TRIM_PADDING or RDRS_KEEP_PADDING
+   */
+  Uint32 ReadColumnTrimPadding(const Uint32 n);
+
+  /**
+   * Get number of columns that are written
+   * @return number of columns
+   */
+  Uint32 ValuesCount();
+
+  /**
+   * Get name of the column that is written
+   *
+   * @param n. index
+   * @return column name
+   */
+  const char *ValueName(Uint32 n);
+
+  /**
+   * Check if the value of the column is NULL
+   *
+   * @param n. index
+   * @return true if the column is set to NULL
+   */
+  bool IsNullValue(Uint32 n);
+
+  /**
+   * Check if the value of the column was a JSON string
+   *
+   * @param n. index
+   * @return true if RDRS_VF_STRING is set
+   */
+  bool IsStringValue(Uint32 n);
+
+  /**
+   * Get length of the column value
+   *
+   * @param n. index
+   * @return length of the string
+   */
+  Uint16 ValueLen(Uint32 n);
+
+  /**
+   * Get column value.
+   *
+   * @param n. index
+   * @return c-string for column value
+   */
+  const char *ValueCStr(Uint32 n);
+
   /**
    * Get operation ID
    *
    * @return operation ID
    */
   const char *OperationId();
+
+  /**
+   * Get the ID of the explicit transaction this request belongs to
+   *
+   * @return transaction ID. nullptr if the request is not part of a transaction
+   */
+  const char *TxId();
+
+  /**
+   * Get name of the index. Unique reads use a unique hash index
+   * instead of the primary key and index scans use an ordered index
+   *
+   * @return index name. nullptr if no index is used
+   */
+  const char *IndexName();
+
+  /**
+   * Get lock mode of the read operation
+   *
+   * @param lock_mode[out]. lock mode
+   * @return 0 if successfull
+   */
+  int ReadLockMode(NdbOperation::LockMode *lock_mode);
+
+  /**
+   * Get the flags that control how the read columns are rendered
+   *
+   * @return RDRS_RF_* flags
+   */
+  Uint32 ResponseFlags();
+
+  /**
+   * Get the format of the response, e.g., JSON or MessagePack
+   *
+   * @return RDRS_FORMAT_*
+   */
+  Uint32 ResponseFormat();
+
+  /**
+   * Get the format of the temporal values of the request and the response
+   *
+   * @return RDRS_TF_* format
+   */
+  Uint32 TimeFormat();
+
+  /**
+   * Get the time zone of the temporal values
+   *
+   * @return offset from UTC in seconds
+   */
+  Int32 TimeZoneOffset();
+
+  /**
+   * Get number of columns whose MySQL type is sent with the request
+   * @return number of columns
+   */
+  Uint32 ColumnTypesCount();
+
+  /**
+   * Get name of the column
+   *
+   * @param n. index
+   * @return column name
+   */
+  const char *ColumnTypeName(Uint32 n);
+
+  /**
+   * Get MySQL type of the column
+   *
+   * @param n. index
+   * @return RDRS_CT_* type
+   */
+  Uint32 ColumnType(Uint32 n);
+
+  /**
+   * Get the labels of an ENUM or SET column
+   *
+   * @param n. index
+   * @param labels[out]. labels in the order of the column definition
+   */
+  void ColumnTypeLabels(Uint32 n, std::vector<std::string> *labels);
 };
 
 #endif  // DATA_ACCESS_RONDB_SRC_PK_READ_PKR_REQUEST_HPP_
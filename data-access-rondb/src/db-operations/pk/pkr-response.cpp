@@ -45,6 +45,10 @@ Uint32 PKRResponse::GetWriteHeader() {
   return this->writeHeader;
 }
 
+void PKRResponse::SetWriteHeader(Uint32 writeHeader) {
+  this->writeHeader = writeHeader;
+}
+
 void *PKRResponse::GetWritePointer() {
   return resp->buffer + writeHeader;
 }
@@ -85,6 +89,16 @@ RS_Status PKRResponse::Append_cstring(const char *str, bool appendComma) {
   return RS_OK;
 }
 
+RS_Status PKRResponse::Append_bytes(const char *data, Uint32 len) {
+  if (len > GetRemainingCapacity()) {
+    return RS_SERVER_ERROR(ERROR_016);
+  }
+
+  std::memcpy(resp->buffer + writeHeader, data, len);
+  writeHeader += len;
+  return RS_OK;
+}
+
 RS_Status PKRResponse::Append_i8(char num, bool appendComma) {
   return Append_i64(num, appendComma);
 }
@@ -157,61 +171,3 @@ RS_Status PKRResponse::Append_i64(Int64 num, bool appendComma) {
   }
   return RS_OK;
 }
-
-RS_Status PKRResponse::Append_char(const char *fromBuff, Uint32 fromBuffLen, CHARSET_INFO *fromCS,
-                                   bool appendComma) {
-  int extraSpace = 3;  // +2 for quotation marks and +1 for null character
-  if (appendComma) {
-    extraSpace += 1;
-  }
-
-  Uint32 estimatedBytes = fromBuffLen + extraSpace;
-
-  if (estimatedBytes > GetRemainingCapacity()) {
-    return RS_SERVER_ERROR(ERROR_010 + std::string(" Response buffer remaining capacity: ") +
-                           std::to_string(GetRemainingCapacity()) + std::string(" Required: ") +
-                           std::to_string(estimatedBytes));
-  }
-
-  // from_buffer -> printable string  -> escaped string
-  char tempBuff[estimatedBytes];
-  const char *well_formed_error_pos;
-  const char *cannot_convert_error_pos;
-  const char *from_end_pos;
-  const char *error_pos;
-
-  /* convert_to_printable(tempBuff, tempBuffLen, fromBuffer, fromLength, fromCS, 0); */
-  int bytesFormed = well_formed_copy_nchars(fromCS, tempBuff, estimatedBytes, fromCS, fromBuff,
-                                            fromBuffLen, UINT32_MAX, &well_formed_error_pos,
-                                            &cannot_convert_error_pos, &from_end_pos);
-
-  error_pos = well_formed_error_pos ? well_formed_error_pos : cannot_convert_error_pos;
-  if (error_pos) {
-    char printable_buff[32];
-    convert_to_printable(printable_buff, sizeof(printable_buff), error_pos,
-                         fromBuff + fromBuffLen - error_pos, fromCS, 6);
-    return RS_SERVER_ERROR(ERROR_008 + std::string(" Invalid string: ") +
-                           std::string(printable_buff));
-  } else if (from_end_pos < fromBuff + fromBuffLen) {
-    /*
-      result is longer than UINT_MAX32 and doesn't fit into String
-    */
-    return RS_SERVER_ERROR(ERROR_021 + std::string(" Buffer size: ") +
-                           std::to_string(estimatedBytes) + std::string(". Bytes left to copy: ") +
-                           std::to_string((fromBuff + fromBuffLen) - from_end_pos));
-  }
-  std::string wellFormedString = std::string(tempBuff, bytesFormed);
-  // remove blank spaces that are padded to the string
-  size_t endpos = wellFormedString.find_last_not_of(" ");
-  if (std::string::npos != endpos) {
-    wellFormedString = wellFormedString.substr(0, endpos + 1);
-  }
-
-  std::string escapedstr = escape_string(wellFormedString);
-  if ((escapedstr.length() + extraSpace) >= GetRemainingCapacity()) {  // +2 for quotation marks
-    return RS_SERVER_ERROR(ERROR_010);
-  }
-
-  Append_string(escapedstr, true, appendComma);
-  return RS_OK;
-}
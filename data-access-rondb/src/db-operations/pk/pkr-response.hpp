@@ -68,6 +68,13 @@ class PKRResponse {
    */
   Uint32 GetWriteHeader();
 
+  /**
+   * Move the write header back, e.g., to discard a partially written row
+   *
+   * @param writeHeader[in]. new write header location
+   */
+  void SetWriteHeader(Uint32 writeHeader);
+
   /**
    * Get pointer to the writing end of the buffer
    */
@@ -134,10 +141,9 @@ class PKRResponse {
   RS_Status Append_d64(double num, bool appendComma);
 
   /**
-   * Append to response buffer. Append
+   * Append raw bytes to response buffer, e.g., of binary responses
    */
-  RS_Status Append_char(const char *from_buffer, Uint32 from_length, CHARSET_INFO *from_cs,
-                        bool appendComma);
+  RS_Status Append_bytes(const char *data, Uint32 len);
 
   /**
    * Append null. Used to terminate string response message
@@ -0,0 +1,519 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#include "src/db-operations/pk/response-writer.hpp"
+#include <cstring>
+#include <sstream>
+#include <boost/beast/core/detail/base64.hpp>
+#include "src/error-strs.h"
+#include "src/mystring.hpp"
+#include "src/rdrs-const.h"
+
+ResponseWriter::ResponseWriter(PKRResponse *response) {
+  this->response = response;
+}
+
+bool ResponseWriter::NextValue() {
+  if (afterKey) {
+    afterKey = false;
+    return false;
+  }
+  // values that are written outside of any container, e.g., by scans that
+  // write the structure of the response themselves
+  if (containers.empty()) {
+    return false;
+  }
+  return containers.back().count++ > 0;
+}
+
+bool ResponseWriter::NextKey() {
+  afterKey = true;
+  return containers.back().count++ > 0;
+}
+
+RS_Status ResponseWriter::BeginRow() {
+  return BeginObject();
+}
+
+RS_Status ResponseWriter::EndRow() {
+  return EndObject();
+}
+
+ResponseWriter::Mark ResponseWriter::GetMark() const {
+  Mark mark;
+  mark.writeHeader = response->GetWriteHeader();
+  mark.containers  = containers;
+  mark.afterKey    = afterKey;
+  return mark;
+}
+
+void ResponseWriter::Rewind(const Mark &mark) {
+  response->SetWriteHeader(mark.writeHeader);
+  containers = mark.containers;
+  afterKey   = mark.afterKey;
+}
+
+/**
+ * Writes JSON text in the layout of the REST API. Keys are followed by a
+ * space, and so are the row and the arrays of the response, e.g.,
+ * {"operationId": "1","data": {"id":1}, "nullColumns": ["a", "b"] }. Rows
+ * are written without spaces. The response is terminated by a space and a
+ * NUL character
+ */
+class JSONWriter : public ResponseWriter {
+ public:
+  explicit JSONWriter(PKRResponse *response) : ResponseWriter(response) {
+  }
+
+  RS_Status Start() override {
+    return RS_OK;
+  }
+
+  RS_Status Finish() override {
+    RS_Status status = response->Append_string(" ", false, false);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    return response->Append_NULL();
+  }
+
+  RS_Status BeginObject() override {
+    return Begin("{", false);
+  }
+
+  RS_Status EndObject() override {
+    return End("}", false);
+  }
+
+  RS_Status BeginRow() override {
+    return Begin("{", true);
+  }
+
+  RS_Status EndRow() override {
+    return End("}", true);
+  }
+
+  RS_Status BeginArray() override {
+    return Begin("[", false);
+  }
+
+  RS_Status EndArray() override {
+    return End("]", true);
+  }
+
+  RS_Status Key(const std::string &key) override {
+    Container &object = containers.back();
+    std::string text;
+    if (NextKey()) {
+      text = object.spaced ? ", \"" : ",\"";
+    } else {
+      text = "\"";
+    }
+    object.spaced = false;
+    text += escape_string(key) + (object.compact ? "\":" : "\": ");
+    return response->Append_string(text, false, false);
+  }
+
+  RS_Status Null() override {
+    return Value("null");
+  }
+
+  RS_Status Int(Int64 num) override {
+    return Value(std::to_string(num));
+  }
+
+  RS_Status Uint(Uint64 num) override {
+    return Value(std::to_string(num));
+  }
+
+  RS_Status Float(float num) override {
+    return Double(num);
+  }
+
+  RS_Status Double(double num) override {
+    try {
+      std::stringstream ss;
+      ss << num;
+      return Value(ss.str());
+    } catch (...) {
+      return RS_SERVER_ERROR(ERROR_015);
+    }
+  }
+
+  RS_Status String(const std::string &str) override {
+    return Value("\"" + escape_string(str) + "\"");
+  }
+
+  RS_Status Bytes(const char *data, Uint32 len) override {
+    std::string encoded(boost::beast::detail::base64::encoded_size(len), 0);
+    encoded.resize(boost::beast::detail::base64::encode(&encoded[0], data, len));
+    return Value("\"" + encoded + "\"");
+  }
+
+  RS_Status Decimal(const std::string &num) override {
+    return Value(num);
+  }
+
+  RS_Status JsonDocument(const std::string &json) override {
+    return Value(json);
+  }
+
+ private:
+  // the comma is written before the value, as the writer does not know
+  // whether more values follow
+  RS_Status Value(const std::string &text) {
+    if (NextValue()) {
+      std::string sep = containers.back().compact ? "," : ", ";
+      return response->Append_string(sep + text, false, false);
+    }
+    return response->Append_string(text, false, false);
+  }
+
+  RS_Status Begin(const char *bracket, bool row) {
+    RS_Status status = Value(bracket);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    Container container;
+    container.compact = row || (!containers.empty() && containers.back().compact);
+    containers.push_back(container);
+    return RS_OK;
+  }
+
+  // the space after a row or an array is written with the next key or the
+  // end of the enclosing object
+  RS_Status End(const char *bracket, bool spaceAfter) {
+    Container container = containers.back();
+    containers.pop_back();
+    if (spaceAfter && !containers.empty() && !containers.back().compact) {
+      containers.back().spaced = true;
+    }
+    std::string text = container.spaced ? std::string(" ") + bracket : bracket;
+    return response->Append_string(text, false, false);
+  }
+};
+
+/**
+ * Common parts of the MessagePack and CBOR writers. Both formats encode
+ * numbers big-endian and prefix maps and arrays with the number of their
+ * entries. The writer does not know the number in advance, so it uses a
+ * 32 bit count that is set when the container ends. The response starts
+ * with its length as a native 32 bit integer, as it is not NUL terminated
+ */
+class BinaryWriter : public ResponseWriter {
+ public:
+  BinaryWriter(PKRResponse *response, unsigned char mapTag, unsigned char arrayTag)
+      : ResponseWriter(response), mapTag(mapTag), arrayTag(arrayTag) {
+  }
+
+  RS_Status Start() override {
+    start = response->GetWriteHeader();
+    // the length is set when the response is finished
+    char length[sizeof(Uint32)] = {0};
+    return response->Append_bytes(length, sizeof(length));
+  }
+
+  RS_Status Finish() override {
+    Uint32 length = response->GetWriteHeader() - start - sizeof(Uint32);
+    std::memcpy(response->GetResponseBuffer() + start, &length, sizeof(Uint32));
+    return RS_OK;
+  }
+
+  RS_Status BeginObject() override {
+    return Begin(mapTag);
+  }
+
+  RS_Status EndObject() override {
+    return End();
+  }
+
+  RS_Status BeginArray() override {
+    return Begin(arrayTag);
+  }
+
+  RS_Status EndArray() override {
+    return End();
+  }
+
+  RS_Status Decimal(const std::string &num) override {
+    return String(num);
+  }
+
+  RS_Status JsonDocument(const std::string &json) override {
+    return String(json);
+  }
+
+ protected:
+  /**
+   * Append a tag byte followed by the lowest bytes of the value in
+   * big-endian order
+   */
+  RS_Status AppendHead(unsigned char tag, Uint64 value, Uint32 bytes) {
+    char head[9];
+    head[0] = static_cast<char>(tag);
+    for (Uint32 i = 0; i < bytes; i++) {
+      head[bytes - i] = static_cast<char>(value >> (8 * i));
+    }
+    return response->Append_bytes(head, bytes + 1);
+  }
+
+  RS_Status AppendFloat(unsigned char tag, float num) {
+    Uint32 bits;
+    std::memcpy(&bits, &num, sizeof(bits));
+    return AppendHead(tag, bits, sizeof(bits));
+  }
+
+  RS_Status AppendDouble(unsigned char tag, double num) {
+    Uint64 bits;
+    std::memcpy(&bits, &num, sizeof(bits));
+    return AppendHead(tag, bits, sizeof(bits));
+  }
+
+ private:
+  unsigned char mapTag;
+  unsigned char arrayTag;
+  Uint32 start = 0;
+
+  RS_Status Begin(unsigned char tag) {
+    NextValue();
+    Container container;
+    container.offset = response->GetWriteHeader();
+    containers.push_back(container);
+    return AppendHead(tag, 0, sizeof(Uint32));
+  }
+
+  RS_Status End() {
+    Container container = containers.back();
+    containers.pop_back();
+    char *count = response->GetResponseBuffer() + container.offset + 1;
+    for (Uint32 i = 0; i < sizeof(Uint32); i++) {
+      count[sizeof(Uint32) - 1 - i] = static_cast<char>(container.count >> (8 * i));
+    }
+    return RS_OK;
+  }
+};
+
+/**
+ * Writes MessagePack, https://github.com/msgpack/msgpack/blob/master/spec.md
+ */
+class MsgPackWriter : public BinaryWriter {
+ public:
+  explicit MsgPackWriter(PKRResponse *response) : BinaryWriter(response, 0xdf, 0xdd) {
+  }
+
+  RS_Status Key(const std::string &key) override {
+    NextKey();
+    return WriteString(key.data(), key.size());
+  }
+
+  RS_Status Null() override {
+    NextValue();
+    return AppendHead(0xc0, 0, 0);
+  }
+
+  RS_Status Int(Int64 num) override {
+    NextValue();
+    if (num >= 0) {
+      return WriteUint(num);
+    }
+    if (num >= -32) {
+      // negative fixint
+      return AppendHead(static_cast<unsigned char>(num), 0, 0);
+    }
+    if (num >= INT8_MIN) {
+      return AppendHead(0xd0, num, 1);
+    }
+    if (num >= INT16_MIN) {
+      return AppendHead(0xd1, num, 2);
+    }
+    if (num >= INT32_MIN) {
+      return AppendHead(0xd2, num, 4);
+    }
+    return AppendHead(0xd3, num, 8);
+  }
+
+  RS_Status Uint(Uint64 num) override {
+    NextValue();
+    return WriteUint(num);
+  }
+
+  RS_Status Float(float num) override {
+    NextValue();
+    return AppendFloat(0xca, num);
+  }
+
+  RS_Status Double(double num) override {
+    NextValue();
+    return AppendDouble(0xcb, num);
+  }
+
+  RS_Status String(const std::string &str) override {
+    NextValue();
+    return WriteString(str.data(), str.size());
+  }
+
+  RS_Status Bytes(const char *data, Uint32 len) override {
+    NextValue();
+    RS_Status status;
+    if (len <= UINT8_MAX) {
+      status = AppendHead(0xc4, len, 1);
+    } else if (len <= UINT16_MAX) {
+      status = AppendHead(0xc5, len, 2);
+    } else {
+      status = AppendHead(0xc6, len, 4);
+    }
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    return response->Append_bytes(data, len);
+  }
+
+ private:
+  RS_Status WriteUint(Uint64 num) {
+    if (num < 128) {
+      // positive fixint
+      return AppendHead(static_cast<unsigned char>(num), 0, 0);
+    }
+    if (num <= UINT8_MAX) {
+      return AppendHead(0xcc, num, 1);
+    }
+    if (num <= UINT16_MAX) {
+      return AppendHead(0xcd, num, 2);
+    }
+    if (num <= UINT32_MAX) {
+      return AppendHead(0xce, num, 4);
+    }
+    return AppendHead(0xcf, num, 8);
+  }
+
+  RS_Status WriteString(const char *str, Uint32 len) {
+    RS_Status status;
+    if (len < 32) {
+      // fixstr
+      status = AppendHead(0xa0 | len, 0, 0);
+    } else if (len <= UINT8_MAX) {
+      status = AppendHead(0xd9, len, 1);
+    } else if (len <= UINT16_MAX) {
+      status = AppendHead(0xda, len, 2);
+    } else {
+      status = AppendHead(0xdb, len, 4);
+    }
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    return response->Append_bytes(str, len);
+  }
+};
+
+// major types of CBOR data items
+#define CBOR_UINT   0
+#define CBOR_NEGINT 1
+#define CBOR_BYTES  2
+#define CBOR_TEXT   3
+
+/**
+ * Writes CBOR, https://www.rfc-editor.org/rfc/rfc8949
+ */
+class CBORWriter : public BinaryWriter {
+ public:
+  // maps and arrays with a 4 byte count
+  explicit CBORWriter(PKRResponse *response) : BinaryWriter(response, 0xba, 0x9a) {
+  }
+
+  RS_Status Key(const std::string &key) override {
+    NextKey();
+    return WriteItem(CBOR_TEXT, key.data(), key.size());
+  }
+
+  RS_Status Null() override {
+    NextValue();
+    return AppendHead(0xf6, 0, 0);
+  }
+
+  RS_Status Int(Int64 num) override {
+    NextValue();
+    if (num >= 0) {
+      return WriteHead(CBOR_UINT, num);
+    }
+    return WriteHead(CBOR_NEGINT, static_cast<Uint64>(-(num + 1)));
+  }
+
+  RS_Status Uint(Uint64 num) override {
+    NextValue();
+    return WriteHead(CBOR_UINT, num);
+  }
+
+  RS_Status Float(float num) override {
+    NextValue();
+    return AppendFloat(0xfa, num);
+  }
+
+  RS_Status Double(double num) override {
+    NextValue();
+    return AppendDouble(0xfb, num);
+  }
+
+  RS_Status String(const std::string &str) override {
+    NextValue();
+    return WriteItem(CBOR_TEXT, str.data(), str.size());
+  }
+
+  RS_Status Bytes(const char *data, Uint32 len) override {
+    NextValue();
+    return WriteItem(CBOR_BYTES, data, len);
+  }
+
+ private:
+  RS_Status WriteHead(unsigned char major, Uint64 value) {
+    unsigned char tag = major << 5;
+    if (value < 24) {
+      return AppendHead(tag | value, 0, 0);
+    }
+    if (value <= UINT8_MAX) {
+      return AppendHead(tag | 24, value, 1);
+    }
+    if (value <= UINT16_MAX) {
+      return AppendHead(tag | 25, value, 2);
+    }
+    if (value <= UINT32_MAX) {
+      return AppendHead(tag | 26, value, 4);
+    }
+    return AppendHead(tag | 27, value, 8);
+  }
+
+  RS_Status WriteItem(unsigned char major, const char *data, Uint32 len) {
+    RS_Status status = WriteHead(major, len);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    return response->Append_bytes(data, len);
+  }
+};
+
+std::unique_ptr<ResponseWriter> NewResponseWriter(Uint32 format, PKRResponse *response) {
+  switch (format) {
+  case RDRS_FORMAT_MSGPACK:
+  case RDRS_FORMAT_ARROW:
+    return std::unique_ptr<ResponseWriter>(new MsgPackWriter(response));
+  case RDRS_FORMAT_CBOR:
+    return std::unique_ptr<ResponseWriter>(new CBORWriter(response));
+  default:
+    return std::unique_ptr<ResponseWriter>(new JSONWriter(response));
+  }
+}
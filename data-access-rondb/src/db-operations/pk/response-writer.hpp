@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#ifndef DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_PK_RESPONSE_WRITER_HPP_
+#define DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_PK_RESPONSE_WRITER_HPP_
+
+#include <memory>
+#include <string>
+#include <vector>
+#include "src/db-operations/pk/pkr-response.hpp"
+#include "src/status.hpp"
+
+/**
+ * Serializes the values of a response to the response buffer. The same
+ * values are written as JSON text or in a binary format. The binary
+ * formats return binary columns as raw bytes, while JSON returns them as
+ * base64 encoded strings
+ */
+class ResponseWriter {
+ public:
+  explicit ResponseWriter(PKRResponse *response);
+  virtual ~ResponseWriter() = default;
+
+  /**
+   * Start the response. Called before any value is written
+   */
+  virtual RS_Status Start() = 0;
+
+  /**
+   * Terminate the response. Called after the last value is written
+   */
+  virtual RS_Status Finish() = 0;
+
+  virtual RS_Status BeginObject() = 0;
+  virtual RS_Status EndObject()   = 0;
+  virtual RS_Status BeginArray()  = 0;
+  virtual RS_Status EndArray()    = 0;
+
+  /**
+   * Start or end the object that holds the columns of a row. JSON writes
+   * rows without spaces, the other formats like any other object
+   */
+  virtual RS_Status BeginRow();
+  virtual RS_Status EndRow();
+
+  /**
+   * Write the key of the next value of an object
+   */
+  virtual RS_Status Key(const std::string &key) = 0;
+
+  virtual RS_Status Null()             = 0;
+  virtual RS_Status Int(Int64 num)     = 0;
+  virtual RS_Status Uint(Uint64 num)   = 0;
+  virtual RS_Status Float(float num)   = 0;
+  virtual RS_Status Double(double num) = 0;
+
+  /**
+   * Write a UTF-8 string
+   */
+  virtual RS_Status String(const std::string &str) = 0;
+
+  /**
+   * Write the bytes of a binary column
+   */
+  virtual RS_Status Bytes(const char *data, Uint32 len) = 0;
+
+  /**
+   * Write a decimal number, e.g., -12.50. JSON writes it as a number, the
+   * binary formats as a string as they have no exact decimal type
+   */
+  virtual RS_Status Decimal(const std::string &num) = 0;
+
+  /**
+   * Write the text of a JSON document, e.g., of a JSON column. The binary
+   * formats write it as a string
+   */
+  virtual RS_Status JsonDocument(const std::string &json) = 0;
+
+ protected:
+  /**
+   * An object or an array that is being written
+   */
+  typedef struct Container {
+    Uint32 offset = 0;  // location of the container in the response buffer
+    Uint32 count  = 0;  // number of values, or keys for objects
+    bool compact  = false;  // JSON: no spaces between the values, e.g., in rows
+    bool spaced   = false;  // JSON: the last value is followed by a space
+  } Container;
+
+  PKRResponse *response;
+  std::vector<Container> containers;
+  bool afterKey = false;
+
+  /**
+   * Count a value of the current container. The values of objects are
+   * counted by their keys
+   *
+   * @return true if the value is not the first one of the container
+   */
+  bool NextValue();
+
+  /**
+   * Count a key of the current object
+   *
+   * @return true if the key is not the first one of the object
+   */
+  bool NextKey();
+
+ public:
+  /**
+   * Position of the writer in the response
+   */
+  typedef struct Mark {
+    Uint32 writeHeader = 0;
+    std::vector<Container> containers;
+    bool afterKey = false;
+  } Mark;
+
+  /**
+   * Get the current position of the writer
+   */
+  Mark GetMark() const;
+
+  /**
+   * Remove the values written after the mark, e.g., a row that did not fit
+   * in the response
+   */
+  void Rewind(const Mark &mark);
+};
+
+/**
+ * Create a writer for the format of a response
+ *
+ * @param[in] format RDRS_FORMAT_*
+ * @param[in] response the response buffer
+ *
+ * @return writer. JSON is used for unknown formats
+ */
+std::unique_ptr<ResponseWriter> NewResponseWriter(Uint32 format, PKRResponse *response);
+
+#endif  // DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_PK_RESPONSE_WRITER_HPP_
@@ -0,0 +1,179 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#include "src/db-operations/pk/type-conversions.hpp"
+#include <algorithm>
+#include <cstdlib>
+
+/**
+ * Days since 1970-01-01 of a date in the proleptic Gregorian calendar
+ */
+static Int64 DaysFromCivil(Int64 year, Uint32 month, Uint32 day) {
+  year -= month <= 2;
+  const Int64 era  = (year >= 0 ? year : year - 399) / 400;
+  const Uint32 yoe = static_cast<Uint32>(year - era * 400);
+  const Uint32 doy = (153 * (month > 2 ? month - 3 : month + 9) + 2) / 5 + day - 1;
+  const Uint32 doe = yoe * 365 + yoe / 4 - yoe / 100 + doy;
+  return era * 146097 + static_cast<Int64>(doe) - 719468;
+}
+
+Int64 TimeToSeconds(const MYSQL_TIME &l_time) {
+  return DaysFromCivil(l_time.year, l_time.month, l_time.day) * 86400 + l_time.hour * 3600 +
+         l_time.minute * 60 + l_time.second;
+}
+
+void SecondsToTime(Int64 seconds, Uint64 usec, MYSQL_TIME *l_time) {
+  Int64 days = seconds / 86400;
+  Int64 secs = seconds % 86400;
+  if (secs < 0) {
+    secs += 86400;
+    days -= 1;
+  }
+
+  // inverse of DaysFromCivil
+  days += 719468;
+  const Int64 era    = (days >= 0 ? days : days - 146096) / 146097;
+  const Uint32 doe   = static_cast<Uint32>(days - era * 146097);
+  const Uint32 yoe   = (doe - doe / 1460 + doe / 36524 - doe / 146096) / 365;
+  const Uint32 doy   = doe - (365 * yoe + yoe / 4 - yoe / 100);
+  const Uint32 mp    = (5 * doy + 2) / 153;
+  const Uint32 month = mp < 10 ? mp + 3 : mp - 9;
+
+  *l_time             = {};
+  l_time->year        = static_cast<Int64>(yoe) + era * 400 + (month <= 2);
+  l_time->month       = month;
+  l_time->day         = doy - (153 * mp + 2) / 5 + 1;
+  l_time->hour        = secs / 3600;
+  l_time->minute      = secs / 60 % 60;
+  l_time->second      = secs % 60;
+  l_time->second_part = usec;
+  l_time->time_type   = MYSQL_TIMESTAMP_DATETIME;
+}
+
+bool TimeToEpoch(const MYSQL_TIME &l_time, Int32 tzOffset, Int64 *epoch) {
+  if (l_time.month == 0 || l_time.day == 0) {
+    return false;
+  }
+  *epoch = TimeToSeconds(l_time) - tzOffset;
+  return *epoch > 0 && *epoch <= 2147483647;
+}
+
+void EpochToTime(Int64 epoch, Uint64 usec, Int32 tzOffset, MYSQL_TIME *l_time) {
+  SecondsToTime(epoch + tzOffset, usec, l_time);
+}
+
+bool ToOldDecimalStr(const char *valueCStr, size_t valueLen, int precision, int scale,
+                     size_t size, bool isUnsigned, std::string *out) {
+  std::string value(valueCStr, valueLen);
+  bool neg = !value.empty() && value[0] == '-';
+  if (neg || (!value.empty() && value[0] == '+')) {
+    value.erase(0, 1);
+  }
+  if (neg && isUnsigned) {
+    return false;
+  }
+
+  size_t point         = value.find('.');
+  std::string intPart  = value.substr(0, point);
+  std::string fracPart = point == std::string::npos ? "" : value.substr(point + 1);
+  if (intPart.empty() && fracPart.empty()) {
+    return false;
+  }
+  for (char c : intPart + fracPart) {
+    if (c < '0' || c > '9') {
+      return false;
+    }
+  }
+
+  intPart.erase(0, std::min(intPart.find_first_not_of('0'), intPart.size()));
+  if (intPart.empty()) {
+    intPart = "0";
+  }
+
+  if (static_cast<int>(fracPart.size()) > scale ||
+      static_cast<int>(intPart.size()) > std::max(precision - scale, 1)) {
+    return false;
+  }
+  fracPart.append(scale - fracPart.size(), '0');
+
+  std::string digits = (neg ? "-" : "") + intPart + (scale > 0 ? "." + fracPart : "");
+  if (digits.size() > size) {
+    return false;
+  }
+  *out = std::string(size - digits.size(), ' ') + digits;
+  return true;
+}
+
+std::string FromOldDecimalStr(const char *data, size_t len) {
+  std::string value(data, len);
+  value.erase(0, std::min(value.find_first_not_of(' '), value.size()));
+
+  std::string sign;
+  if (!value.empty() && (value[0] == '-' || value[0] == '+')) {
+    sign = value[0] == '-' ? "-" : "";
+    value.erase(0, 1);
+  }
+  value.erase(0, std::min(value.find_first_not_of('0'), value.size()));
+  if (value.empty() || value[0] == '.') {
+    value = "0" + value;
+  }
+  return sign + value;
+}
+
+Uint64 PackOldDatetime(const MYSQL_TIME &l_time) {
+  return (l_time.year * 10000ULL + l_time.month * 100 + l_time.day) * 1000000ULL +
+         l_time.hour * 10000 + l_time.minute * 100 + l_time.second;
+}
+
+void UnpackOldDatetime(Uint64 datetime, MYSQL_TIME *l_time) {
+  Uint64 date       = datetime / 1000000;
+  Uint64 time       = datetime % 1000000;
+  *l_time           = {};
+  l_time->year      = date / 10000;
+  l_time->month     = date / 100 % 100;
+  l_time->day       = date % 100;
+  l_time->hour      = time / 10000;
+  l_time->minute    = time / 100 % 100;
+  l_time->second    = time % 100;
+  l_time->time_type = MYSQL_TIMESTAMP_DATETIME;
+}
+
+void PackOldTime(const MYSQL_TIME &l_time, char *packed) {
+  Int32 time = l_time.hour * 10000 + l_time.minute * 100 + l_time.second;
+  if (l_time.neg) {
+    time = -time;
+  }
+  packed[0] = static_cast<char>(time & 0xFF);
+  packed[1] = static_cast<char>((time >> 8) & 0xFF);
+  packed[2] = static_cast<char>((time >> 16) & 0xFF);
+}
+
+void UnpackOldTime(const char *packed, MYSQL_TIME *l_time) {
+  const unsigned char *bytes = reinterpret_cast<const unsigned char *>(packed);
+  Uint32 value               = bytes[0] | (bytes[1] << 8) | (bytes[2] << 16);
+  Int32 time                 = static_cast<Int32>(value << 8) >> 8;
+
+  *l_time           = {};
+  l_time->neg       = time < 0;
+  time              = std::abs(time);
+  l_time->hour      = time / 10000;
+  l_time->minute    = time / 100 % 100;
+  l_time->second    = time % 100;
+  l_time->time_type = MYSQL_TIMESTAMP_TIME;
+}
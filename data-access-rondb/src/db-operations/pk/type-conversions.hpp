@@ -0,0 +1,84 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#ifndef DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_PK_TYPE_CONVERSIONS_HPP_
+#define DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_PK_TYPE_CONVERSIONS_HPP_
+
+#include <mysql_time.h>
+#include <NdbApi.hpp>
+#include <string>
+
+// Conversions of the temporal and decimal storage formats that are not
+// handled by the MySQL libraries. They do not depend on NDB, so that they
+// can be unit tested without a cluster, see test/type-conversions-test.cpp
+
+/**
+ * Seconds since the epoch of the time, as if the time is in UTC
+ */
+Int64 TimeToSeconds(const MYSQL_TIME &l_time);
+
+/**
+ * Converts seconds since the epoch to a UTC MYSQL_TIME
+ */
+void SecondsToTime(Int64 seconds, Uint64 usec, MYSQL_TIME *l_time);
+
+/**
+ * Seconds since the epoch of a time in the given time zone. Returns false
+ * if the time is not in the TIMESTAMP range, i.e., '1970-01-01 00:00:01'
+ * UTC to '2038-01-19 03:14:07' UTC
+ */
+bool TimeToEpoch(const MYSQL_TIME &l_time, Int32 tzOffset, Int64 *epoch);
+
+/**
+ * Converts seconds since the epoch to MYSQL_TIME in the given time zone
+ */
+void EpochToTime(Int64 epoch, Uint64 usec, Int32 tzOffset, MYSQL_TIME *l_time);
+
+/**
+ * MySQL < 5.0 decimals are stored as strings of digits that are right
+ * aligned and padded with spaces, e.g., "  -12.50" for DECIMAL(6,2).
+ *
+ * @param[in] size the size of the column in bytes
+ * @param[out] out the padded value
+ * @return false if the value does not fit in the column
+ */
+bool ToOldDecimalStr(const char *value, size_t len, int precision, int scale, size_t size,
+                     bool isUnsigned, std::string *out);
+
+/**
+ * Old decimal value without the padding. Zerofill columns have leading zeros
+ * that are also removed
+ */
+std::string FromOldDecimalStr(const char *data, size_t len);
+
+/**
+ * The old DATETIME format, the number YYYYMMDDHHMMSS
+ */
+Uint64 PackOldDatetime(const MYSQL_TIME &l_time);
+
+void UnpackOldDatetime(Uint64 datetime, MYSQL_TIME *l_time);
+
+/**
+ * The old TIME format, a 3 byte signed number HHMMSS, little-endian
+ */
+void PackOldTime(const MYSQL_TIME &l_time, char *packed);
+
+void UnpackOldTime(const char *packed, MYSQL_TIME *l_time);
+
+#endif  // DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_PK_TYPE_CONVERSIONS_HPP_
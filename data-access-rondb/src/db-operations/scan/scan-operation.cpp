@@ -0,0 +1,757 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#include "src/db-operations/scan/scan-operation.hpp"
+#include <algorithm>
+#include <cstring>
+#include <string>
+#include "src/db-operations/pk/common.hpp"
+#include "src/error-strs.h"
+#include "src/logger.hpp"
+#include "src/rdrs-const.h"
+#include "src/status.hpp"
+
+// ordered index that NDB creates for the primary key
+#define PRIMARY_INDEX_NAME "PRIMARY"
+
+ScanOperation::ScanOperation(RS_Buffer *req_buff, RS_Buffer *resp_buff, Ndb *ndb_object) {
+  this->request    = new ScanRequest(req_buff);
+  this->response   = new PKRResponse(resp_buff);
+  this->writer     = NewResponseWriter(request->ResponseFormat(), response);
+  this->ndb_object = ndb_object;
+}
+
+ScanOperation::~ScanOperation() {
+  delete request;
+  delete response;
+}
+
+RS_Status ScanOperation::Init() {
+  if (ndb_object->setCatalogName(request->DB()) != 0) {
+    return RS_CLIENT_ERROR(ERROR_011 + std::string(" Database: ") + std::string(request->DB()) +
+                           " Table: " + request->Table());
+  }
+  const NdbDictionary::Dictionary *dict = ndb_object->getDictionary();
+  table_dict                            = dict->getTable(request->Table());
+  if (table_dict == nullptr) {
+    return RS_CLIENT_ERROR(ERROR_011 + std::string(" Database: ") + std::string(request->DB()) +
+                           " Table: " + request->Table());
+  }
+
+  bool paginated = request->ScanFlags() & RDRS_ISF_PAGINATE;
+  if (request->IndexName() == nullptr && paginated) {
+    // paginated table scans are ordered by the primary key so that they can be resumed.
+    // Tables without an ordered primary key index are scanned without pagination
+    index_dict = dict->getIndex(PRIMARY_INDEX_NAME, request->Table());
+    paginate   = index_dict != nullptr;
+  } else if (request->IndexName() != nullptr) {
+    paginate   = paginated;
+    index_dict = dict->getIndex(request->IndexName(), request->Table());
+    if (index_dict == nullptr) {
+      return RS_CLIENT_ERROR(ERROR_043 + std::string(" Index: ") + request->IndexName());
+    }
+    if (index_dict->getType() != NdbDictionary::Index::OrderedIndex) {
+      return RS_CLIENT_ERROR(ERROR_044 + std::string(" Index: ") + request->IndexName());
+    }
+  }
+  return RS_OK;
+}
+
+RS_Status ScanOperation::ValidateRequest() {
+  // check that the read columns exist
+  if (request->ReadColumnsCount() > 0) {
+    for (Uint32 i = 0; i < request->ReadColumnsCount(); i++) {
+      const NdbDictionary::Column *col = table_dict->getColumn(request->ReadColumnName(i));
+      if (col == nullptr) {
+        return RS_CLIENT_ERROR(ERROR_012 + std::string(" Column: ") +
+                               std::string(request->ReadColumnName(i)));
+      }
+
+      if (request->ReadColumnReturnType(i) > __MAX_TYPE_NOT_A_DRT ||
+          request->ReadColumnReturnType(i) < DEFAULT_DRT) {
+        return RS_SERVER_ERROR(ERROR_025 + std::string(" Column: ") +
+                               std::string(request->ReadColumnName(i)));
+      }
+      if (!IsReturnTypeSupported(col, request->ReadColumnReturnType(i))) {
+        return RS_CLIENT_ERROR(ERROR_025 + std::string(" Column: ") +
+                               std::string(request->ReadColumnName(i)));
+      }
+    }
+  }
+
+  if (request->FilterNodesCount() > 0) {
+    Uint32 node      = 0;
+    RS_Status status = ValidateFilter(&node);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    // the tree must contain all the nodes
+    if (node != request->FilterNodesCount()) {
+      return RS_CLIENT_ERROR(ERROR_039);
+    }
+  }
+
+  if (index_dict != nullptr) {
+    RS_Status status = ValidateBound(false);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    status = ValidateBound(true);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  }
+
+  return RS_OK;
+}
+
+RS_Status ScanOperation::ValidateBound(bool upper) {
+  if (request->BoundColumnsCount(upper) > index_dict->getNoOfColumns()) {
+    return RS_CLIENT_ERROR(ERROR_045 + std::string(" Too many columns."));
+  }
+
+  for (Uint32 i = 0; i < request->BoundColumnsCount(upper); i++) {
+    if (strcmp(request->BoundColumnName(upper, i), index_dict->getColumn(i)->getName()) != 0) {
+      return RS_CLIENT_ERROR(ERROR_045 + std::string(" Expecting column: ") +
+                             index_dict->getColumn(i)->getName() +
+                             " Got: " + request->BoundColumnName(upper, i));
+    }
+  }
+  return RS_OK;
+}
+
+RS_Status ScanOperation::ValidateFilter(Uint32 *node) {
+  if (*node >= request->FilterNodesCount()) {
+    return RS_CLIENT_ERROR(ERROR_039);
+  }
+
+  Uint32 n = (*node)++;
+  switch (request->FilterNodeType(n)) {
+  case RDRS_SF_AND:
+  case RDRS_SF_OR:
+  case RDRS_SF_NOT: {
+    Uint32 children = request->FilterNodeChildren(n);
+    if (children == 0 || (request->FilterNodeType(n) == RDRS_SF_NOT && children != 1)) {
+      return RS_CLIENT_ERROR(ERROR_039);
+    }
+    for (Uint32 i = 0; i < children; i++) {
+      RS_Status status = ValidateFilter(node);
+      if (status.http_code != SUCCESS) {
+        return status;
+      }
+    }
+    return RS_OK;
+  }
+  case RDRS_SF_EQ:
+  case RDRS_SF_NE:
+  case RDRS_SF_LT:
+  case RDRS_SF_LE:
+  case RDRS_SF_GT:
+  case RDRS_SF_GE:
+  case RDRS_SF_ISNULL: {
+    const NdbDictionary::Column *col = table_dict->getColumn(request->FilterNodeColumn(n));
+    if (col == nullptr) {
+      return RS_CLIENT_ERROR(ERROR_012 + std::string(" Column: ") +
+                             std::string(request->FilterNodeColumn(n)));
+    }
+    if (col->getType() == NdbDictionary::Column::Blob ||
+        col->getType() == NdbDictionary::Column::Text) {
+      return RS_CLIENT_ERROR(ERROR_040 + std::string(" Column: ") + std::string(col->getName()));
+    }
+    return RS_OK;
+  }
+  default:
+    return RS_CLIENT_ERROR(ERROR_039);
+  }
+}
+
+RS_Status ScanOperation::SetupTransaction() {
+  transaction = ndb_object->startTransaction();
+  if (transaction == nullptr) {
+    return RS_RONDB_SERVER_ERROR(ndb_object->getNdbError(), ERROR_005);
+  }
+  return RS_OK;
+}
+
+RS_Status ScanOperation::SetupScan() {
+  if (index_dict != nullptr) {
+    NdbIndexScanOperation *index_scan_op = transaction->getNdbIndexScanOperation(index_dict);
+    if (index_scan_op == nullptr) {
+      return RS_RONDB_SERVER_ERROR(transaction->getNdbError(), ERROR_041);
+    }
+    scan_op = index_scan_op;
+
+    // the rows are merged from all fragments to return them in index order
+    Uint32 flags = NdbScanOperation::SF_OrderBy;
+    if (request->ScanFlags() & RDRS_ISF_DESCENDING) {
+      flags |= NdbScanOperation::SF_Descending;
+    }
+    if (index_scan_op->readTuples(NdbOperation::LM_CommittedRead, flags) != 0) {
+      return RS_RONDB_SERVER_ERROR(index_scan_op->getNdbError(), ERROR_041);
+    }
+
+    RS_Status status = SetupBound(index_scan_op, false);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    status = SetupBound(index_scan_op, true);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  } else {
+    scan_op = transaction->getNdbScanOperation(table_dict);
+    if (scan_op == nullptr) {
+      return RS_RONDB_SERVER_ERROR(transaction->getNdbError(), ERROR_041);
+    }
+
+    if (scan_op->readTuples(NdbOperation::LM_CommittedRead) != 0) {
+      return RS_RONDB_SERVER_ERROR(scan_op->getNdbError(), ERROR_041);
+    }
+  }
+
+  if (request->FilterNodesCount() > 0) {
+    NdbScanFilter filter(scan_op);
+    if (filter.begin(NdbScanFilter::AND) != 0) {
+      return RS_RONDB_SERVER_ERROR(filter.getNdbError(), ERROR_041);
+    }
+
+    Uint32 node      = 0;
+    RS_Status status = SetupFilter(&filter, &node);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+
+    if (filter.end() != 0) {
+      return RS_RONDB_SERVER_ERROR(filter.getNdbError(), ERROR_041);
+    }
+  }
+
+  if (request->ReadColumnsCount() > 0) {
+    for (Uint32 i = 0; i < request->ReadColumnsCount(); i++) {
+      RS_Status status = SetupReadColumn(
+          scan_op, table_dict->getColumn(request->ReadColumnName(i)), &recs, &blobs);
+      if (status.http_code != SUCCESS) {
+        return status;
+      }
+    }
+  } else {
+    for (int i = 0; i < table_dict->getNoOfColumns(); i++) {
+      RS_Status status = SetupReadColumn(scan_op, table_dict->getColumn(i), &recs, &blobs);
+      if (status.http_code != SUCCESS) {
+        return status;
+      }
+    }
+  }
+
+  if (paginate) {
+    token_reserve = 64;
+    for (int i = 0; i < table_dict->getNoOfPrimaryKeys(); i++) {
+      const NdbDictionary::Column *col = table_dict->getColumn(table_dict->getPrimaryKey(i));
+      key_recs.push_back(scan_op->getValue(col->getName(), nullptr));
+      // escaped strings take up to 6 characters per byte
+      token_reserve += 64 + strlen(col->getName()) + 6 * col->getSizeInBytes();
+    }
+  }
+
+  for (size_t i = 0; i < recs.size(); i++) {
+    if (recs[i] == nullptr && blobs[i] == nullptr) {
+      return RS_RONDB_SERVER_ERROR(scan_op->getNdbError(), ERROR_041);
+    }
+
+    const NdbDictionary::Column *col =
+        blobs[i] != nullptr ? blobs[i]->getColumn() : recs[i]->getColumn();
+    col_types.push_back(GetMySQLColumnType(request, col->getName()));
+  }
+
+  for (size_t i = 0; i < key_recs.size(); i++) {
+    if (key_recs[i] == nullptr) {
+      return RS_RONDB_SERVER_ERROR(scan_op->getNdbError(), ERROR_041);
+    }
+  }
+
+  return RS_OK;
+}
+
+RS_Status ScanOperation::SetupFilter(NdbScanFilter *filter, Uint32 *node) {
+  Uint32 n    = (*node)++;
+  Uint32 type = request->FilterNodeType(n);
+
+  if (type == RDRS_SF_AND || type == RDRS_SF_OR || type == RDRS_SF_NOT) {
+    // NOT is a NAND group with a single child
+    NdbScanFilter::Group group = NdbScanFilter::NAND;
+    if (type == RDRS_SF_AND) {
+      group = NdbScanFilter::AND;
+    } else if (type == RDRS_SF_OR) {
+      group = NdbScanFilter::OR;
+    }
+
+    if (filter->begin(group) != 0) {
+      return RS_RONDB_SERVER_ERROR(filter->getNdbError(), ERROR_041);
+    }
+    for (Uint32 i = 0; i < request->FilterNodeChildren(n); i++) {
+      RS_Status status = SetupFilter(filter, node);
+      if (status.http_code != SUCCESS) {
+        return status;
+      }
+    }
+    if (filter->end() != 0) {
+      return RS_RONDB_SERVER_ERROR(filter->getNdbError(), ERROR_041);
+    }
+    return RS_OK;
+  }
+
+  if (type == RDRS_SF_ISNULL) {
+    const NdbDictionary::Column *col = table_dict->getColumn(request->FilterNodeColumn(n));
+    if (filter->isnull(col->getColumnNo()) != 0) {
+      return RS_RONDB_SERVER_ERROR(filter->getNdbError(), ERROR_041);
+    }
+    return RS_OK;
+  }
+
+  return SetupFilterCmp(filter, n);
+}
+
+RS_Status ScanOperation::SetupBound(NdbIndexScanOperation *index_scan_op, bool upper) {
+  Uint32 count     = request->BoundColumnsCount(upper);
+  bool isInclusive = request->ScanFlags() &
+                     (upper ? RDRS_ISF_UPPER_INCLUSIVE : RDRS_ISF_LOWER_INCLUSIVE);
+
+  for (Uint32 i = 0; i < count; i++) {
+    // only the last column of the bound can be strict
+    int type;
+    if (upper) {
+      type = (i < count - 1 || isInclusive) ? NdbIndexScanOperation::BoundGE
+                                            : NdbIndexScanOperation::BoundGT;
+    } else {
+      type = (i < count - 1 || isInclusive) ? NdbIndexScanOperation::BoundLE
+                                            : NdbIndexScanOperation::BoundLT;
+    }
+
+    const NdbDictionary::Column *col = table_dict->getColumn(request->BoundColumnName(upper, i));
+    RS_Status status = ConvertColValue(
+        col, request->BoundValueCStr(upper, i), request->BoundValueLen(upper, i),
+        GetTemporalFormat(request), ERROR_041,
+        [&](const char *value, Uint32 len) -> int {
+          return index_scan_op->setBound(col->getName(), type, value);
+        });
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  }
+  return RS_OK;
+}
+
+RS_Status ScanOperation::SetupFilterCmp(NdbScanFilter *filter, Uint32 node) {
+  NdbScanFilter::BinaryCondition cond;
+  switch (request->FilterNodeType(node)) {
+  case RDRS_SF_EQ:
+    cond = NdbScanFilter::COND_EQ;
+    break;
+  case RDRS_SF_NE:
+    cond = NdbScanFilter::COND_NE;
+    break;
+  case RDRS_SF_LT:
+    cond = NdbScanFilter::COND_LT;
+    break;
+  case RDRS_SF_LE:
+    cond = NdbScanFilter::COND_LE;
+    break;
+  case RDRS_SF_GT:
+    cond = NdbScanFilter::COND_GT;
+    break;
+  case RDRS_SF_GE:
+    cond = NdbScanFilter::COND_GE;
+    break;
+  default:
+    return RS_CLIENT_ERROR(ERROR_039);
+  }
+
+  const NdbDictionary::Column *col = table_dict->getColumn(request->FilterNodeColumn(node));
+
+  // NdbScanFilter expects variable length values without the length prefix
+  // and fixed size values with the size of the column
+  return ConvertColValue(col, request->FilterNodeValueCStr(node), request->FilterNodeValueLen(node),
+                         GetTemporalFormat(request), ERROR_041,
+                         [&](const char *value, Uint32 len) -> int {
+                           switch (col->getArrayType()) {
+                           case NdbDictionary::Column::ArrayTypeShortVar:
+                             return filter->cmp(cond, col->getColumnNo(), value + 1, len - 1);
+                           case NdbDictionary::Column::ArrayTypeMediumVar:
+                             return filter->cmp(cond, col->getColumnNo(), value + 2, len - 2);
+                           default:
+                             return filter->cmp(
+                                 cond, col->getColumnNo(), value,
+                                 std::min(len, static_cast<Uint32>(col->getSizeInBytes())));
+                           }
+                         });
+}
+
+RS_Status ScanOperation::Execute() {
+  if (transaction->execute(NdbTransaction::NoCommit) != 0) {
+    return RS_RONDB_SERVER_ERROR(transaction->getNdbError(), ERROR_009);
+  }
+  return RS_OK;
+}
+
+RS_Status ScanOperation::CreateResponse() {
+  if (response->GetMaxCapacity() <= SCAN_RESP_HEADER_END) {
+    return RS_SERVER_ERROR(ERROR_016);
+  }
+  response->SetWriteHeader(SCAN_RESP_HEADER_END);
+
+  RS_Status status = WriteResponse();
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  Uint32 *header = reinterpret_cast<Uint32 *>(response->GetResponseBuffer());
+  header[SCAN_RESP_LENGTH_IDX]          = response->GetWriteHeader() - SCAN_RESP_HEADER_END;
+  header[SCAN_RESP_NEXT_KEY_OFFSET_IDX] = next_key_offset;
+  header[SCAN_RESP_NEXT_KEY_LENGTH_IDX] = next_key_length;
+  return RS_OK;
+}
+
+RS_Status ScanOperation::WriteResponse() {
+  // NDJSON responses contain one row per line without the enclosing object.
+  // The structure of binary responses is written by the writer
+  bool ndjson      = request->ScanFlags() & RDRS_ISF_NDJSON;
+  bool binary      = request->ResponseFormat() != RDRS_FORMAT_JSON;
+  RS_Status status = RS_OK;
+
+  if (binary) {
+    status = StartBinaryResponse();
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  } else if (!ndjson) {
+    status = response->Append_string("{", false, false);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+
+    if (request->OperationId() != nullptr) {
+      status = response->Append_string("\"operationId\": ", false, false);
+      if (status.http_code != SUCCESS) {
+        return status;
+      }
+      status = response->Append_string(request->OperationId(), true, true);
+      if (status.http_code != SUCCESS) {
+        return status;
+      }
+    }
+
+    status = response->Append_string("\"data\": [", false, false);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  }
+
+  Uint32 limit  = request->Limit();
+  Uint32 rows   = 0;
+  bool nextPage = false;  // the current row did not fit in this page
+  while (true) {
+    int check = scan_op->nextResult(true);
+    if (check == 1) {  // no more rows
+      break;
+    } else if (check != 0) {
+      return RS_RONDB_SERVER_ERROR(scan_op->getNdbError(), ERROR_042);
+    }
+
+    if (limit != 0 && rows == limit) {
+      nextPage = paginate;
+      break;
+    }
+
+    if (paginate && rows > 0 && response->GetRemainingCapacity() < token_reserve) {
+      nextPage = true;
+      break;
+    }
+
+    ResponseWriter::Mark rowStart = writer->GetMark();
+    if (rows > 0 && !ndjson && !binary) {
+      status = response->Append_string(",", false, false);
+    }
+    if (status.http_code == SUCCESS) {
+      status = AppendRow();
+    }
+    if (status.http_code == SUCCESS && ndjson) {
+      status = response->Append_string("\n", false, false);
+    }
+    if (status.http_code != SUCCESS) {
+      // the row does not fit in the response. Continue from this row on the next page
+      if (paginate && rows > 0 && strcmp(status.message, ERROR_016) == 0) {
+        writer->Rewind(rowStart);
+        nextPage = true;
+        break;
+      }
+      return status;
+    }
+    rows++;
+  }
+
+  if (ndjson) {
+    // the next page token is sent as the last line
+    if (!nextPage) {
+      return RS_OK;
+    }
+
+    status = response->Append_string("{", false, false);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    status = AppendNextPageToken();
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    return response->Append_string("}\n", false, false);
+  }
+
+  if (binary) {
+    status = writer->EndArray();
+    if (status.http_code == SUCCESS && nextPage) {
+      status = AppendNextPageToken();
+    }
+    if (status.http_code == SUCCESS) {
+      status = writer->EndObject();
+    }
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    return writer->Finish();
+  }
+
+  status = response->Append_string("]", false, false);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  if (nextPage) {
+    status = response->Append_string(", ", false, false);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+    status = AppendNextPageToken();
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  }
+
+  return response->Append_string("}", false, false);
+}
+
+RS_Status ScanOperation::StartBinaryResponse() {
+  RS_Status status = writer->Start();
+  if (status.http_code == SUCCESS) {
+    status = writer->BeginObject();
+  }
+  if (status.http_code == SUCCESS && request->OperationId() != nullptr) {
+    status = writer->Key("operationId");
+    if (status.http_code == SUCCESS) {
+      status = writer->String(request->OperationId());
+    }
+  }
+  if (status.http_code == SUCCESS && request->ResponseFormat() == RDRS_FORMAT_ARROW) {
+    status = writer->Key("schema");
+    if (status.http_code == SUCCESS) {
+      status = WriteArrowSchema(request, recs, blobs, writer.get());
+    }
+  }
+  if (status.http_code == SUCCESS) {
+    status = writer->Key("data");
+  }
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+  return writer->BeginArray();
+}
+
+RS_Status ScanOperation::AppendNextPageToken() {
+  // the token must be the last field of the response. The REST server
+  // replaces the key with a signed token, the location of the key is set
+  // in the header of JSON responses
+  const bool binary = request->ResponseFormat() != RDRS_FORMAT_JSON;
+  RS_Status status  = RS_OK;
+  if (binary) {
+    status = writer->Key("nextPageToken");
+    if (status.http_code == SUCCESS) {
+      status = writer->BeginArray();
+    }
+  } else {
+    status = response->Append_string("\"nextPageToken\": ", false, false);
+    if (status.http_code == SUCCESS) {
+      next_key_offset = response->GetWriteHeader() - SCAN_RESP_HEADER_END;
+      status          = response->Append_string("[", false, false);
+    }
+  }
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  // temporal keys are written as RFC 3339 strings in UTC, so that the next
+  // page starts at the same key whatever the time zone of that request is
+  TemporalFormat keyFmt;
+  keyFmt.format = RDRS_TF_RFC3339;
+
+  for (Uint32 i = 0; i < key_recs.size(); i++) {
+    const NdbDictionary::Column *col = key_recs[i]->getColumn();
+    if (binary) {
+      status = writer->BeginObject();
+      if (status.http_code == SUCCESS) {
+        status = writer->Key("column");
+      }
+      if (status.http_code == SUCCESS) {
+        status = writer->String(col->getName());
+      }
+      if (status.http_code == SUCCESS) {
+        status = writer->Key("value");
+      }
+    } else {
+      status = response->Append_string(std::string("{\"column\": \"") + col->getName() +
+                                           std::string("\", \"value\": "),
+                                       false, false);
+    }
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+
+    // CHAR keys are padded again when the scan is resumed
+    status = WriteColToRespBuff(key_recs[i], writer.get(), DEFAULT_DRT, keyFmt, MySQLColumnType(),
+                                col->getType() == NdbDictionary::Column::Char);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+
+    if (binary) {
+      status = writer->EndObject();
+    } else {
+      status = response->Append_string("}", false, i != (key_recs.size() - 1));
+    }
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  }
+
+  if (binary) {
+    return writer->EndArray();
+  }
+  status = response->Append_string("]", false, false);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+  next_key_length = response->GetWriteHeader() - SCAN_RESP_HEADER_END - next_key_offset;
+  return RS_OK;
+}
+
+RS_Status ScanOperation::AppendRow() {
+  RS_Status status = writer->BeginRow();
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  const bool omitNulls = (request->ResponseFlags() & RDRS_RF_OMIT_NULLS) != 0;
+  for (Uint32 i = 0; i < recs.size(); i++) {
+    const NdbDictionary::Column *col =
+        blobs[i] != nullptr ? blobs[i]->getColumn() : recs[i]->getColumn();
+
+    if (omitNulls) {
+      bool isNull = false;
+      status      = IsNullCol(recs[i], blobs[i], &isNull);
+      if (status.http_code != SUCCESS) {
+        return status;
+      }
+      if (isNull) {
+        continue;
+      }
+    }
+
+    status = writer->Key(col->getName());
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+
+    DataReturnType drt = GetReturnType(request, i, col);
+    if (blobs[i] != nullptr) {
+      status = WriteBlobColToRespBuff(blobs[i], writer.get(), drt, col_types[i]);
+    } else {
+      status = WriteColToRespBuff(recs[i], writer.get(), drt, GetTemporalFormat(request),
+                                  col_types[i], GetTrimPadding(request, i, col));
+    }
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+  }
+
+  return writer->EndRow();
+}
+
+void ScanOperation::CloseTransaction() {
+  // closing the transaction also closes the scan
+  ndb_object->closeTransaction(transaction);
+}
+
+RS_Status ScanOperation::PerformOperation() {
+  RS_Status status = Init();
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  status = ValidateRequest();
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  status = SetupTransaction();
+  if (status.http_code != SUCCESS) {
+    this->Abort();
+    return status;
+  }
+
+  status = SetupScan();
+  if (status.http_code != SUCCESS) {
+    this->Abort();
+    return status;
+  }
+
+  status = Execute();
+  if (status.http_code != SUCCESS) {
+    this->Abort();
+    return status;
+  }
+
+  status = CreateResponse();
+  if (status.http_code != SUCCESS) {
+    this->Abort();
+    return status;
+  }
+
+  CloseTransaction();
+  return RS_OK;
+}
+
+RS_Status ScanOperation::Abort() {
+  if (transaction != nullptr) {
+    NdbTransaction::CommitStatusType status = transaction->commitStatus();
+    if (status == NdbTransaction::CommitStatusType::Started) {
+      transaction->execute(NdbTransaction::Rollback);
+    }
+    ndb_object->closeTransaction(transaction);
+  }
+
+  return RS_OK;
+}
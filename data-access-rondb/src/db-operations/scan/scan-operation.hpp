@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+#ifndef DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_SCAN_SCAN_OPERATION_HPP_
+#define DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_SCAN_SCAN_OPERATION_HPP_
+
+#include <stdint.h>
+#include <memory>
+#include <vector>
+#include <NdbApi.hpp>
+#include "src/db-operations/scan/scan-request.hpp"
+#include "src/db-operations/pk/common.hpp"
+#include "src/db-operations/pk/pkr-response.hpp"
+#include "src/db-operations/pk/response-writer.hpp"
+#include "src/rdrs-dal.h"
+
+class ScanOperation {
+ private:
+  ScanRequest *request;
+  PKRResponse *response;
+  std::unique_ptr<ResponseWriter> writer;  // writes the rows in the format of the response
+  Ndb *ndb_object                        = nullptr;
+  NdbTransaction *transaction            = nullptr;
+  NdbScanOperation *scan_op              = nullptr;
+  const NdbDictionary::Table *table_dict = nullptr;
+  const NdbDictionary::Index *index_dict = nullptr;  // set for ordered index scans
+  std::vector<NdbRecAttr *> recs;          // columns that will be read from DB
+  std::vector<NdbBlob *> blobs;            // BLOB/TEXT columns. nullptr for other columns
+  std::vector<MySQLColumnType> col_types;  // MySQL types of the read columns
+  bool paginate = false;                   // scan is ordered by the primary key and can be resumed
+  std::vector<NdbRecAttr *> key_recs;      // primary key columns used for the next page token
+  Uint32 token_reserve   = 0;              // response space kept for the next page token
+  Uint32 next_key_offset = 0;              // location of the next page key in JSON responses
+  Uint32 next_key_length = 0;              // 0 if there is no next page
+
+ public:
+  ScanOperation(RS_Buffer *req_buff, RS_Buffer *resp_buff, Ndb *ndb_object);
+
+  ~ScanOperation();
+
+  /**
+   * perform the operation
+   */
+  RS_Status PerformOperation();
+
+ private:
+  /**
+   * initialize data structures
+   * @return status
+   */
+  RS_Status Init();
+
+  /**
+   * Validate request
+   * @return status
+   */
+  RS_Status ValidateRequest();
+
+  /**
+   * Validate the filter subtree rooted at the given node
+   *
+   * @param node[in/out]. root of the subtree. Set to the node following the subtree
+   * @return status
+   */
+  RS_Status ValidateFilter(Uint32 *node);
+
+  /**
+   * Validate that the bound columns are a prefix of the index columns
+   *
+   * @param upper[in]. upper or lower bound
+   * @return status
+   */
+  RS_Status ValidateBound(bool upper);
+
+  /**
+   * start a transaction
+   *
+   * @return status
+   */
+  RS_Status SetupTransaction();
+
+  /**
+   * setup scan operation, filter and read columns
+   * @returns status
+   */
+  RS_Status SetupScan();
+
+  /**
+   * Add the filter subtree rooted at the given node to the scan filter
+   *
+   * @param filter[in]. scan filter
+   * @param node[in/out]. root of the subtree. Set to the node following the subtree
+   * @return status
+   */
+  RS_Status SetupFilter(NdbScanFilter *filter, Uint32 *node);
+
+  /**
+   * Set the lower or upper bound of an ordered index scan
+   *
+   * @param index_scan_op[in]. index scan operation
+   * @param upper[in]. upper or lower bound
+   * @return status
+   */
+  RS_Status SetupBound(NdbIndexScanOperation *index_scan_op, bool upper);
+
+  /**
+   * Add comparison to the scan filter
+   *
+   * @param filter[in]. scan filter
+   * @param node[in]. comparison node
+   * @return status
+   */
+  RS_Status SetupFilterCmp(NdbScanFilter *filter, Uint32 node);
+
+  /**
+   * Execute transaction
+   *
+   * @return status
+   */
+  RS_Status Execute();
+
+  /**
+   * fetch the rows and create response. The header of the response is
+   * set once the rows are written, see SCAN_RESP_* in rdrs-const.h
+   *
+   * @return status
+   */
+  RS_Status CreateResponse();
+
+  /**
+   * fetch the rows and write them after the header of the response
+   *
+   * @return status
+   */
+  RS_Status WriteResponse();
+
+  /**
+   * Start a binary response, i.e., write the operation ID, the schema of
+   * Arrow responses and open the array of rows
+   *
+   * @return status
+   */
+  RS_Status StartBinaryResponse();
+
+  /**
+   * Append current row to response buffer
+   * @return status
+   */
+  RS_Status AppendRow();
+
+  /**
+   * Append the primary key of the current row as the next page token.
+   * The next page starts at this row
+   *
+   * @return status
+   */
+  RS_Status AppendNextPageToken();
+
+  /**
+   * Close transaction
+   */
+  void CloseTransaction();
+
+  /**
+   * abort operation
+   */
+  RS_Status Abort();
+};
+#endif  // DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_SCAN_SCAN_OPERATION_HPP_
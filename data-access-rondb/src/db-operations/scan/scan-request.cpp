@@ -0,0 +1,120 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#include "src/db-operations/scan/scan-request.hpp"
+#include "src/rdrs-const.h"
+
+// Filter layout
+// [count][ node 0 ][ node 1 ]...[ node n ][ bytes ... ]
+//
+// node: [ type ][ arg 1 ][ arg 2 ]
+//   and/or/not : arg 1 is the number of children. The children follow the node
+//   comparisons : arg 1 is the column name offset and arg 2 is the value offset
+//   isnull      : arg 1 is the column name offset
+//
+// values are stored as [ 2B size ][ bytes ... ], the same as the primary key values
+
+#define FILTER_NODE_SIZE 3
+
+ScanRequest::ScanRequest(const RS_Buffer *request) : PKRRequest(request) {
+}
+
+Uint32 ScanRequest::FilterNodesCount() {
+  Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_FILTER_IDX];
+  if (offset == 0) {
+    return 0;
+  }
+  return (reinterpret_cast<Uint32 *>(req->buffer))[offset / ADDRESS_SIZE];
+}
+
+Uint32 ScanRequest::FilterNodeOffset(const Uint32 n) {
+  Uint32 offset = (reinterpret_cast<Uint32 *>(req->buffer))[PKR_FILTER_IDX];
+  return offset + ADDRESS_SIZE + (n * FILTER_NODE_SIZE * ADDRESS_SIZE);  // skip count
+}
+
+Uint32 ScanRequest::FilterNodeType(const Uint32 n) {
+  return (reinterpret_cast<Uint32 *>(req->buffer))[FilterNodeOffset(n) / ADDRESS_SIZE];
+}
+
+Uint32 ScanRequest::FilterNodeChildren(const Uint32 n) {
+  return (reinterpret_cast<Uint32 *>(req->buffer))[(FilterNodeOffset(n) / ADDRESS_SIZE) + 1];
+}
+
+const char *ScanRequest::FilterNodeColumn(const Uint32 n) {
+  Uint32 cOffset =
+      (reinterpret_cast<Uint32 *>(req->buffer))[(FilterNodeOffset(n) / ADDRESS_SIZE) + 1];
+  return req->buffer + cOffset;
+}
+
+const char *ScanRequest::FilterNodeValueCStr(const Uint32 n) {
+  Uint32 vOffset =
+      (reinterpret_cast<Uint32 *>(req->buffer))[(FilterNodeOffset(n) / ADDRESS_SIZE) + 2];
+  return req->buffer + vOffset + 2;  // skip first 2 bytes that contain size of string
+}
+
+Uint16 ScanRequest::FilterNodeValueLen(const Uint32 n) {
+  Uint32 vOffset =
+      (reinterpret_cast<Uint32 *>(req->buffer))[(FilterNodeOffset(n) / ADDRESS_SIZE) + 2];
+  unsigned char *data_start = (unsigned char *)req->buffer + vOffset;
+  return ((Uint16)data_start[1] * (Uint16)256) + (Uint16)data_start[0];
+}
+
+Uint32 ScanRequest::Limit() {
+  return (reinterpret_cast<Uint32 *>(req->buffer))[PKR_LIMIT_IDX];
+}
+
+Uint32 ScanRequest::ScanFlags() {
+  return (reinterpret_cast<Uint32 *>(req->buffer))[PKR_SCAN_FLAGS_IDX];
+}
+
+// The bounds use the same layout as the primary key columns
+Uint32 ScanRequest::BoundColumnsCount(bool upper) {
+  Uint32 offset =
+      (reinterpret_cast<Uint32 *>(req->buffer))[upper ? PKR_UPPER_BOUND_IDX : PKR_LOWER_BOUND_IDX];
+  if (offset == 0) {
+    return 0;
+  }
+  return (reinterpret_cast<Uint32 *>(req->buffer))[offset / ADDRESS_SIZE];
+}
+
+Uint32 ScanRequest::BoundTupleOffset(bool upper, const Uint32 n) {
+  Uint32 offset =
+      (reinterpret_cast<Uint32 *>(req->buffer))[upper ? PKR_UPPER_BOUND_IDX : PKR_LOWER_BOUND_IDX];
+  // +1 for count
+  return (reinterpret_cast<Uint32 *>(req->buffer))[(offset / ADDRESS_SIZE) + 1 + n];
+}
+
+const char *ScanRequest::BoundColumnName(bool upper, const Uint32 n) {
+  Uint32 kvOffset = BoundTupleOffset(upper, n);
+  Uint32 kOffset  = (reinterpret_cast<Uint32 *>(req->buffer))[kvOffset / ADDRESS_SIZE];
+  return req->buffer + kOffset;
+}
+
+const char *ScanRequest::BoundValueCStr(bool upper, const Uint32 n) {
+  Uint32 kvOffset = BoundTupleOffset(upper, n);
+  Uint32 vOffset  = (reinterpret_cast<Uint32 *>(req->buffer))[(kvOffset / ADDRESS_SIZE) + 1];
+  return req->buffer + vOffset + 2;  // skip first 2 bytes that contain size of string
+}
+
+Uint16 ScanRequest::BoundValueLen(bool upper, const Uint32 n) {
+  Uint32 kvOffset = BoundTupleOffset(upper, n);
+  Uint32 vOffset  = (reinterpret_cast<Uint32 *>(req->buffer))[(kvOffset / ADDRESS_SIZE) + 1];
+  unsigned char *data_start = (unsigned char *)req->buffer + vOffset;
+  return ((Uint16)data_start[1] * (Uint16)256) + (Uint16)data_start[0];
+}
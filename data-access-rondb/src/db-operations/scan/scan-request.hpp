@@ -0,0 +1,150 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+#ifndef DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_SCAN_SCAN_REQUEST_HPP_
+#define DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_SCAN_SCAN_REQUEST_HPP_
+
+#include <stdint.h>
+#include <NdbApi.hpp>
+#include "src/rdrs-dal.h"
+#include "src/db-operations/pk/pkr-request.hpp"
+
+/**
+ * Scan request. The header, DB, table, read columns and
+ * operation ID are the same as for the primary key read request
+ */
+class ScanRequest : public PKRRequest {
+ private:
+  /**
+   * Get offset of nth filter node
+   *
+   * @param n nth node
+   * @return offset
+   */
+  Uint32 FilterNodeOffset(const Uint32 n);
+
+  /**
+   * Get offset of the nth column/value pair of a bound
+   *
+   * @param upper[in]. upper or lower bound
+   * @param n[in]. nth column
+   * @return offset
+   */
+  Uint32 BoundTupleOffset(bool upper, const Uint32 n);
+
+ public:
+  explicit ScanRequest(const RS_Buffer *request);
+
+  /**
+   * Get number of filter nodes. The nodes are stored in pre-order
+   *
+   * @return number of nodes. 0 if the scan has no filter
+   */
+  Uint32 FilterNodesCount();
+
+  /**
+   * Get type of the nth filter node, e.g., RDRS_SF_AND
+   *
+   * @param n[in]. index
+   * @return node type
+   */
+  Uint32 FilterNodeType(const Uint32 n);
+
+  /**
+   * Get number of children of the nth filter node. Only valid for and/or/not nodes
+   *
+   * @param n[in]. index
+   * @return number of children
+   */
+  Uint32 FilterNodeChildren(const Uint32 n);
+
+  /**
+   * Get column of the nth filter node. Only valid for comparison and isnull nodes
+   *
+   * @param n[in]. index
+   * @return column name
+   */
+  const char *FilterNodeColumn(const Uint32 n);
+
+  /**
+   * Get value of the nth filter node. Only valid for comparison nodes
+   *
+   * @param n[in]. index
+   * @return c-string for the value
+   */
+  const char *FilterNodeValueCStr(const Uint32 n);
+
+  /**
+   * Get length of the value of the nth filter node
+   *
+   * @param n[in]. index
+   * @return length of the value
+   */
+  Uint16 FilterNodeValueLen(const Uint32 n);
+
+  /**
+   * Get max number of rows to return
+   *
+   * @return limit. 0 if not set
+   */
+  Uint32 Limit();
+
+  /**
+   * Get index scan flags, e.g., RDRS_ISF_DESCENDING
+   *
+   * @return flags
+   */
+  Uint32 ScanFlags();
+
+  /**
+   * Get number of columns in a bound. The columns are a prefix of the index columns
+   *
+   * @param upper[in]. upper or lower bound
+   * @return number of columns. 0 if the bound is not set
+   */
+  Uint32 BoundColumnsCount(bool upper);
+
+  /**
+   * Get name of the nth column of a bound
+   *
+   * @param upper[in]. upper or lower bound
+   * @param n[in]. index
+   * @return column name
+   */
+  const char *BoundColumnName(bool upper, const Uint32 n);
+
+  /**
+   * Get value of the nth column of a bound
+   *
+   * @param upper[in]. upper or lower bound
+   * @param n[in]. index
+   * @return c-string for the value
+   */
+  const char *BoundValueCStr(bool upper, const Uint32 n);
+
+  /**
+   * Get length of the value of the nth column of a bound
+   *
+   * @param upper[in]. upper or lower bound
+   * @param n[in]. index
+   * @return length of the value
+   */
+  Uint16 BoundValueLen(bool upper, const Uint32 n);
+};
+
+#endif  // DATA_ACCESS_RONDB_SRC_DB_OPERATIONS_SCAN_SCAN_REQUEST_HPP_
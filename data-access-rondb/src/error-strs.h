@@ -40,7 +40,7 @@ extern "C" {
 #define ERROR_014 "Wrong primay-key column."
 #define ERROR_015 "Wrong data type."
 #define ERROR_016 "Response buffer overflow."
-#define ERROR_017 "Hash indexes on float and double; and indexes on Blob types are not supported."
+#define ERROR_017 "Indexes on Blob types are not supported."
 #define ERROR_018 "Undefined data type."
 #define ERROR_019 "Unable to read data."
 #define ERROR_020 "Column length too big."
@@ -52,6 +52,29 @@ extern "C" {
 #define ERROR_026 "Reading BLOB/TEXT column is not supported yet."
 #define ERROR_027 "Invalid Date/Time."
 #define ERROR_028 "Programming error. Please report bug."
+#define ERROR_029 "Column is not nullable."
+#define ERROR_030 "Writing BLOB/TEXT column is not supported yet."
+#define ERROR_031 "Failed to start write operation."
+#define ERROR_032 "Failed to set NdbOperation::setValue()."
+#define ERROR_033 "Atomic batch operation failed. No changes were made."
+#define ERROR_034 "Transaction does not exist or has expired."
+#define ERROR_035 "Too many open transactions."
+#define ERROR_036 "Transaction is being used by another request."
+#define ERROR_037 "Failed to commit transaction."
+#define ERROR_038 "Invalid lock mode."
+#define ERROR_039 "Invalid scan filter."
+#define ERROR_040 "Filtering on BLOB/TEXT columns is not supported."
+#define ERROR_041 "Failed to set up scan operation."
+#define ERROR_042 "Failed to read scan results."
+#define ERROR_043 "Index does not exist."
+#define ERROR_044 "Index is not an ordered index."
+#define ERROR_045 "Invalid index bound."
+#define ERROR_046 "Index is not a unique index."
+#define ERROR_047 "BLOB/TEXT column is larger than the max read size."
+#define ERROR_048 "Failed to read BLOB/TEXT column."
+#define ERROR_049 "Column is not a BLOB/TEXT column."
+#define ERROR_050 "Column value does not match the MySQL type of the column."
+#define ERROR_051 "String can not be converted to the character set of the column."
 
 #ifdef __cplusplus
 }
@@ -0,0 +1,335 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#include "src/json-binary.hpp"
+#include <stdint.h>
+#include <cstdio>
+#include <cstdlib>
+#include <cstring>
+#include <string>
+#include <boost/beast/core/detail/base64.hpp>
+#include "src/mystring.hpp"
+#include "src/rondb-lib/decimal_utils.hpp"
+#include "src/rondb-lib/rdrs_date.hpp"
+
+// value types of the binary format
+#define JSONB_TYPE_SMALL_OBJECT 0x0
+#define JSONB_TYPE_LARGE_OBJECT 0x1
+#define JSONB_TYPE_SMALL_ARRAY  0x2
+#define JSONB_TYPE_LARGE_ARRAY  0x3
+#define JSONB_TYPE_LITERAL      0x4
+#define JSONB_TYPE_INT16        0x5
+#define JSONB_TYPE_UINT16       0x6
+#define JSONB_TYPE_INT32        0x7
+#define JSONB_TYPE_UINT32       0x8
+#define JSONB_TYPE_INT64        0x9
+#define JSONB_TYPE_UINT64       0xA
+#define JSONB_TYPE_DOUBLE       0xB
+#define JSONB_TYPE_STRING       0xC
+#define JSONB_TYPE_OPAQUE       0xF
+
+#define JSONB_NULL_LITERAL  0x0
+#define JSONB_TRUE_LITERAL  0x1
+#define JSONB_FALSE_LITERAL 0x2
+
+// MySQL field types of the opaque values that are not printed as base64
+#define FIELD_TYPE_TIMESTAMP  7
+#define FIELD_TYPE_DATE       10
+#define FIELD_TYPE_TIME       11
+#define FIELD_TYPE_DATETIME   12
+#define FIELD_TYPE_NEWDECIMAL 246
+
+// the deepest document MySQL accepts
+#define JSON_MAX_DEPTH 100
+
+namespace {
+
+Uint64 ReadLE(const char *data, size_t bytes) {
+  Uint64 value = 0;
+  for (size_t i = 0; i < bytes; i++) {
+    value |= static_cast<Uint64>(static_cast<unsigned char>(data[i])) << (8 * i);
+  }
+  return value;
+}
+
+/**
+ * Lengths of strings and opaque values use 7 bits of every byte. The high
+ * bit is set if more bytes follow
+ */
+bool ReadVarLength(const char *data, size_t len, Uint32 *length, size_t *bytes) {
+  Uint64 value = 0;
+  for (size_t i = 0; i < len && i < 5; i++) {
+    unsigned char byte = static_cast<unsigned char>(data[i]);
+    value |= static_cast<Uint64>(byte & 0x7F) << (7 * i);
+    if ((byte & 0x80) == 0) {
+      if (value > UINT32_MAX) {
+        return false;
+      }
+      *length = static_cast<Uint32>(value);
+      *bytes  = i + 1;
+      return true;
+    }
+  }
+  return false;
+}
+
+void AppendQuoted(const std::string &str, std::string *out) {
+  out->append("\"");
+  out->append(escape_string(str));
+  out->append("\"");
+}
+
+void AppendDouble(double value, std::string *out) {
+  char buf[32];
+  snprintf(buf, sizeof(buf), "%.15g", value);
+  if (strtod(buf, nullptr) != value) {
+    snprintf(buf, sizeof(buf), "%.17g", value);
+  }
+
+  // MySQL shows that the value is a double, e.g., 1.0
+  std::string str(buf);
+  if (str.find_first_of(".eEni") == std::string::npos) {
+    str += ".0";
+  }
+  out->append(str);
+}
+
+bool AppendOpaque(Uint8 fieldType, const char *data, size_t len, std::string *out) {
+  switch (fieldType) {
+  case FIELD_TYPE_NEWDECIMAL: {
+    // precision, scale and the binary decimal
+    if (len < 2) {
+      return false;
+    }
+    int precision = static_cast<unsigned char>(data[0]);
+    int scale     = static_cast<unsigned char>(data[1]);
+    if (precision == 0 || precision > MaxMySQLDecimalPrecision || scale > precision ||
+        static_cast<int>(len) - 2 != getDecimalColumnSpace(precision, scale)) {
+      return false;
+    }
+    char decStr[MaxDecimalStrLen];
+    if (decimal_bin2str(data + 2, static_cast<int>(len) - 2, precision, scale, decStr,
+                        MaxDecimalStrLen) != E_DEC_OK) {
+      return false;
+    }
+    out->append(decStr);
+    return true;
+  }
+  case FIELD_TYPE_DATE:
+  case FIELD_TYPE_TIME:
+  case FIELD_TYPE_DATETIME:
+  case FIELD_TYPE_TIMESTAMP: {
+    // packed temporal value
+    if (len != 8) {
+      return false;
+    }
+    longlong packed = static_cast<longlong>(ReadLE(data, 8));
+    MYSQL_TIME l_time;
+    if (fieldType == FIELD_TYPE_TIME) {
+      TIME_from_longlong_time_packed(&l_time, packed);
+    } else {
+      TIME_from_longlong_datetime_packed(&l_time, packed);
+      if (fieldType == FIELD_TYPE_DATE) {
+        l_time.time_type = MYSQL_TIMESTAMP_DATE;
+      }
+    }
+    char to[MAX_DATE_STRING_REP_LENGTH];
+    my_TIME_to_str(l_time, to, 6);
+    AppendQuoted(to, out);
+    return true;
+  }
+  default: {
+    // other values, e.g., BLOBs, are printed as base64:typeNN:<data>
+    std::string encoded(boost::beast::detail::base64::encoded_size(len), 0);
+    encoded.resize(boost::beast::detail::base64::encode(&encoded[0], data, len));
+    AppendQuoted("base64:type" + std::to_string(fieldType) + ":" + encoded, out);
+    return true;
+  }
+  }
+}
+
+bool AppendValue(Uint8 type, const char *data, size_t len, int depth, std::string *out);
+
+/**
+ * Objects and arrays start with the element count and the size in bytes,
+ * followed by the key entries (objects only) and the value entries.
+ * Offsets are from the start of the object or array
+ */
+bool AppendContainer(bool isObject, bool large, const char *data, size_t len, int depth,
+                     std::string *out) {
+  const size_t offsetSize = large ? 4 : 2;
+  if (depth > JSON_MAX_DEPTH || len < 2 * offsetSize) {
+    return false;
+  }
+
+  const Uint64 count = ReadLE(data, offsetSize);
+  const Uint64 size  = ReadLE(data + offsetSize, offsetSize);
+  const size_t keyEntrySize   = offsetSize + 2;
+  const size_t valueEntrySize = 1 + offsetSize;
+  const size_t headerSize =
+      2 * offsetSize + count * ((isObject ? keyEntrySize : 0) + valueEntrySize);
+  if (size > len || headerSize > size) {
+    return false;
+  }
+
+  out->append(isObject ? "{" : "[");
+  for (Uint64 i = 0; i < count; i++) {
+    if (i > 0) {
+      out->append(", ");
+    }
+
+    if (isObject) {
+      const char *keyEntry = data + 2 * offsetSize + i * keyEntrySize;
+      Uint64 keyOffset     = ReadLE(keyEntry, offsetSize);
+      Uint64 keyLength     = ReadLE(keyEntry + offsetSize, 2);
+      if (keyOffset + keyLength > size) {
+        return false;
+      }
+      AppendQuoted(std::string(data + keyOffset, keyLength), out);
+      out->append(": ");
+    }
+
+    const char *valueEntry = data + 2 * offsetSize +
+                             count * (isObject ? keyEntrySize : 0) + i * valueEntrySize;
+    Uint8 type = static_cast<Uint8>(valueEntry[0]);
+
+    // small values are stored in the value entry instead of the offset
+    bool inlined = type == JSONB_TYPE_LITERAL || type == JSONB_TYPE_INT16 ||
+                   type == JSONB_TYPE_UINT16 ||
+                   (large && (type == JSONB_TYPE_INT32 || type == JSONB_TYPE_UINT32));
+    if (inlined) {
+      if (!AppendValue(type, valueEntry + 1, offsetSize, depth + 1, out)) {
+        return false;
+      }
+      continue;
+    }
+
+    Uint64 valueOffset = ReadLE(valueEntry + 1, offsetSize);
+    if (valueOffset < headerSize || valueOffset >= size) {
+      return false;
+    }
+    if (!AppendValue(type, data + valueOffset, size - valueOffset, depth + 1, out)) {
+      return false;
+    }
+  }
+  out->append(isObject ? "}" : "]");
+  return true;
+}
+
+bool AppendValue(Uint8 type, const char *data, size_t len, int depth, std::string *out) {
+  switch (type) {
+  case JSONB_TYPE_SMALL_OBJECT:
+  case JSONB_TYPE_LARGE_OBJECT:
+    return AppendContainer(true, type == JSONB_TYPE_LARGE_OBJECT, data, len, depth, out);
+  case JSONB_TYPE_SMALL_ARRAY:
+  case JSONB_TYPE_LARGE_ARRAY:
+    return AppendContainer(false, type == JSONB_TYPE_LARGE_ARRAY, data, len, depth, out);
+  case JSONB_TYPE_LITERAL: {
+    if (len < 1) {
+      return false;
+    }
+    switch (static_cast<Uint8>(data[0])) {
+    case JSONB_NULL_LITERAL:
+      out->append("null");
+      return true;
+    case JSONB_TRUE_LITERAL:
+      out->append("true");
+      return true;
+    case JSONB_FALSE_LITERAL:
+      out->append("false");
+      return true;
+    default:
+      return false;
+    }
+  }
+  case JSONB_TYPE_INT16:
+  case JSONB_TYPE_UINT16: {
+    if (len < 2) {
+      return false;
+    }
+    Uint16 value = static_cast<Uint16>(ReadLE(data, 2));
+    out->append(type == JSONB_TYPE_INT16 ? std::to_string(static_cast<Int16>(value))
+                                         : std::to_string(value));
+    return true;
+  }
+  case JSONB_TYPE_INT32:
+  case JSONB_TYPE_UINT32: {
+    if (len < 4) {
+      return false;
+    }
+    Uint32 value = static_cast<Uint32>(ReadLE(data, 4));
+    out->append(type == JSONB_TYPE_INT32 ? std::to_string(static_cast<Int32>(value))
+                                         : std::to_string(value));
+    return true;
+  }
+  case JSONB_TYPE_INT64:
+  case JSONB_TYPE_UINT64: {
+    if (len < 8) {
+      return false;
+    }
+    Uint64 value = ReadLE(data, 8);
+    out->append(type == JSONB_TYPE_INT64 ? std::to_string(static_cast<Int64>(value))
+                                         : std::to_string(value));
+    return true;
+  }
+  case JSONB_TYPE_DOUBLE: {
+    if (len < 8) {
+      return false;
+    }
+    Uint64 bits = ReadLE(data, 8);
+    double value;
+    memcpy(&value, &bits, sizeof(value));
+    AppendDouble(value, out);
+    return true;
+  }
+  case JSONB_TYPE_STRING: {
+    Uint32 length = 0;
+    size_t bytes  = 0;
+    if (!ReadVarLength(data, len, &length, &bytes) || bytes + length > len) {
+      return false;
+    }
+    AppendQuoted(std::string(data + bytes, length), out);
+    return true;
+  }
+  case JSONB_TYPE_OPAQUE: {
+    Uint32 length = 0;
+    size_t bytes  = 0;
+    if (len < 1 || !ReadVarLength(data + 1, len - 1, &length, &bytes) ||
+        1 + bytes + length > len) {
+      return false;
+    }
+    return AppendOpaque(static_cast<Uint8>(data[0]), data + 1 + bytes, length, out);
+  }
+  default:
+    return false;
+  }
+}
+
+}  // namespace
+
+bool JsonBinaryToText(const char *data, size_t len, std::string *out) {
+  out->clear();
+
+  // MySQL reads an empty document as the JSON null literal
+  if (len == 0) {
+    out->append("null");
+    return true;
+  }
+  return AppendValue(static_cast<Uint8>(data[0]), data + 1, len - 1, 0, out);
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#ifndef DATA_ACCESS_RONDB_SRC_JSON_BINARY_HPP_
+#define DATA_ACCESS_RONDB_SRC_JSON_BINARY_HPP_
+
+#include <string>
+
+/**
+ * Convert a document of a MySQL JSON column to JSON text. MySQL stores
+ * JSON columns in its binary JSON format, see sql/json_binary.h in the
+ * MySQL sources. The text is formatted the same way as MySQL does it
+ *
+ * @param[in] data the binary document
+ * @param[in] len length of the document
+ * @param[out] out the JSON text
+ * @return true if the document is valid
+ */
+bool JsonBinaryToText(const char *data, size_t len, std::string *out);
+
+#endif  // DATA_ACCESS_RONDB_SRC_JSON_BINARY_HPP_
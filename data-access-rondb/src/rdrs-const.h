@@ -29,19 +29,129 @@ extern "C" {
 #define ADDRESS_SIZE 4
 
 // Request Type Identifiers
-#define RDRS_PK_REQ_ID    1
-#define RDRS_BATCH_REQ_ID 2
-
-// Primary Key Read Request Header Indexes
-#define PKR_OP_TYPE_IDX   0
-#define PKR_CAPACITY_IDX  1
-#define PKR_LENGTH_IDX    2
-#define PKR_DB_IDX        3
-#define PKR_TABLE_IDX     4
-#define PKR_PK_COLS_IDX   5
-#define PKR_READ_COLS_IDX 6
-#define PKR_OP_ID_IDX     7
-#define PKR_HEADER_END    32
+#define RDRS_PK_REQ_ID         1
+#define RDRS_BATCH_REQ_ID      2
+#define RDRS_PK_INSERT_REQ_ID  3
+#define RDRS_PK_UPDATE_REQ_ID  4
+#define RDRS_PK_UPSERT_REQ_ID  5
+#define RDRS_PK_DELETE_REQ_ID  6
+#define RDRS_SCAN_REQ_ID       7
+#define RDRS_INDEX_SCAN_REQ_ID 8
+#define RDRS_BLOB_READ_REQ_ID  9
+
+// Lock modes of read operations. 0 is committed read
+#define RDRS_LM_COMMITTED 1
+#define RDRS_LM_SHARED    2
+#define RDRS_LM_EXCLUSIVE 3
+
+// Index scan flags
+#define RDRS_ISF_LOWER_INCLUSIVE 1
+#define RDRS_ISF_UPPER_INCLUSIVE 2
+#define RDRS_ISF_DESCENDING      4
+#define RDRS_ISF_PAGINATE        8
+#define RDRS_ISF_NDJSON          16
+
+// Scan filter node types
+#define RDRS_SF_AND    1
+#define RDRS_SF_OR     2
+#define RDRS_SF_NOT    3
+#define RDRS_SF_EQ     4
+#define RDRS_SF_NE     5
+#define RDRS_SF_LT     6
+#define RDRS_SF_LE     7
+#define RDRS_SF_GT     8
+#define RDRS_SF_GE     9
+#define RDRS_SF_ISNULL 10
+
+// Response flags of read operations
+#define RDRS_RF_BIG_NUMBERS_AS_STRINGS 1
+#define RDRS_RF_OMIT_NULLS             2
+#define RDRS_RF_INCLUDE_NULL_MASK      4
+
+// Formats of DATE, DATETIME, TIME and TIMESTAMP values
+#define RDRS_TF_MYSQL        0
+#define RDRS_TF_RFC3339      1
+#define RDRS_TF_EPOCH_MILLIS 2
+
+// Trimming of the padding of CHAR and BINARY read columns. The default
+// is set in the configuration of the REST server
+#define RDRS_TRIM_DEFAULT 0
+#define RDRS_TRIM_PADDING 1
+#define RDRS_KEEP_PADDING 2
+
+// MySQL types of the columns that NDB stores as other types. ENUM and SET
+// columns are stored as CHAR and JSON columns as BLOB
+#define RDRS_CT_ENUM 1
+#define RDRS_CT_SET  2
+#define RDRS_CT_JSON 3
+
+// Flags of the values of write operations. The values are sent as text,
+// the flags tell which JSON type they had
+#define RDRS_VF_STRING 1
+
+// Formats of the responses of pk read, write and batch operations. The
+// MessagePack and CBOR responses are prefixed with their length in bytes.
+// Arrow responses are MessagePack responses that also contain the Arrow
+// types of the read columns. The REST server converts them to Arrow
+#define RDRS_FORMAT_JSON    0
+#define RDRS_FORMAT_MSGPACK 1
+#define RDRS_FORMAT_CBOR    2
+#define RDRS_FORMAT_ARROW   3
+
+// RS_Status.rdrs_code. Errors that must be told apart from other errors
+// with the same HTTP code. A 404 for a missing transaction has no response
+// body, unlike the 404 for a missing row
+#define RDRS_ERR_NONE         0
+#define RDRS_ERR_TX_NOT_FOUND 1
+
+// Explicit transactions are identified by 128 random bits, hex encoded.
+// The TX ID slot of the request header stores the offset of the null
+// terminated ID
+#define TX_ID_LEN 32
+
+// Primary Key Read Request Header Indexes. Scan and blob read requests use the same header
+#define PKR_OP_TYPE_IDX     0
+#define PKR_CAPACITY_IDX    1
+#define PKR_LENGTH_IDX      2
+#define PKR_DB_IDX          3
+#define PKR_TABLE_IDX       4
+#define PKR_PK_COLS_IDX     5
+#define PKR_READ_COLS_IDX   6
+#define PKR_OP_ID_IDX       7
+#define PKR_TX_ID_IDX       8
+#define PKR_VALUES_IDX      9
+#define PKR_LOCK_MODE_IDX   10
+#define PKR_FILTER_IDX      11
+#define PKR_LIMIT_IDX       12
+#define PKR_INDEX_IDX       13
+#define PKR_LOWER_BOUND_IDX 14
+#define PKR_UPPER_BOUND_IDX 15
+#define PKR_SCAN_FLAGS_IDX  16
+#define PKR_BLOB_OFFSET_IDX 17
+#define PKR_RESP_FLAGS_IDX  18
+#define PKR_TIME_FORMAT_IDX 19
+#define PKR_TIME_ZONE_IDX   20
+#define PKR_COL_TYPES_IDX   21
+#define PKR_RESP_FORMAT_IDX 22
+// size of the header in bytes. The body starts after the last slot
+#define PKR_HEADER_END      92
+
+// Blob read response header indexes. The data follows the header
+#define BLOB_RESP_NULL_IDX   0
+#define BLOB_RESP_LENGTH_IDX 1
+#define BLOB_RESP_BYTES_IDX  2
+#define BLOB_RESP_TEXT_IDX   3
+#define BLOB_RESP_HEADER_END 16
+
+// Scan response header indexes. The response follows the header. LENGTH
+// is the length of the response in bytes. NEXT_KEY is the primary key of
+// the first row of the next page in JSON and NDJSON responses, its offset
+// is relative to the start of the response. The length is 0 if there is no
+// next page
+#define SCAN_RESP_LENGTH_IDX          0
+#define SCAN_RESP_NEXT_KEY_OFFSET_IDX 1
+#define SCAN_RESP_NEXT_KEY_LENGTH_IDX 2
+#define SCAN_RESP_HEADER_END          16
 
 #ifdef __cplusplus
 }
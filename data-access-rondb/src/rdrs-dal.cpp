@@ -28,9 +28,13 @@
 #include <NdbApi.hpp>
 #include "src/error-strs.h"
 #include "src/logger.hpp"
+#include "db-operations/blob/blob-read-operation.hpp"
+#include "db-operations/pk/common.hpp"
 #include "db-operations/pk/pkr-operation.hpp"
+#include "db-operations/scan/scan-operation.hpp"
 #include "src/status.hpp"
 #include "src/ndb_object_pool.hpp"
+#include "src/tx_manager.hpp"
 
 int GetAvailableAPINode(const char *connection_string);
 
@@ -83,6 +87,7 @@ RS_Status Init(const char *connection_string, _Bool find_available_node_id) {
 RS_Status Shutdown() {
   try {
     // ndb_end(0); // causes seg faults when called repeated from unit tests*/
+    TxManager::GetInstance()->Close();
     NdbObjectPool::GetInstance()->Close();
     delete ndb_connection;
   } catch (...) {
@@ -103,16 +108,40 @@ RS_Status CloseNDBObject(Ndb *ndb_object) {
   return RS_OK;
 }
 
-RS_Status PKRead(RS_Buffer *reqBuff, RS_Buffer *respBuff) {
+/**
+ * Run a single primary key operation. If the request carries a transaction ID
+ * then the operation is executed as part of that transaction
+ */
+RS_Status PKOperation(RS_Buffer *reqBuff, RS_Buffer *respBuff) {
+  PKRRequest req(reqBuff);
+  const char *tx_id = req.TxId();
+  if (tx_id != nullptr) {
+    Ndb *ndb_object             = nullptr;
+    NdbTransaction *transaction = nullptr;
+    RS_Status status = TxManager::GetInstance()->Acquire(tx_id, &ndb_object, &transaction);
+    if (status.http_code != SUCCESS) {
+      return status;
+    }
+
+    PKROperation pkop(reqBuff, respBuff, ndb_object, transaction);
+    status = pkop.PerformOperation();
+    if (pkop.TxFailed()) {
+      TxManager::GetInstance()->Drop(tx_id);
+    } else {
+      TxManager::GetInstance()->Return(tx_id);
+    }
+    return status;
+  }
+
   Ndb *ndb_object  = nullptr;
   RS_Status status = NdbObjectPool::GetInstance()->GetNdbObject(ndb_connection, &ndb_object);
   if (status.http_code != SUCCESS) {
     return status;
   }
 
-  PKROperation pkread(reqBuff, respBuff, ndb_object);
+  PKROperation pkop(reqBuff, respBuff, ndb_object);
 
-  status = pkread.PerformOperation();
+  status = pkop.PerformOperation();
   CloseNDBObject(ndb_object);
   if (status.http_code != SUCCESS) {
     return status;
@@ -121,6 +150,18 @@ RS_Status PKRead(RS_Buffer *reqBuff, RS_Buffer *respBuff) {
   return RS_OK;
 }
 
+RS_Status PKRead(RS_Buffer *reqBuff, RS_Buffer *respBuff) {
+  return PKOperation(reqBuff, respBuff);
+}
+
+/**
+ * Primary key write operation. Insert, update, upsert or delete
+ */
+
+RS_Status PKWrite(RS_Buffer *reqBuff, RS_Buffer *respBuff) {
+  return PKOperation(reqBuff, respBuff);
+}
+
 /**
  * Batched primary key read operation
  */
@@ -143,6 +184,146 @@ RS_Status PKBatchRead(unsigned int no_req, RS_Buffer *req_buffs, RS_Buffer *resp
   return RS_OK;
 }
 
+/**
+ * Batched primary key read and write operations
+ */
+
+RS_Status PKBatchOperation(unsigned int no_req, RS_Buffer *req_buffs, RS_Buffer *resp_buffs,
+                           bool atomic) {
+  Ndb *ndb_object  = nullptr;
+  RS_Status status = NdbObjectPool::GetInstance()->GetNdbObject(ndb_connection, &ndb_object);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  PKROperation pkop(no_req, req_buffs, resp_buffs, ndb_object, atomic);
+
+  status = pkop.PerformOperation();
+  CloseNDBObject(ndb_object);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  return RS_OK;
+}
+
+/**
+ * Table and ordered index scan operation
+ */
+RS_Status Scan(RS_Buffer *reqBuff, RS_Buffer *respBuff) {
+  Ndb *ndb_object  = nullptr;
+  RS_Status status = NdbObjectPool::GetInstance()->GetNdbObject(ndb_connection, &ndb_object);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  ScanOperation scan(reqBuff, respBuff, ndb_object);
+
+  status = scan.PerformOperation();
+  CloseNDBObject(ndb_object);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  return RS_OK;
+}
+
+/**
+ * Open a BLOB/TEXT reader
+ */
+RS_Status BlobOpen(RS_Buffer *reqBuff, RS_Buffer *respBuff, void **reader) {
+  Ndb *ndb_object  = nullptr;
+  RS_Status status = NdbObjectPool::GetInstance()->GetNdbObject(ndb_connection, &ndb_object);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  BlobReadOperation *blobRead = new BlobReadOperation(ndb_object);
+
+  status = blobRead->Open(reqBuff, respBuff);
+  if (status.http_code != SUCCESS) {
+    delete blobRead;
+    CloseNDBObject(ndb_object);
+    return status;
+  }
+
+  *reader = blobRead;
+  return RS_OK;
+}
+
+/**
+ * Read a part of a BLOB/TEXT column
+ */
+RS_Status BlobRead(void *reader, RS_Buffer *reqBuff, RS_Buffer *respBuff) {
+  return static_cast<BlobReadOperation *>(reader)->Read(reqBuff, respBuff);
+}
+
+/**
+ * Close a BLOB/TEXT reader
+ */
+void BlobClose(void *reader) {
+  BlobReadOperation *blobRead = static_cast<BlobReadOperation *>(reader);
+  Ndb *ndb_object             = blobRead->GetNdbObject();
+  delete blobRead;
+  CloseNDBObject(ndb_object);
+}
+
+/**
+ * Start an explicit transaction
+ */
+RS_Status TxBegin(char *tx_id) {
+  return TxManager::GetInstance()->Begin(ndb_connection, tx_id);
+}
+
+/**
+ * Commit an explicit transaction
+ */
+RS_Status TxCommit(const char *tx_id) {
+  return TxManager::GetInstance()->Commit(tx_id);
+}
+
+/**
+ * Abort an explicit transaction
+ */
+RS_Status TxAbort(const char *tx_id) {
+  return TxManager::GetInstance()->Abort(tx_id);
+}
+
+/**
+ * Abort idle explicit transactions
+ */
+RS_Status TxAbortIdle() {
+  Uint32 count = TxManager::GetInstance()->AbortIdle();
+  if (count > 0) {
+    INFO(std::string("Aborted idle transactions: ") + std::to_string(count));
+  }
+  return RS_OK;
+}
+
+/**
+ * Configure explicit transactions
+ */
+RS_Status TxConfigure(unsigned int max_open_txs, unsigned int idle_timeout_ms) {
+  TxManager::GetInstance()->Configure(max_open_txs, idle_timeout_ms);
+  return RS_OK;
+}
+
+/**
+ * Configure BLOB/TEXT reads
+ */
+RS_Status BlobConfigure(unsigned int max_read_size) {
+  SetMaxBlobReadSize(max_read_size);
+  return RS_OK;
+}
+
+/**
+ * Configure the trimming of the padding of CHAR and BINARY columns
+ */
+RS_Status PaddingConfigure(unsigned int trim_char, unsigned int trim_binary) {
+  SetTrimPadding(trim_char != 0, trim_binary != 0);
+  return RS_OK;
+}
+
 /**
  * Deallocate pointer array
  */
@@ -27,10 +27,12 @@ extern "C" {
 #include <stdbool.h>
 
 typedef enum HTTP_CODE {
-  SUCCESS      = 200,
-  CLIENT_ERROR = 400,
-  NOT_FOUND    = 404,
-  SERVER_ERROR = 500
+  SUCCESS           = 200,
+  CLIENT_ERROR      = 400,
+  NOT_FOUND         = 404,
+  CONFLICT          = 409,
+  TOO_MANY_REQUESTS = 429,
+  SERVER_ERROR      = 500
 } HTTP_CODE;
 
 // Status 
@@ -45,6 +47,7 @@ typedef struct RS_Status {
   char message[RS_STATUS_MSG_LEN];  // error message.
   int err_line_no;                  // error line number
   char err_file_name[RS_STATUS_FILE_NAME_LEN];  // error file name.
+  int rdrs_code;                    // RDRS_ERR_*. The errors the REST server handles separately
 } RS_Status;
 
 // Log Message 
@@ -56,13 +59,17 @@ typedef struct RS_LOG_MSG {
 
 // Data return type. You can change the return type for the column data
 // int/floats/decimal are returned as JSON Number type (default),
-// varchar/char are returned as strings (default) and varbinary as base64 (default)
-// Right now only default return type is supported
+// varchar/char are returned as strings (default) and varbinary as base64 (default).
+// The bytes of char, binary, bit and BLOB/TEXT columns can also be returned
+// as base64 or hex encoded strings. 64 bit integers and decimals can be
+// returned as JSON strings as clients may not be able to parse them as numbers
 typedef enum DataReturnType {
   DEFAULT_DRT = 1,
-  // BASE64 = 2;
+  BASE64_DRT  = 2,
+  HEX_DRT     = 3,
+  STRING_DRT  = 4,
 
-  __MAX_TYPE_NOT_A_DRT = 1
+  __MAX_TYPE_NOT_A_DRT = 4
 } DataReturnType;
 
 // Buffer that contain request or response objects
@@ -96,11 +103,84 @@ RS_Status Shutdown();
  */
 RS_Status PKRead(RS_Buffer *reqBuff, RS_Buffer *respBuff);
 
+/**
+ * Primary key write operation. Insert, update, upsert or delete
+ */
+RS_Status PKWrite(RS_Buffer *reqBuff, RS_Buffer *respBuff);
+
 /**
  * Batched primary key read operation
  */
 RS_Status PKBatchRead(unsigned int no_req, RS_Buffer *req_buffs, RS_Buffer *resp_buffs);
 
+/**
+ * Batched primary key read and write operations executed in a single transaction.
+ * If atomic is set then either all operations are committed or none
+ */
+RS_Status PKBatchOperation(unsigned int no_req, RS_Buffer *req_buffs, RS_Buffer *resp_buffs,
+                           bool atomic);
+
+/**
+ * Scan a table or a range of an ordered index. Rows can be filtered using a scan filter
+ */
+RS_Status Scan(RS_Buffer *reqBuff, RS_Buffer *respBuff);
+
+/**
+ * Open a reader for a BLOB/TEXT column of a row and read the part of the
+ * value that starts at the offset set in the request. The row is share
+ * locked until the reader is closed with BlobClose. The reader is not
+ * opened if the status is not SUCCESS
+ */
+RS_Status BlobOpen(RS_Buffer *reqBuff, RS_Buffer *respBuff, void **reader);
+
+/**
+ * Read the next part of a BLOB/TEXT value with an open reader, starting at
+ * the offset set in the request. Large values are read with multiple calls
+ */
+RS_Status BlobRead(void *reader, RS_Buffer *reqBuff, RS_Buffer *respBuff);
+
+/**
+ * Close a BLOB/TEXT reader and release the lock on the row
+ */
+void BlobClose(void *reader);
+
+/**
+ * Start an explicit transaction. The returned ID is set in the TX ID slot
+ * of the subsequent pk read/write requests. tx_id must have room for
+ * TX_ID_LEN characters and the null terminator
+ */
+RS_Status TxBegin(char *tx_id);
+
+/**
+ * Commit an explicit transaction
+ */
+RS_Status TxCommit(const char *tx_id);
+
+/**
+ * Abort an explicit transaction
+ */
+RS_Status TxAbort(const char *tx_id);
+
+/**
+ * Abort explicit transactions that have been idle for too long
+ */
+RS_Status TxAbortIdle();
+
+/**
+ * Set the max number of open explicit transactions and the idle timeout
+ */
+RS_Status TxConfigure(unsigned int max_open_txs, unsigned int idle_timeout_ms);
+
+/**
+ * Set the max size of the BLOB/TEXT columns that can be read
+ */
+RS_Status BlobConfigure(unsigned int max_read_size);
+
+/**
+ * Set whether the padding of CHAR and BINARY columns is trimmed by default
+ */
+RS_Status PaddingConfigure(unsigned int trim_char, unsigned int trim_binary);
+
 /**
  * Deallocate pointer array
  */
@@ -33,4 +33,7 @@ size_t well_formed_copy_nchars(const CHARSET_INFO *to_cs, char *to, size_t to_le
                                const CHARSET_INFO *from_cs, const char *from, size_t from_length,
                                size_t nchars, const char **well_formed_error_pos,
                                const char **cannot_convert_error_pos, const char **from_end_pos);
+
+// charset defined in RonDB lib
+extern CHARSET_INFO my_charset_utf8mb4_bin;
 #endif  // DATA_ACCESS_RONDB_SRC_RONDB_LIB_RDRS_STRING_HPP_
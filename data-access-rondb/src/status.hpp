@@ -25,6 +25,7 @@
 #include <iostream>
 #include <NdbApi.hpp>
 #include "src/rdrs-dal.h"
+#include "src/rdrs-const.h"
 
 ///**
 // * create an object of RS_Status.
@@ -40,7 +41,8 @@
 //}
 
 inline RS_Status __RS_ERROR(const HTTP_CODE http_code, int status, int classification, int code,
-                            int mysql_code, std::string msg, int line_no, std::string file_name) {
+                            int mysql_code, std::string msg, int line_no, std::string file_name,
+                            int rdrs_code = RDRS_ERR_NONE) {
   RS_Status ret;
   ret.http_code      = http_code;
   ret.status         = status;
@@ -48,6 +50,7 @@ inline RS_Status __RS_ERROR(const HTTP_CODE http_code, int status, int classific
   ret.code           = code;
   ret.mysql_code     = mysql_code;
   ret.err_line_no    = line_no;
+  ret.rdrs_code      = rdrs_code;
 
   strncpy(ret.message, msg.c_str(), RS_STATUS_MSG_LEN - 1);  // last byte for null terminator char
   strncpy(ret.err_file_name, file_name.c_str(),
@@ -72,6 +75,14 @@ inline RS_Status __RS_ERROR_RONDB(const struct NdbError &error, std::string msg,
   __RS_ERROR(CLIENT_ERROR, -1, -1, -1, -1, msg, __LINE__, __MYFILENAME__);
 #define RS_CLIENT_404_ERROR()                                                                      \
   __RS_ERROR(NOT_FOUND, -1, -1, -1, -1, "Not Found", __LINE__, __MYFILENAME__);
+#define RS_CLIENT_404_WITH_MSG_ERROR(msg)                                                          \
+  __RS_ERROR(NOT_FOUND, -1, -1, -1, -1, msg, __LINE__, __MYFILENAME__);
+#define RS_CLIENT_404_TX_ERROR(msg)                                                                \
+  __RS_ERROR(NOT_FOUND, -1, -1, -1, -1, msg, __LINE__, __MYFILENAME__, RDRS_ERR_TX_NOT_FOUND);
+#define RS_CLIENT_409_ERROR(msg)                                                                   \
+  __RS_ERROR(CONFLICT, -1, -1, -1, -1, msg, __LINE__, __MYFILENAME__);
+#define RS_CLIENT_429_ERROR(msg)                                                                   \
+  __RS_ERROR(TOO_MANY_REQUESTS, -1, -1, -1, -1, msg, __LINE__, __MYFILENAME__);
 #define RS_SERVER_ERROR(msg)                                                                       \
   __RS_ERROR(SERVER_ERROR, -1, -1, -1, -1, msg, __LINE__, __MYFILENAME__);
 #define RS_RONDB_SERVER_ERROR(ndberror, msg)                                                       \
@@ -0,0 +1,255 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#include "tx_manager.hpp"
+#include <unistd.h>
+#include <cstring>
+#include <vector>
+#include "src/status.hpp"
+#include "src/error-strs.h"
+#include "src/ndb_object_pool.hpp"
+#include "src/rdrs-const.h"
+
+TxManager *TxManager::__instance = nullptr;
+std::once_flag TxManager::__instance_flag;
+
+TxManager *TxManager::GetInstance() {
+  // called concurrently by the requests. The instance is never deleted, so
+  // it outlives the requests that are still running at exit
+  std::call_once(__instance_flag, []() { __instance = new TxManager(); });
+  return __instance;
+}
+
+void TxManager::Configure(Uint32 max_open_txs, Uint32 idle_timeout_ms) {
+  std::lock_guard<std::mutex> guard(__mutex);
+  __max_open_txs    = max_open_txs;
+  __idle_timeout_ms = idle_timeout_ms;
+}
+
+RS_Status TxManager::NewTxId(std::string *tx_id) {
+  // getentropy() reads the kernel CSPRNG, the same as /dev/urandom
+  unsigned char bytes[TX_ID_LEN / 2];
+  if (getentropy(bytes, sizeof(bytes)) != 0) {
+    return RS_SERVER_ERROR(ERROR_005 + std::string(" Failed to generate the transaction ID"));
+  }
+
+  static const char hex[] = "0123456789abcdef";
+  tx_id->clear();
+  for (size_t i = 0; i < sizeof(bytes); i++) {
+    tx_id->push_back(hex[bytes[i] >> 4]);
+    tx_id->push_back(hex[bytes[i] & 0x0F]);
+  }
+  return RS_OK;
+}
+
+RS_Status TxManager::Begin(Ndb_cluster_connection *ndb_connection, char *tx_id) {
+  // the slot is reserved under the lock, the transaction is started without
+  // it as it is a round trip to the data nodes. Reserved slots are in use,
+  // so they can not be acquired or aborted yet
+  std::string id;
+  {
+    std::lock_guard<std::mutex> guard(__mutex);
+    if (__txs.size() >= __max_open_txs) {
+      return RS_CLIENT_429_ERROR(ERROR_035);
+    }
+
+    do {
+      RS_Status status = NewTxId(&id);
+      if (status.http_code != SUCCESS) {
+        return status;
+      }
+    } while (__txs.count(id) != 0);
+
+    TxHandle handle;
+    handle.ndb_object  = nullptr;
+    handle.transaction = nullptr;
+    handle.last_used   = std::chrono::steady_clock::now();
+    handle.in_use      = true;
+    __txs[id]          = handle;
+  }
+
+  Ndb *ndb_object             = nullptr;
+  NdbTransaction *transaction = nullptr;
+  RS_Status status = NdbObjectPool::GetInstance()->GetNdbObject(ndb_connection, &ndb_object);
+  if (status.http_code == SUCCESS) {
+    transaction = ndb_object->startTransaction();
+    if (transaction == nullptr) {
+      status = RS_RONDB_SERVER_ERROR(ndb_object->getNdbError(), ERROR_005);
+      NdbObjectPool::GetInstance()->ReturnResource(ndb_object);
+    }
+  }
+
+  bool closed = false;
+  {
+    std::lock_guard<std::mutex> guard(__mutex);
+    auto it = __txs.find(id);
+    if (status.http_code != SUCCESS) {
+      if (it != __txs.end()) {
+        __txs.erase(it);
+      }
+      return status;
+    }
+
+    // Close() removes the reserved slots too
+    if (it == __txs.end()) {
+      closed = true;
+    } else {
+      it->second.ndb_object  = ndb_object;
+      it->second.transaction = transaction;
+      it->second.last_used   = std::chrono::steady_clock::now();
+      it->second.in_use      = false;
+    }
+  }
+
+  if (closed) {
+    TxHandle handle;
+    handle.ndb_object  = ndb_object;
+    handle.transaction = transaction;
+    Release(&handle);
+    return RS_SERVER_ERROR(ERROR_005);
+  }
+
+  memcpy(tx_id, id.c_str(), TX_ID_LEN + 1);
+  return RS_OK;
+}
+
+RS_Status TxManager::Acquire(const std::string &tx_id, Ndb **ndb_object,
+                             NdbTransaction **transaction) {
+  std::lock_guard<std::mutex> guard(__mutex);
+  auto it = __txs.find(tx_id);
+  if (it == __txs.end()) {
+    return RS_CLIENT_404_TX_ERROR(ERROR_034);
+  }
+  if (it->second.in_use) {
+    return RS_CLIENT_409_ERROR(ERROR_036);
+  }
+
+  it->second.in_use = true;
+  *ndb_object       = it->second.ndb_object;
+  *transaction      = it->second.transaction;
+  return RS_OK;
+}
+
+void TxManager::Return(const std::string &tx_id) {
+  std::lock_guard<std::mutex> guard(__mutex);
+  auto it = __txs.find(tx_id);
+  if (it != __txs.end()) {
+    it->second.in_use    = false;
+    it->second.last_used = std::chrono::steady_clock::now();
+  }
+}
+
+void TxManager::Drop(const std::string &tx_id) {
+  TxHandle handle;
+  {
+    std::lock_guard<std::mutex> guard(__mutex);
+    auto it = __txs.find(tx_id);
+    if (it == __txs.end()) {
+      return;
+    }
+    handle = it->second;
+    __txs.erase(it);
+  }
+  Release(&handle);
+}
+
+RS_Status TxManager::Remove(const std::string &tx_id, TxHandle *handle) {
+  std::lock_guard<std::mutex> guard(__mutex);
+  auto it = __txs.find(tx_id);
+  if (it == __txs.end()) {
+    return RS_CLIENT_404_TX_ERROR(ERROR_034);
+  }
+  if (it->second.in_use) {
+    return RS_CLIENT_409_ERROR(ERROR_036);
+  }
+
+  *handle = it->second;
+  __txs.erase(it);
+  return RS_OK;
+}
+
+void TxManager::Release(TxHandle *handle) {
+  NdbTransaction::CommitStatusType status = handle->transaction->commitStatus();
+  if (status == NdbTransaction::CommitStatusType::Started) {
+    handle->transaction->execute(NdbTransaction::Rollback);
+  }
+  handle->ndb_object->closeTransaction(handle->transaction);
+  NdbObjectPool::GetInstance()->ReturnResource(handle->ndb_object);
+}
+
+RS_Status TxManager::Commit(const std::string &tx_id) {
+  TxHandle handle;
+  RS_Status status = Remove(tx_id, &handle);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  if (handle.transaction->execute(NdbTransaction::Commit) != 0) {
+    // copy the error before the transaction is closed
+    status = RS_RONDB_SERVER_ERROR(handle.transaction->getNdbError(), ERROR_037);
+  }
+  Release(&handle);
+  return status;
+}
+
+RS_Status TxManager::Abort(const std::string &tx_id) {
+  TxHandle handle;
+  RS_Status status = Remove(tx_id, &handle);
+  if (status.http_code != SUCCESS) {
+    return status;
+  }
+
+  Release(&handle);
+  return RS_OK;
+}
+
+Uint32 TxManager::AbortIdle() {
+  std::vector<TxHandle> idle;
+  {
+    std::lock_guard<std::mutex> guard(__mutex);
+    auto now = std::chrono::steady_clock::now();
+    for (auto it = __txs.begin(); it != __txs.end();) {
+      auto idle_ms =
+          std::chrono::duration_cast<std::chrono::milliseconds>(now - it->second.last_used);
+      if (!it->second.in_use && idle_ms.count() > __idle_timeout_ms) {
+        idle.push_back(it->second);
+        it = __txs.erase(it);
+      } else {
+        it++;
+      }
+    }
+  }
+
+  for (size_t i = 0; i < idle.size(); i++) {
+    Release(&idle[i]);
+  }
+  return idle.size();
+}
+
+RS_Status TxManager::Close() {
+  std::lock_guard<std::mutex> guard(__mutex);
+  for (auto it = __txs.begin(); it != __txs.end(); it++) {
+    // the transactions of reserved slots are released by Begin()
+    if (it->second.transaction != nullptr) {
+      Release(&it->second);
+    }
+  }
+  __txs.clear();
+  return RS_OK;
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+#ifndef DATA_ACCESS_RONDB_SRC_TX_MANAGER_HPP_
+#define DATA_ACCESS_RONDB_SRC_TX_MANAGER_HPP_
+
+#include <NdbApi.hpp>
+#include <chrono>
+#include <mutex>
+#include <string>
+#include <unordered_map>
+#include "rdrs-dal.h"
+
+/**
+ * Keeps track of the explicit transactions started by the clients.
+ * Every open transaction holds an Ndb object from the NdbObjectPool,
+ * so the number of open transactions is capped and the transactions
+ * that are not used for a while are aborted
+ */
+class TxManager {
+ private:
+  typedef struct TxHandle {
+    Ndb *ndb_object;
+    NdbTransaction *transaction;
+    std::chrono::steady_clock::time_point last_used;
+    bool in_use;
+  } TxHandle;
+
+  std::unordered_map<std::string, TxHandle> __txs;
+  std::mutex __mutex;
+  Uint32 __max_open_txs    = 1024;
+  Uint32 __idle_timeout_ms = 30000;
+
+  static TxManager *__instance;
+  static std::once_flag __instance_flag;
+  TxManager() {
+  }
+
+  /**
+   * Generate a new transaction ID. The REST server does not authenticate
+   * the clients, so the IDs are random and can not be guessed
+   *
+   * @param tx_id[out]. transaction ID, TX_ID_LEN hex characters
+   * @return status
+   */
+  static RS_Status NewTxId(std::string *tx_id);
+
+  /**
+   * Remove transaction from the list of open transactions
+   *
+   * @param tx_id. transaction ID
+   * @param handle[out]. removed transaction
+   * @return status
+   */
+  RS_Status Remove(const std::string &tx_id, TxHandle *handle);
+
+  /**
+   * Rollback and close transaction and return the Ndb object to the pool
+   */
+  void Release(TxHandle *handle);
+
+ public:
+  /**
+   * Static method for accessing class instance.
+   *
+   * @return TxManager instance.
+   */
+  static TxManager *GetInstance();
+
+  /**
+   * Set max number of open transactions and the idle timeout
+   */
+  void Configure(Uint32 max_open_txs, Uint32 idle_timeout_ms);
+
+  /**
+   * Start a new transaction
+   *
+   * @param ndb_connection. cluster connection
+   * @param tx_id[out]. ID of the new transaction. It must have room for
+   * TX_ID_LEN characters and the null terminator
+   * @return status
+   */
+  RS_Status Begin(Ndb_cluster_connection *ndb_connection, char *tx_id);
+
+  /**
+   * Get the transaction for running operations. The transaction can not
+   * be used by other requests until it is returned using Return()
+   *
+   * @param tx_id. transaction ID
+   * @param ndb_object[out]. Ndb object of the transaction
+   * @param transaction[out]. transaction
+   * @return status
+   */
+  RS_Status Acquire(const std::string &tx_id, Ndb **ndb_object, NdbTransaction **transaction);
+
+  /**
+   * Return transaction acquired using Acquire()
+   *
+   * @param tx_id. transaction ID
+   */
+  void Return(const std::string &tx_id);
+
+  /**
+   * Rollback and close transaction acquired using Acquire(). It is used
+   * instead of Return() when an operation leaves the transaction unusable.
+   * Later requests get ERROR_034
+   *
+   * @param tx_id. transaction ID
+   */
+  void Drop(const std::string &tx_id);
+
+  /**
+   * Commit and close transaction
+   *
+   * @param tx_id. transaction ID
+   * @return status
+   */
+  RS_Status Commit(const std::string &tx_id);
+
+  /**
+   * Rollback and close transaction
+   *
+   * @param tx_id. transaction ID
+   * @return status
+   */
+  RS_Status Abort(const std::string &tx_id);
+
+  /**
+   * Abort transactions that have not been used for longer than the idle timeout
+   *
+   * @return number of aborted transactions
+   */
+  Uint32 AbortIdle();
+
+  /**
+   * Abort all open transactions
+   *
+   * @return status
+   */
+  RS_Status Close();
+};
+#endif  // DATA_ACCESS_RONDB_SRC_TX_MANAGER_HPP_
@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2022 Hopsworks AB
+ *
+ * This program is free software; you can redistribute it and/or
+ * modify it under the terms of the GNU General Public License
+ * as published by the Free Software Foundation; either version 2
+ * of the License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301,
+ * USA.
+ */
+
+// Unit tests of the old DECIMAL, DATETIME, TIME and TIMESTAMP formats.
+// MySQL 8 can not create columns of these types, so they can not be tested
+// through the REST API
+
+#include <cstdio>
+#include <cstring>
+#include <string>
+#include "src/db-operations/pk/type-conversions.hpp"
+
+static int failures = 0;
+
+#define CHECK(cond)                                                                  \
+  do {                                                                               \
+    if (!(cond)) {                                                                   \
+      fprintf(stderr, "%s:%d: check failed: %s\n", __FILE__, __LINE__, #cond);      \
+      failures++;                                                                    \
+    }                                                                                \
+  } while (0)
+
+static MYSQL_TIME NewTime(Uint32 year, Uint32 month, Uint32 day, Uint32 hour, Uint32 minute,
+                          Uint32 second, bool neg = false) {
+  MYSQL_TIME l_time = {};
+  l_time.year       = year;
+  l_time.month      = month;
+  l_time.day        = day;
+  l_time.hour       = hour;
+  l_time.minute     = minute;
+  l_time.second     = second;
+  l_time.neg        = neg;
+  return l_time;
+}
+
+static bool SameTime(const MYSQL_TIME &a, const MYSQL_TIME &b) {
+  return a.year == b.year && a.month == b.month && a.day == b.day && a.hour == b.hour &&
+         a.minute == b.minute && a.second == b.second && a.second_part == b.second_part &&
+         a.neg == b.neg;
+}
+
+static std::string OldDecimal(const std::string &value, int precision, int scale, size_t size,
+                              bool isUnsigned = false) {
+  std::string out;
+  if (!ToOldDecimalStr(value.data(), value.size(), precision, scale, size, isUnsigned, &out)) {
+    return "invalid";
+  }
+  return out;
+}
+
+static void TestOldDecimal() {
+  // DECIMAL(6,2) is stored in 8 bytes, sign, 4 digits, point and 2 digits
+  CHECK(OldDecimal("-12.5", 6, 2, 8) == "  -12.50");
+  CHECK(OldDecimal("+12", 6, 2, 8) == "   12.00");
+  CHECK(OldDecimal("0012.25", 6, 2, 8) == "   12.25");
+  CHECK(OldDecimal(".5", 6, 2, 8) == "    0.50");
+  CHECK(OldDecimal("9999.99", 6, 2, 8) == " 9999.99");
+  CHECK(OldDecimal("-9999.99", 6, 2, 8) == "-9999.99");
+  CHECK(OldDecimal("42", 4, 0, 5) == "   42");
+
+  CHECK(OldDecimal("10000", 6, 2, 8) == "invalid");
+  CHECK(OldDecimal("1.234", 6, 2, 8) == "invalid");
+  CHECK(OldDecimal("1e2", 6, 2, 8) == "invalid");
+  CHECK(OldDecimal("", 6, 2, 8) == "invalid");
+  CHECK(OldDecimal("-", 6, 2, 8) == "invalid");
+  CHECK(OldDecimal(".", 6, 2, 8) == "invalid");
+  CHECK(OldDecimal("-1", 6, 2, 8, true) == "invalid");
+  CHECK(OldDecimal("1", 6, 2, 8, true) == "    1.00");
+
+  CHECK(FromOldDecimalStr("  -12.50", 8) == "-12.50");
+  CHECK(FromOldDecimalStr("    0.50", 8) == "0.50");
+  CHECK(FromOldDecimalStr("00012.50", 8) == "12.50");
+  CHECK(FromOldDecimalStr("-0000.50", 8) == "-0.50");
+  CHECK(FromOldDecimalStr("   +0042", 8) == "42");
+  CHECK(FromOldDecimalStr("       0", 8) == "0");
+}
+
+static void TestOldDatetime() {
+  MYSQL_TIME l_time = NewTime(2022, 1, 31, 23, 59, 58);
+  CHECK(PackOldDatetime(l_time) == 20220131235958ULL);
+
+  MYSQL_TIME unpacked;
+  UnpackOldDatetime(20220131235958ULL, &unpacked);
+  CHECK(SameTime(unpacked, l_time));
+  CHECK(unpacked.time_type == MYSQL_TIMESTAMP_DATETIME);
+
+  // zero dates are kept as they are
+  UnpackOldDatetime(0, &unpacked);
+  CHECK(SameTime(unpacked, NewTime(0, 0, 0, 0, 0, 0)));
+  CHECK(PackOldDatetime(NewTime(0, 0, 0, 0, 0, 0)) == 0);
+
+  l_time = NewTime(9999, 12, 31, 23, 59, 59);
+  UnpackOldDatetime(PackOldDatetime(l_time), &unpacked);
+  CHECK(SameTime(unpacked, l_time));
+}
+
+static void TestOldTime() {
+  char packed[3];
+  MYSQL_TIME unpacked;
+
+  // 12:34:56 is the number 123456 = 0x01E240
+  MYSQL_TIME l_time = NewTime(0, 0, 0, 12, 34, 56);
+  PackOldTime(l_time, packed);
+  CHECK(memcmp(packed, "\x40\xE2\x01", 3) == 0);
+  UnpackOldTime(packed, &unpacked);
+  CHECK(SameTime(unpacked, l_time));
+  CHECK(unpacked.time_type == MYSQL_TIMESTAMP_TIME);
+
+  // negative times are stored in two's complement
+  l_time = NewTime(0, 0, 0, 838, 59, 59, true);
+  PackOldTime(l_time, packed);
+  CHECK(memcmp(packed, "\x59\x0A\x80", 3) == 0);
+  UnpackOldTime(packed, &unpacked);
+  CHECK(SameTime(unpacked, l_time));
+
+  l_time = NewTime(0, 0, 0, 0, 0, 1, true);
+  PackOldTime(l_time, packed);
+  CHECK(memcmp(packed, "\xFF\xFF\xFF", 3) == 0);
+  UnpackOldTime(packed, &unpacked);
+  CHECK(SameTime(unpacked, l_time));
+
+  PackOldTime(NewTime(0, 0, 0, 0, 0, 0), packed);
+  CHECK(memcmp(packed, "\x00\x00\x00", 3) == 0);
+}
+
+static void TestTimestamp() {
+  Int64 epoch = 0;
+
+  CHECK(TimeToEpoch(NewTime(1970, 1, 1, 0, 0, 1), 0, &epoch) && epoch == 1);
+  CHECK(TimeToEpoch(NewTime(2038, 1, 19, 3, 14, 7), 0, &epoch) && epoch == 2147483647);
+  CHECK(TimeToEpoch(NewTime(2022, 3, 1, 12, 0, 0), 0, &epoch) && epoch == 1646136000);
+
+  // the time is in the time zone of the request
+  CHECK(TimeToEpoch(NewTime(2022, 3, 1, 14, 0, 0), 7200, &epoch) && epoch == 1646136000);
+
+  // outside of the TIMESTAMP range
+  CHECK(!TimeToEpoch(NewTime(1970, 1, 1, 0, 0, 0), 0, &epoch));
+  CHECK(!TimeToEpoch(NewTime(2038, 1, 19, 3, 14, 8), 0, &epoch));
+  CHECK(!TimeToEpoch(NewTime(1970, 1, 1, 1, 0, 0), 7200, &epoch));
+  CHECK(!TimeToEpoch(NewTime(2022, 0, 1, 0, 0, 0), 0, &epoch));
+
+  MYSQL_TIME l_time;
+  EpochToTime(1646136000, 0, 0, &l_time);
+  CHECK(SameTime(l_time, NewTime(2022, 3, 1, 12, 0, 0)));
+  EpochToTime(1646136000, 0, -3600, &l_time);
+  CHECK(SameTime(l_time, NewTime(2022, 3, 1, 11, 0, 0)));
+
+  // leap days
+  EpochToTime(951782400, 0, 0, &l_time);
+  CHECK(SameTime(l_time, NewTime(2000, 2, 29, 0, 0, 0)));
+  CHECK(TimeToEpoch(NewTime(2000, 2, 29, 0, 0, 0), 0, &epoch) && epoch == 951782400);
+
+  // round trip of times spread over the TIMESTAMP range
+  for (Int64 seconds = 1; seconds <= 2147483647 - 86400; seconds += 86400 + 3661) {
+    EpochToTime(seconds, 0, 0, &l_time);
+    CHECK(TimeToEpoch(l_time, 0, &epoch) && epoch == seconds);
+  }
+
+  // dates before the epoch
+  SecondsToTime(-1, 0, &l_time);
+  CHECK(SameTime(l_time, NewTime(1969, 12, 31, 23, 59, 59)));
+  CHECK(TimeToSeconds(NewTime(1969, 12, 31, 23, 59, 59)) == -1);
+}
+
+int main() {
+  TestOldDecimal();
+  TestOldDatetime();
+  TestOldTime();
+  TestTimestamp();
+
+  if (failures != 0) {
+    fprintf(stderr, "%d checks failed\n", failures);
+    return 1;
+  }
+  printf("PASS\n");
+  return 0;
+}
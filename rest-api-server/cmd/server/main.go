@@ -20,7 +20,10 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
+	"os/signal"
 	"runtime"
+	"syscall"
 
 	"hopsworks.ai/rdrs/internal/config"
 	"hopsworks.ai/rdrs/internal/log"
@@ -50,6 +53,17 @@ func main() {
 	if err != nil {
 		log.Panic(fmt.Sprintf("Unable to setup router: Error: %v", err))
 	}
+
+	// StartRouter returns when the router is stopped
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		if err := router.StopRouter(); err != nil {
+			log.Errorf("Unable to stop router: Error: %v", err)
+		}
+	}()
+
 	err = router.StartRouter()
 	if err != nil {
 		log.Panic(fmt.Sprintf("Unable to start router: Error: %v", err))
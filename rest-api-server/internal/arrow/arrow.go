@@ -0,0 +1,363 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package arrow builds Arrow record batches from the rows returned by the
+// data access layer and writes them in the Arrow IPC streaming format,
+// see https://arrow.apache.org/docs/format/Columnar.html. Only the types
+// that the data access layer returns are supported
+package arrow
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Field is a column of a record batch. The type is in the format of the
+// Arrow C data interface, e.g., "i" for int32 or "u" for UTF-8 strings.
+// The data access layer derives it from the NDB type of the column
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// kinds of the supported types
+const (
+	kindInt = iota
+	kindFloat
+	kindUtf8
+	kindBinary
+	kindDecimal
+	kindDate
+	kindTimestamp
+	kindDuration
+	kindList
+)
+
+type fieldType struct {
+	kind      int
+	bitWidth  int // width of fixed size values
+	signed    bool
+	precision int
+	scale     int
+	timezone  string
+}
+
+var intTypes = map[string]fieldType{
+	"c": {kind: kindInt, bitWidth: 8, signed: true},
+	"C": {kind: kindInt, bitWidth: 8},
+	"s": {kind: kindInt, bitWidth: 16, signed: true},
+	"S": {kind: kindInt, bitWidth: 16},
+	"i": {kind: kindInt, bitWidth: 32, signed: true},
+	"I": {kind: kindInt, bitWidth: 32},
+	"l": {kind: kindInt, bitWidth: 64, signed: true},
+	"L": {kind: kindInt, bitWidth: 64},
+}
+
+// parseType parses a format string of the Arrow C data interface. Dates,
+// timestamps and durations are in milliseconds. Lists are lists of UTF-8
+// strings, e.g., the labels of SET columns
+func parseType(format string) (fieldType, error) {
+	if t, ok := intTypes[format]; ok {
+		return t, nil
+	}
+
+	switch {
+	case format == "f":
+		return fieldType{kind: kindFloat, bitWidth: 32}, nil
+	case format == "g":
+		return fieldType{kind: kindFloat, bitWidth: 64}, nil
+	case format == "u":
+		return fieldType{kind: kindUtf8}, nil
+	case format == "z":
+		return fieldType{kind: kindBinary}, nil
+	case format == "tdm":
+		return fieldType{kind: kindDate, bitWidth: 64}, nil
+	case format == "tDm":
+		return fieldType{kind: kindDuration, bitWidth: 64}, nil
+	case strings.HasPrefix(format, "tsm:"):
+		return fieldType{kind: kindTimestamp, bitWidth: 64, timezone: format[len("tsm:"):]}, nil
+	case format == "+l":
+		return fieldType{kind: kindList}, nil
+	case strings.HasPrefix(format, "d:"):
+		// d:precision,scale[,bitWidth]
+		params := strings.Split(format[len("d:"):], ",")
+		t := fieldType{kind: kindDecimal, bitWidth: 128}
+		var err error
+		if len(params) < 2 || len(params) > 3 {
+			return t, fmt.Errorf("invalid Arrow decimal type '%s'", format)
+		}
+		if t.precision, err = strconv.Atoi(params[0]); err != nil {
+			return t, fmt.Errorf("invalid Arrow decimal type '%s'", format)
+		}
+		if t.scale, err = strconv.Atoi(params[1]); err != nil {
+			return t, fmt.Errorf("invalid Arrow decimal type '%s'", format)
+		}
+		if len(params) == 3 {
+			if t.bitWidth, err = strconv.Atoi(params[2]); err != nil || (t.bitWidth != 128 && t.bitWidth != 256) {
+				return t, fmt.Errorf("invalid Arrow decimal type '%s'", format)
+			}
+		}
+		return t, nil
+	default:
+		return fieldType{}, fmt.Errorf("unsupported Arrow type '%s'", format)
+	}
+}
+
+// column holds the buffers of a column. Fixed size values are stored in
+// values. Variable size values are stored in values and their end in
+// offsets. Lists store the end of every list in offsets and the strings
+// in the child column
+type column struct {
+	dataType fieldType
+	length   int
+	nulls    int
+	validity []byte
+	values   []byte
+	offsets  []byte
+	child    *column
+}
+
+func newColumn(t fieldType) *column {
+	col := &column{dataType: t}
+	switch t.kind {
+	case kindUtf8, kindBinary:
+		col.offsets = make([]byte, 4)
+	case kindList:
+		col.offsets = make([]byte, 4)
+		col.child = newColumn(fieldType{kind: kindUtf8})
+	}
+	return col
+}
+
+func (col *column) appendValidity(valid bool) {
+	if col.length%8 == 0 {
+		col.validity = append(col.validity, 0)
+	}
+	if valid {
+		col.validity[col.length/8] |= 1 << (col.length % 8)
+	} else {
+		col.nulls++
+	}
+	col.length++
+}
+
+func (col *column) appendOffset(offset int) {
+	col.offsets = appendUint32(col.offsets, uint32(offset))
+}
+
+func (col *column) appendNull() {
+	switch col.dataType.kind {
+	case kindUtf8, kindBinary:
+		col.appendOffset(len(col.values))
+	case kindList:
+		col.appendOffset(col.child.length)
+	default:
+		col.values = append(col.values, make([]byte, col.dataType.bitWidth/8)...)
+	}
+	col.appendValidity(false)
+}
+
+// appendValue adds a value as decoded from the MessagePack response of the
+// data access layer
+func (col *column) appendValue(value interface{}) error {
+	if value == nil {
+		col.appendNull()
+		return nil
+	}
+
+	t := col.dataType
+	switch t.kind {
+	case kindInt, kindDate, kindTimestamp, kindDuration:
+		bits, ok := integerBits(value)
+		if !ok {
+			return fmt.Errorf("expecting an integer. Got: %v", value)
+		}
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], bits)
+		col.values = append(col.values, b[:t.bitWidth/8]...)
+	case kindFloat:
+		var num float64
+		switch v := value.(type) {
+		case float32:
+			num = float64(v)
+		case float64:
+			num = v
+		default:
+			return fmt.Errorf("expecting a floating point number. Got: %v", value)
+		}
+		if t.bitWidth == 32 {
+			col.values = appendUint32(col.values, math.Float32bits(float32(num)))
+		} else {
+			col.values = appendUint64(col.values, math.Float64bits(num))
+		}
+	case kindUtf8, kindBinary:
+		switch v := value.(type) {
+		case string:
+			col.values = append(col.values, v...)
+		case []byte:
+			col.values = append(col.values, v...)
+		default:
+			return fmt.Errorf("expecting a string. Got: %v", value)
+		}
+		col.appendOffset(len(col.values))
+	case kindDecimal:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expecting a decimal string. Got: %v", value)
+		}
+		dec, err := decimalBytes(str, t.scale, t.bitWidth/8)
+		if err != nil {
+			return err
+		}
+		col.values = append(col.values, dec...)
+	case kindList:
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("expecting an array. Got: %v", value)
+		}
+		for _, item := range items {
+			if err := col.child.appendValue(item); err != nil {
+				return err
+			}
+		}
+		col.appendOffset(col.child.length)
+	}
+	col.appendValidity(true)
+	return nil
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// integerBits returns the bits of a signed or unsigned integer. MessagePack
+// decodes non negative numbers as unsigned
+func integerBits(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return uint64(v), true
+	case uint64:
+		return v, true
+	case int:
+		return uint64(v), true
+	case uint:
+		return uint64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// decimalBytes converts a decimal string, e.g., -12.50, to the little
+// endian two's complement of the unscaled value
+func decimalBytes(str string, scale int, byteWidth int) ([]byte, error) {
+	invalid := fmt.Errorf("invalid decimal '%s'", str)
+
+	digits := strings.TrimPrefix(str, "-")
+	fraction := ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		digits, fraction = digits[:i], digits[i+1:]
+	}
+	if len(fraction) > scale {
+		return nil, invalid
+	}
+	digits += fraction + strings.Repeat("0", scale-len(fraction))
+
+	num, ok := new(big.Int).SetString(digits, 10)
+	if !ok || strings.ContainsAny(digits, "+-") {
+		return nil, invalid
+	}
+	if strings.HasPrefix(str, "-") {
+		num.Neg(num)
+	}
+	if num.BitLen() >= 8*byteWidth {
+		return nil, invalid
+	}
+	if num.Sign() < 0 {
+		num.Add(num, new(big.Int).Lsh(big.NewInt(1), uint(8*byteWidth)))
+	}
+
+	dec := num.FillBytes(make([]byte, byteWidth))
+	for i, j := 0, len(dec)-1; i < j; i, j = i+1, j-1 {
+		dec[i], dec[j] = dec[j], dec[i]
+	}
+	return dec, nil
+}
+
+// RecordBuilder collects the rows of a record batch
+type RecordBuilder struct {
+	fields  []Field
+	columns []*column
+	rows    int
+}
+
+// NewRecordBuilder returns a builder for a record batch with the fields
+func NewRecordBuilder(fields []Field) (*RecordBuilder, error) {
+	builder := &RecordBuilder{fields: fields, columns: make([]*column, len(fields))}
+	for i, field := range fields {
+		t, err := parseType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("column '%s'. %v", field.Name, err)
+		}
+		builder.columns[i] = newColumn(t)
+	}
+	return builder, nil
+}
+
+// Append adds a row. The values are in the order of the fields and nil
+// values are null
+func (b *RecordBuilder) Append(values []interface{}) error {
+	if len(values) != len(b.columns) {
+		return fmt.Errorf("expecting %d values. Got: %d", len(b.columns), len(values))
+	}
+	for i, value := range values {
+		if err := b.columns[i].appendValue(value); err != nil {
+			return fmt.Errorf("column '%s'. %v", b.fields[i].Name, err)
+		}
+	}
+	b.rows++
+	return nil
+}
+
+// Rows returns the number of rows of the record batch
+func (b *RecordBuilder) Rows() int {
+	return b.rows
+}
+
+// Stream returns an IPC stream that contains the schema, the record batch
+// and the end of the stream. The metadata, if any, is added to the schema
+func (b *RecordBuilder) Stream(metadata map[string]string) []byte {
+	stream := writeMessage(nil, schemaMessage(b.fields, b.columns, metadata), nil)
+	body := recordBatchBody{}
+	for _, col := range b.columns {
+		body.addColumn(col)
+	}
+	stream = writeMessage(stream, recordBatchMessage(b.rows, &body), body.data)
+	// end of stream
+	return append(stream, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0)
+}
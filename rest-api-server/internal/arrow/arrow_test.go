@@ -0,0 +1,278 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package arrow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"testing"
+
+	official "github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/ipc"
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+func TestDecimalBytes(t *testing.T) {
+	tests := []struct {
+		str      string
+		scale    int
+		expected []byte
+	}{
+		{"12.5", 2, []byte{0xe2, 0x04, 0, 0}},
+		{"-12.50", 2, []byte{0x1e, 0xfb, 0xff, 0xff}},
+		{"0", 0, []byte{0, 0, 0, 0}},
+		{"-1", 0, []byte{0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, test := range tests {
+		dec, err := decimalBytes(test.str, test.scale, 4)
+		if err != nil {
+			t.Fatalf("Unable to convert %s. Error: %v", test.str, err)
+		}
+		if !bytes.Equal(dec, test.expected) {
+			t.Fatalf("Wrong bytes for %s. Expecting: %v, Got: %v", test.str, test.expected, dec)
+		}
+	}
+
+	for _, str := range []string{"1.234", "abc", "1-2", "+-1", "2147483648"} {
+		if _, err := decimalBytes(str, 2, 4); err == nil {
+			t.Fatalf("Converting %s should have failed", str)
+		}
+	}
+}
+
+func TestRecordBuilderErrors(t *testing.T) {
+	for _, format := range []string{"x", "d:10", "d:10,2,64", "tss:"} {
+		if _, err := NewRecordBuilder([]Field{{Name: "col", Type: format}}); err == nil {
+			t.Fatalf("Type %s should not be supported", format)
+		}
+	}
+
+	builder, err := NewRecordBuilder([]Field{{Name: "id", Type: "i"}, {Name: "name", Type: "u"}})
+	if err != nil {
+		t.Fatalf("Unable to create the builder. Error: %v", err)
+	}
+	for _, values := range [][]interface{}{{int64(1)}, {"1", "a"}, {int64(1), int64(2)}} {
+		if err := builder.Append(values); err == nil {
+			t.Fatalf("Appending %v should have failed", values)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	builder, err := NewRecordBuilder([]Field{{Name: "id", Type: "i"}, {Name: "name", Type: "u"}})
+	if err != nil {
+		t.Fatalf("Unable to create the builder. Error: %v", err)
+	}
+	for _, row := range [][]interface{}{{int64(1), "alice"}, {uint64(2), nil}, {int64(-3), "bob"}} {
+		if err := builder.Append(row); err != nil {
+			t.Fatalf("Unable to append %v. Error: %v", row, err)
+		}
+	}
+	stream := builder.Stream(map[string]string{"operationId": "1"})
+
+	// the stream has the schema, the record batch and the end of stream
+	var headerTypes []byte
+	var body []byte
+	for {
+		if len(stream) < 8 || binary.LittleEndian.Uint32(stream) != 0xffffffff {
+			t.Fatalf("Expecting a continuation marker")
+		}
+		size := int(binary.LittleEndian.Uint32(stream[4:]))
+		if size == 0 {
+			if len(stream) != 8 {
+				t.Fatalf("Expecting the end of the stream. Got: %v", stream)
+			}
+			break
+		}
+		if size%8 != 0 {
+			t.Fatalf("The metadata is not aligned to 8 bytes. Size: %d", size)
+		}
+
+		msg := &flatbuffers.Table{Bytes: stream[8 : 8+size]}
+		msg.Pos = flatbuffers.GetUOffsetT(msg.Bytes)
+		headerTypes = append(headerTypes, msg.GetByteSlot(6, 0))
+		bodyLength := int(msg.GetInt64Slot(10, 0))
+		body = stream[8+size : 8+size+bodyLength]
+		stream = stream[8+size+bodyLength:]
+	}
+	if !bytes.Equal(headerTypes, []byte{headerSchema, headerRecordBatch}) {
+		t.Fatalf("Wrong messages. Got: %v", headerTypes)
+	}
+
+	// id: validity, values. name: validity, offsets, values
+	expected := [][]byte{
+		{0x07}, {1, 0, 0, 0, 2, 0, 0, 0, 0xfd, 0xff, 0xff, 0xff},
+		{0x05}, {0, 0, 0, 0, 5, 0, 0, 0, 5, 0, 0, 0, 8, 0, 0, 0}, []byte("alicebob"),
+	}
+	offset := 0
+	for i, buffer := range expected {
+		if !bytes.Equal(body[offset:offset+len(buffer)], buffer) {
+			t.Fatalf("Wrong buffer %d. Expecting: %v, Got: %v", i, buffer, body[offset:offset+len(buffer)])
+		}
+		offset += (len(buffer) + 7) &^ 7
+	}
+	if offset != len(body) {
+		t.Fatalf("Wrong body length. Expecting: %d, Got: %d", offset, len(body))
+	}
+}
+
+// TestOfficialReader reads a stream with all the supported types, except
+// 256 bit decimals, with the IPC reader of the Arrow Go library
+func TestOfficialReader(t *testing.T) {
+	fields := []Field{
+		{Name: "i8", Type: "c"}, {Name: "u8", Type: "C"}, {Name: "i16", Type: "s"}, {Name: "u16", Type: "S"},
+		{Name: "i32", Type: "i"}, {Name: "u32", Type: "I"}, {Name: "i64", Type: "l"}, {Name: "u64", Type: "L"},
+		{Name: "f32", Type: "f"}, {Name: "f64", Type: "g"}, {Name: "str", Type: "u"}, {Name: "bin", Type: "z"},
+		{Name: "date", Type: "tdm"}, {Name: "ts", Type: "tsm:UTC"}, {Name: "time", Type: "tDm"},
+		{Name: "dec", Type: "d:10,2"}, {Name: "set", Type: "+l"},
+	}
+	expectedTypes := []string{"int8", "uint8", "int16", "uint16", "int32", "uint32", "int64", "uint64",
+		"float32", "float64", "utf8", "binary", "date64", "timestamp[ms, tz=UTC]", "duration[ms]",
+		"decimal(10, 2)", "list<item: utf8>"}
+
+	builder, err := NewRecordBuilder(fields)
+	if err != nil {
+		t.Fatalf("Unable to create the builder. Error: %v", err)
+	}
+	rows := [][]interface{}{
+		{int64(-8), uint64(8), int64(-16), uint64(16), int64(-32), uint64(32), int64(-64), uint64(1 << 63),
+			float32(1.5), 2.5, "green", []byte{0, 1, 2}, int64(1646092800000), int64(1646136000123),
+			int64(-3723000), "-12.50", []interface{}{"a", "c"}},
+		{nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil},
+		{int64(127), uint64(255), int64(32767), uint64(65535), int64(2147483647), uint64(4294967295),
+			int64(-1), uint64(0), float32(-0.25), -1e300, "", []byte{}, int64(0), int64(0), int64(0),
+			"99999999.99", []interface{}{}},
+	}
+	for _, row := range rows {
+		if err := builder.Append(row); err != nil {
+			t.Fatalf("Unable to append %v. Error: %v", row, err)
+		}
+	}
+
+	reader, err := ipc.NewReader(bytes.NewReader(builder.Stream(map[string]string{"operationId": "op1"})))
+	if err != nil {
+		t.Fatalf("Unable to read the schema. Error: %v", err)
+	}
+	defer reader.Release()
+
+	schema := reader.Schema()
+	for i, field := range schema.Fields() {
+		if field.Name != fields[i].Name || fmt.Sprint(field.Type) != expectedTypes[i] || !field.Nullable {
+			t.Fatalf("Wrong field %d. Expecting: %s %s, Got: %v", i, fields[i].Name, expectedTypes[i], field)
+		}
+	}
+	if len(schema.Fields()) != len(fields) {
+		t.Fatalf("Wrong number of fields. Got: %v", schema)
+	}
+	if idx := schema.Metadata().FindKey("operationId"); idx < 0 || schema.Metadata().Values()[idx] != "op1" {
+		t.Fatalf("Wrong metadata. Got: %v", schema.Metadata())
+	}
+
+	if !reader.Next() {
+		t.Fatalf("Expecting a record batch. Error: %v", reader.Err())
+	}
+	record := reader.Record()
+	if record.NumRows() != int64(len(rows)) {
+		t.Fatalf("Expecting %d rows. Got: %d", len(rows), record.NumRows())
+	}
+	for i := range fields {
+		col := record.Column(i)
+		for row := range rows {
+			expected := fmt.Sprint(rows[row][i])
+			if got := officialValue(t, col, row); got != expected {
+				t.Fatalf("Wrong value of %s in row %d. Expecting: %s, Got: %s", fields[i].Name, row, expected, got)
+			}
+		}
+	}
+
+	if reader.Next() {
+		t.Fatalf("Expecting the end of the stream")
+	}
+	if reader.Err() != nil {
+		t.Fatalf("Unable to read the stream. Error: %v", reader.Err())
+	}
+}
+
+// officialValue formats a value of an array of the Arrow Go library like
+// fmt.Sprint formats the value that was appended
+func officialValue(t *testing.T, col array.Interface, row int) string {
+	if col.IsNull(row) {
+		return fmt.Sprint(nil)
+	}
+
+	switch arr := col.(type) {
+	case *array.Int8:
+		return fmt.Sprint(arr.Value(row))
+	case *array.Uint8:
+		return fmt.Sprint(arr.Value(row))
+	case *array.Int16:
+		return fmt.Sprint(arr.Value(row))
+	case *array.Uint16:
+		return fmt.Sprint(arr.Value(row))
+	case *array.Int32:
+		return fmt.Sprint(arr.Value(row))
+	case *array.Uint32:
+		return fmt.Sprint(arr.Value(row))
+	case *array.Int64:
+		return fmt.Sprint(arr.Value(row))
+	case *array.Uint64:
+		return fmt.Sprint(arr.Value(row))
+	case *array.Float32:
+		return fmt.Sprint(arr.Value(row))
+	case *array.Float64:
+		return fmt.Sprint(arr.Value(row))
+	case *array.String:
+		return arr.Value(row)
+	case *array.Binary:
+		return fmt.Sprint(arr.Value(row))
+	case *array.Date64:
+		return fmt.Sprint(int64(arr.Value(row)))
+	case *array.Timestamp:
+		return fmt.Sprint(int64(arr.Value(row)))
+	case *array.Duration:
+		return fmt.Sprint(int64(arr.Value(row)))
+	case *array.Decimal128:
+		return decimalString(arr.Value(row), arr.DataType().(*official.Decimal128Type).Scale)
+	case *array.List:
+		offsets := arr.Offsets()
+		labels := arr.ListValues().(*array.String)
+		var items []interface{}
+		for i := offsets[row]; i < offsets[row+1]; i++ {
+			items = append(items, labels.Value(int(i)))
+		}
+		return fmt.Sprint(items)
+	default:
+		t.Fatalf("Unexpected array type %T", col)
+		return ""
+	}
+}
+
+func decimalString(num decimal128.Num, scale int32) string {
+	unscaled := new(big.Int).Lsh(big.NewInt(num.HighBits()), 64)
+	unscaled.Or(unscaled, new(big.Int).SetUint64(num.LowBits()))
+	digits := fmt.Sprintf("%0*s", scale+1, new(big.Int).Abs(unscaled).String())
+	str := digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+	if unscaled.Sign() < 0 {
+		return "-" + str
+	}
+	return str
+}
@@ -0,0 +1,233 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package arrow
+
+import (
+	"sort"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// The messages of the stream are flatbuffers, see Message.fbs and
+// Schema.fbs in the Arrow repository. The tables are built by hand, the
+// numbers below are the slots and enum values of these files
+//
+//   <0xFFFFFFFF><metadata size><Message flatbuffer, padded to 8 bytes><body>
+
+const (
+	metadataV5 = 4
+
+	headerSchema      = 1
+	headerRecordBatch = 3
+
+	typeInt           = 2
+	typeFloatingPoint = 3
+	typeBinary        = 4
+	typeUtf8          = 5
+	typeDecimal       = 7
+	typeDate          = 8
+	typeTimestamp     = 10
+	typeList          = 12
+	typeDuration      = 18
+
+	precisionSingle = 1
+	precisionDouble = 2
+	unitMillisecond = 1
+)
+
+func writeMessage(stream []byte, metadata []byte, body []byte) []byte {
+	padded := (len(metadata) + 7) &^ 7
+	stream = appendUint32(stream, 0xffffffff)
+	stream = appendUint32(stream, uint32(padded))
+	stream = append(stream, metadata...)
+	stream = append(stream, make([]byte, padded-len(metadata))...)
+	return append(stream, body...)
+}
+
+func message(fb *flatbuffers.Builder, headerType byte, header flatbuffers.UOffsetT, bodyLength int) []byte {
+	fb.StartObject(5)
+	fb.PrependInt64Slot(3, int64(bodyLength), 0)
+	fb.PrependUOffsetTSlot(2, header, 0)
+	fb.PrependByteSlot(1, headerType, 0)
+	fb.PrependInt16Slot(0, metadataV5, 0)
+	fb.Finish(fb.EndObject())
+	return fb.FinishedBytes()
+}
+
+func offsetVector(fb *flatbuffers.Builder, offsets []flatbuffers.UOffsetT) flatbuffers.UOffsetT {
+	fb.StartVector(4, len(offsets), 4)
+	for i := len(offsets) - 1; i >= 0; i-- {
+		fb.PrependUOffsetT(offsets[i])
+	}
+	return fb.EndVector(len(offsets))
+}
+
+func schemaMessage(fields []Field, columns []*column, metadata map[string]string) []byte {
+	fb := flatbuffers.NewBuilder(1024)
+
+	offsets := make([]flatbuffers.UOffsetT, len(fields))
+	for i, field := range fields {
+		offsets[i] = fieldTable(fb, field.Name, columns[i].dataType)
+	}
+	fieldsVector := offsetVector(fb, offsets)
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	keyValues := make([]flatbuffers.UOffsetT, len(keys))
+	for i, key := range keys {
+		k := fb.CreateString(key)
+		v := fb.CreateString(metadata[key])
+		fb.StartObject(2)
+		fb.PrependUOffsetTSlot(0, k, 0)
+		fb.PrependUOffsetTSlot(1, v, 0)
+		keyValues[i] = fb.EndObject()
+	}
+	metadataVector := offsetVector(fb, keyValues)
+
+	fb.StartObject(4)
+	fb.PrependUOffsetTSlot(1, fieldsVector, 0)
+	fb.PrependUOffsetTSlot(2, metadataVector, 0)
+	return message(fb, headerSchema, fb.EndObject(), 0)
+}
+
+// fieldTable builds a Field table. Readers expect the children vector
+// even if the type has no children
+func fieldTable(fb *flatbuffers.Builder, name string, t fieldType) flatbuffers.UOffsetT {
+	nameString := fb.CreateString(name)
+	var children []flatbuffers.UOffsetT
+	if t.kind == kindList {
+		children = append(children, fieldTable(fb, "item", fieldType{kind: kindUtf8}))
+	}
+	childrenVector := offsetVector(fb, children)
+	typeID, typeTable := typeTable(fb, t)
+
+	fb.StartObject(7)
+	fb.PrependUOffsetTSlot(0, nameString, 0)
+	fb.PrependBoolSlot(1, true, false)
+	fb.PrependByteSlot(2, typeID, 0)
+	fb.PrependUOffsetTSlot(3, typeTable, 0)
+	fb.PrependUOffsetTSlot(5, childrenVector, 0)
+	return fb.EndObject()
+}
+
+func typeTable(fb *flatbuffers.Builder, t fieldType) (byte, flatbuffers.UOffsetT) {
+	switch t.kind {
+	case kindInt:
+		fb.StartObject(2)
+		fb.PrependInt32Slot(0, int32(t.bitWidth), 0)
+		fb.PrependBoolSlot(1, t.signed, false)
+		return typeInt, fb.EndObject()
+	case kindFloat:
+		precision := int16(precisionDouble)
+		if t.bitWidth == 32 {
+			precision = precisionSingle
+		}
+		fb.StartObject(1)
+		fb.PrependInt16Slot(0, precision, 0)
+		return typeFloatingPoint, fb.EndObject()
+	case kindBinary:
+		fb.StartObject(0)
+		return typeBinary, fb.EndObject()
+	case kindDecimal:
+		fb.StartObject(3)
+		fb.PrependInt32Slot(0, int32(t.precision), 0)
+		fb.PrependInt32Slot(1, int32(t.scale), 0)
+		fb.PrependInt32Slot(2, int32(t.bitWidth), 128)
+		return typeDecimal, fb.EndObject()
+	case kindDate:
+		fb.StartObject(1)
+		fb.PrependInt16Slot(0, unitMillisecond, 0)
+		return typeDate, fb.EndObject()
+	case kindTimestamp:
+		var timezone flatbuffers.UOffsetT
+		if t.timezone != "" {
+			timezone = fb.CreateString(t.timezone)
+		}
+		fb.StartObject(2)
+		fb.PrependInt16Slot(0, unitMillisecond, 0)
+		fb.PrependUOffsetTSlot(1, timezone, 0)
+		return typeTimestamp, fb.EndObject()
+	case kindDuration:
+		fb.StartObject(1)
+		fb.PrependInt16Slot(0, unitMillisecond, 0)
+		return typeDuration, fb.EndObject()
+	case kindList:
+		fb.StartObject(0)
+		return typeList, fb.EndObject()
+	default:
+		fb.StartObject(0)
+		return typeUtf8, fb.EndObject()
+	}
+}
+
+// recordBatchBody holds the buffers of the columns in the order of the
+// fields, children after their parents
+type recordBatchBody struct {
+	nodes   [][2]int // length and null count of every column
+	buffers [][2]int // offset and length of every buffer in the body
+	data    []byte
+}
+
+func (body *recordBatchBody) addBuffer(buffer []byte) {
+	body.buffers = append(body.buffers, [2]int{len(body.data), len(buffer)})
+	body.data = append(body.data, buffer...)
+	// buffers are aligned to 8 bytes
+	body.data = append(body.data, make([]byte, (8-len(buffer)%8)%8)...)
+}
+
+func (body *recordBatchBody) addColumn(col *column) {
+	body.nodes = append(body.nodes, [2]int{col.length, col.nulls})
+	body.addBuffer(col.validity)
+	switch col.dataType.kind {
+	case kindUtf8, kindBinary:
+		body.addBuffer(col.offsets)
+		body.addBuffer(col.values)
+	case kindList:
+		body.addBuffer(col.offsets)
+		body.addColumn(col.child)
+	default:
+		body.addBuffer(col.values)
+	}
+}
+
+// structVector builds a vector of structs that have two 64 bit fields,
+// i.e., FieldNode and Buffer
+func structVector(fb *flatbuffers.Builder, structs [][2]int) flatbuffers.UOffsetT {
+	fb.StartVector(16, len(structs), 8)
+	for i := len(structs) - 1; i >= 0; i-- {
+		fb.Prep(8, 16)
+		fb.PrependInt64(int64(structs[i][1]))
+		fb.PrependInt64(int64(structs[i][0]))
+	}
+	return fb.EndVector(len(structs))
+}
+
+func recordBatchMessage(rows int, body *recordBatchBody) []byte {
+	fb := flatbuffers.NewBuilder(1024)
+	nodes := structVector(fb, body.nodes)
+	buffers := structVector(fb, body.buffers)
+
+	fb.StartObject(5)
+	fb.PrependInt64Slot(0, int64(rows), 0)
+	fb.PrependUOffsetTSlot(1, nodes, 0)
+	fb.PrependUOffsetTSlot(2, buffers, 0)
+	return message(fb, headerRecordBatch, fb.EndObject(), len(body.data))
+}
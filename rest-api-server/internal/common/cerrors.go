@@ -26,6 +26,10 @@ func ERROR_008() string {
 	return C.ERROR_008
 }
 
+func ERROR_009() string {
+	return C.ERROR_009
+}
+
 func ERROR_011() string {
 	return C.ERROR_011
 }
@@ -58,6 +62,10 @@ func ERROR_024() string {
 	return C.ERROR_024
 }
 
+func ERROR_025() string {
+	return C.ERROR_025
+}
+
 func ERROR_026() string {
 	return C.ERROR_026
 }
@@ -65,3 +73,87 @@ func ERROR_026() string {
 func ERROR_027() string {
 	return C.ERROR_027
 }
+
+func ERROR_029() string {
+	return C.ERROR_029
+}
+
+func ERROR_030() string {
+	return C.ERROR_030
+}
+
+func ERROR_033() string {
+	return C.ERROR_033
+}
+
+func ERROR_034() string {
+	return C.ERROR_034
+}
+
+func ERROR_035() string {
+	return C.ERROR_035
+}
+
+func ERROR_036() string {
+	return C.ERROR_036
+}
+
+func ERROR_037() string {
+	return C.ERROR_037
+}
+
+func ERROR_038() string {
+	return C.ERROR_038
+}
+
+func ERROR_039() string {
+	return C.ERROR_039
+}
+
+func ERROR_040() string {
+	return C.ERROR_040
+}
+
+func ERROR_041() string {
+	return C.ERROR_041
+}
+
+func ERROR_042() string {
+	return C.ERROR_042
+}
+
+func ERROR_043() string {
+	return C.ERROR_043
+}
+
+func ERROR_044() string {
+	return C.ERROR_044
+}
+
+func ERROR_045() string {
+	return C.ERROR_045
+}
+
+func ERROR_046() string {
+	return C.ERROR_046
+}
+
+func ERROR_047() string {
+	return C.ERROR_047
+}
+
+func ERROR_048() string {
+	return C.ERROR_048
+}
+
+func ERROR_049() string {
+	return C.ERROR_049
+}
+
+func ERROR_050() string {
+	return C.ERROR_050
+}
+
+func ERROR_051() string {
+	return C.ERROR_051
+}
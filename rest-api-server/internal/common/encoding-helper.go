@@ -19,6 +19,7 @@
 package common
 
 import (
+	"encoding/json"
 	"fmt"
 	"unsafe"
 
@@ -53,14 +54,34 @@ func CopyGoStrToCStr(src []byte, dst *dal.NativeBuffer, offset uint32) (uint32,
 // we adjust the size accordingly.
 
 func CopyGoStrToNDBStr(src []byte, dst *dal.NativeBuffer, offset uint32) (uint32, error) {
-	dstBuf := unsafe.Slice((*byte)(dst.Buffer), dst.Size)
-
 	// remove the quotation marks from string
 	str := string(src)
 	if str[0:1] == "\"" && str[len(str)-1:] == "\"" {
 		src = []byte(str[1 : len(str)-1])
 	}
 
+	return copyNDBStr(src, dst, offset)
+}
+
+// copy a JSON value to the buffer at the specified location.
+// JSON strings are unquoted and unescaped before copying. All
+// other JSON values, e.g. numbers, are copied as they are. The
+// native layer checks that the values match the column types
+func CopyJSONValueToNDBStr(src json.RawMessage, dst *dal.NativeBuffer, offset uint32) (uint32, error) {
+	if len(src) > 0 && src[0] == '"' {
+		var str string
+		if err := json.Unmarshal(src, &str); err != nil {
+			return 0, err
+		}
+		src = []byte(str)
+	}
+
+	return copyNDBStr(src, dst, offset)
+}
+
+func copyNDBStr(src []byte, dst *dal.NativeBuffer, offset uint32) (uint32, error) {
+	dstBuf := unsafe.Slice((*byte)(dst.Buffer), dst.Size)
+
 	if offset+uint32(len(src))+1+2 > dst.Size {
 		return 0, fmt.Errorf("Trying to write more data than the buffer capacity")
 	}
@@ -21,9 +21,15 @@ package common
 import "C"
 import (
 	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
 	"unsafe"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/log"
 )
 
 type ErrorResponse struct {
@@ -34,7 +40,131 @@ func ProcessResponse(buffer unsafe.Pointer) string {
 	return C.GoString((*C.char)(buffer))
 }
 
+// processBinaryResponse returns a MessagePack or CBOR response. Binary
+// responses are not NUL terminated, they start with their length
+func processBinaryResponse(buffer unsafe.Pointer) string {
+	length := *(*uint32)(buffer)
+	return C.GoStringN((*C.char)(unsafe.Add(buffer, 4)), C.int(length))
+}
+
+// ProcessFormattedResponse returns a response in the format of the request
+func ProcessFormattedResponse(buffer unsafe.Pointer, format string) string {
+	if format == "" {
+		return ProcessResponse(buffer)
+	}
+	return processBinaryResponse(buffer)
+}
+
+// msgpackHandle decodes MessagePack strings as strings and binary values
+// as []byte
+var msgpackHandle = func() *codec.MsgpackHandle {
+	handle := &codec.MsgpackHandle{WriteExt: true}
+	handle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return handle
+}()
+
+// DecodeMsgPackResponse decodes a MessagePack response, e.g., the response
+// of an Arrow request that is converted to Arrow by the REST server
+func DecodeMsgPackResponse(resp string, v interface{}) error {
+	return codec.NewDecoderBytes([]byte(resp), msgpackHandle).Decode(v)
+}
+
 func SetResponseError(c *gin.Context, code int, resp ErrorResponse) {
 	b, _ := json.Marshal(resp)
 	c.String(code, string(b))
 }
+
+// acceptedTypes returns the media types of the Accept header without
+// their parameters
+func acceptedTypes(c *gin.Context) []string {
+	var types []string
+	for _, mediaType := range strings.Split(c.GetHeader("Accept"), ",") {
+		if i := strings.Index(mediaType, ";"); i >= 0 {
+			mediaType = mediaType[:i]
+		}
+		types = append(types, strings.TrimSpace(mediaType))
+	}
+	return types
+}
+
+// AcceptsNDJSON returns true if the client asked for a newline delimited JSON response
+func AcceptsNDJSON(c *gin.Context) bool {
+	for _, mediaType := range acceptedTypes(c) {
+		if mediaType == ds.NDJSON_MIME {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsArrow returns true if the client asked for an Arrow IPC stream
+func AcceptsArrow(c *gin.Context) bool {
+	for _, mediaType := range acceptedTypes(c) {
+		if mediaType == ds.ARROW_MIME {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsCSV returns true if the client asked for comma separated values
+func AcceptsCSV(c *gin.Context) bool {
+	for _, mediaType := range acceptedTypes(c) {
+		if mediaType == ds.CSV_MIME {
+			return true
+		}
+	}
+	return false
+}
+
+// ResponseFormat returns the binary format that the client asked for,
+// ds.MSGPACK_MIME or ds.CBOR_MIME. It returns an empty string for JSON.
+// The first binary format in the Accept header is used
+func ResponseFormat(c *gin.Context) string {
+	for _, mediaType := range acceptedTypes(c) {
+		if mediaType == ds.MSGPACK_MIME || mediaType == ds.CBOR_MIME {
+			return mediaType
+		}
+	}
+	return ""
+}
+
+// SetFormattedResponse sends a response in the format of the request.
+// Binary responses set their content type
+func SetFormattedResponse(c *gin.Context, code int, format string, body string) {
+	if format != "" {
+		c.Header("Content-Type", format)
+	}
+	c.Writer.WriteHeader(code)
+	c.Writer.Write(([]byte)(body))
+}
+
+// StartStreamedResponse sends the headers of a streamed response, i.e.,
+// ds.NDJSON_MIME or ds.CSV_MIME. The response is sent using chunked
+// transfer encoding as its length is unknown
+func StartStreamedResponse(c *gin.Context, format string) {
+	c.Header("Content-Type", format)
+	c.Writer.WriteHeader(http.StatusOK)
+}
+
+// WriteStreamed sends the data to the client without waiting for the rest of the response
+func WriteStreamed(c *gin.Context, data string) {
+	c.Writer.Write(([]byte)(data))
+	c.Writer.Flush()
+}
+
+// SetNDJSONError ends a streamed response with an error. The status code
+// has already been sent, so the error is sent as the last line
+func SetNDJSONError(c *gin.Context, resp ErrorResponse) {
+	b, _ := json.Marshal(resp)
+	WriteStreamed(c, string(b)+"\n")
+}
+
+// AbortStreamedResponse ends a streamed response that can not contain the
+// error, e.g., CSV. The connection is closed without ending the chunked
+// transfer encoding, so that the client does not mistake the partial
+// response for a complete one
+func AbortStreamedResponse(c *gin.Context, resp ErrorResponse) {
+	log.Errorf("Aborting the streamed response of %s. Error: %s", c.Request.URL.Path, resp.Error)
+	panic(http.ErrAbortHandler)
+}
@@ -229,6 +229,8 @@ func init() {
 			"INSERT INTO  float_table1 set id0=2", // NULL values for non primary columns
 
 			"CREATE TABLE float_table2(id0 FLOAT, col0 FLOAT, col1 FLOAT UNSIGNED, PRIMARY KEY(id0))",
+			"INSERT INTO  float_table2 VALUES(1.5,-1.25,1.25)",
+			"INSERT INTO  float_table2 VALUES(-2.5,0,0)",
 		},
 
 		{ // clean up commands
@@ -250,6 +252,8 @@ func init() {
 			"INSERT INTO  double_table1 set id0=2", // NULL values for non primary columns
 
 			"CREATE TABLE double_table2(id0 DOUBLE, col0 DOUBLE, col1 DOUBLE UNSIGNED, PRIMARY KEY(id0))",
+			"INSERT INTO  double_table2 VALUES(1.5,-1.25,1.25)",
+			"INSERT INTO  double_table2 VALUES(-2.5,0,0)",
 		},
 
 		{ // clean up commands
@@ -445,6 +449,150 @@ func init() {
 			"insert into bit_table values(1,  b'1',  b'111', b'1111111111111111111111111', b'111111111111111111111111111111111111111', b'1111111111111111111111111111111111111111111111111111111111111111')",
 			"insert into bit_table values(2,  b'0',  b'000', b'0000000000000000000000000', b'000000000000000000000000000000000000000', b'0000000000000000000000000000000000000000000000000000000000000000')",
 			"insert into bit_table set id0=\"3\"",
+
+			"CREATE TABLE `bit_table2` ( `id0` bit(12), `col0` int DEFAULT NULL, PRIMARY KEY (`id0`))",
+			"insert into bit_table2 values(b'101010101010', 1)",
+			"insert into bit_table2 values(b'1', 2)",
+		},
+
+		{ // clean up commands
+			"DROP DATABASE " + db,
+		},
+	}
+
+	db = "DB025"
+	databases[db] = [][]string{
+		{
+			// setup commands
+			"DROP DATABASE IF EXISTS " + db,
+			"CREATE DATABASE " + db,
+			"USE " + db,
+
+			"CREATE TABLE table_1(id0 INT, col_0 VARCHAR(100), col_1 INT NOT NULL DEFAULT 0, col_2 DOUBLE, col_3 CHAR(10), col_4 TEXT, PRIMARY KEY(id0))",
+			"INSERT INTO table_1 VALUES(1, 'col_0_data', 1, 1.5, 'char_data', 'text_data')",
+
+			// CHAR primary key. MySQL pads the keys with spaces
+			"CREATE TABLE table_2(id0 CHAR(10), col_0 VARCHAR(100), PRIMARY KEY(id0))",
+			"INSERT INTO table_2 VALUES('mysql', 'mysql_data')",
+		},
+
+		{ // clean up commands
+			"DROP DATABASE " + db,
+		},
+	}
+
+	db = "DB026"
+	databases[db] = [][]string{
+		{
+			// setup commands
+			"DROP DATABASE IF EXISTS " + db,
+			"CREATE DATABASE " + db,
+			"USE " + db,
+
+			"CREATE TABLE table_1(id0 INT, col_0 VARCHAR(100), col_1 INT, col_2 DOUBLE, col_3 CHAR(10), PRIMARY KEY(id0))",
+			"INSERT INTO table_1 VALUES(1, 'red', 10, 1.5, 'a')",
+			"INSERT INTO table_1 VALUES(2, 'green', 20, 2.5, 'b')",
+			"INSERT INTO table_1 VALUES(3, 'blue', 30, 3.5, 'c')",
+			"INSERT INTO table_1 VALUES(4, 'red', 40, 4.5, 'd')",
+			"INSERT INTO table_1 VALUES(5, NULL, NULL, NULL, NULL)",
+			"CREATE TABLE table_2(id0 INT, col_0 TEXT, PRIMARY KEY(id0))",
+			"INSERT INTO table_2 VALUES(1, 'text_data')",
+
+			// time series table
+			"CREATE TABLE readings(id0 INT, device_id VARCHAR(32), ts INT, reading DOUBLE, PRIMARY KEY(id0), INDEX device_ts(device_id, ts))",
+			"INSERT INTO readings VALUES(1, 'dev_1', 100, 1.0)",
+			"INSERT INTO readings VALUES(2, 'dev_1', 200, 2.0)",
+			"INSERT INTO readings VALUES(3, 'dev_1', 300, 3.0)",
+			"INSERT INTO readings VALUES(4, 'dev_1', 400, 4.0)",
+			"INSERT INTO readings VALUES(5, 'dev_2', 100, 5.0)",
+			"INSERT INTO readings VALUES(6, 'dev_2', 200, 6.0)",
+
+			"CREATE TABLE users(id0 INT, email VARCHAR(64), name VARCHAR(64), PRIMARY KEY(id0), UNIQUE INDEX email_idx(email))",
+			"INSERT INTO users VALUES(1, 'alice@example.com', 'alice')",
+			"INSERT INTO users VALUES(2, 'bob@example.com', 'bob')",
+		},
+
+		{ // clean up commands
+			"DROP DATABASE " + db,
+		},
+	}
+
+	db = "DB027"
+	databases[db] = [][]string{
+		{
+			// setup commands
+			"DROP DATABASE IF EXISTS " + db,
+			"CREATE DATABASE " + db,
+			"USE " + db,
+
+			// model artifacts. The data is 16 * 1000 bytes
+			"CREATE TABLE artifacts(id0 INT, name VARCHAR(32), data LONGBLOB, notes TEXT, version INT, PRIMARY KEY(id0, name))",
+			"INSERT INTO artifacts VALUES(1, 'model', UNHEX(REPEAT('000102030405060708090A0B0C0D0E0F', 1000)), 'trained on DB026', 1)",
+			"INSERT INTO artifacts VALUES(2, 'empty', '', NULL, 1)",
+		},
+
+		{ // clean up commands
+			"DROP DATABASE " + db,
+		},
+	}
+
+	db = "DB028"
+	databases[db] = [][]string{
+		{
+			// setup commands
+			"DROP DATABASE IF EXISTS " + db,
+			"CREATE DATABASE " + db,
+			"USE " + db,
+
+			// MySQL only types
+			"CREATE TABLE types(id0 INT, col0 ENUM('red','green','blue'), col1 SET('a','b','c'), col2 JSON, PRIMARY KEY(id0))",
+			`INSERT INTO types VALUES(1, 'green', 'a,c', '{"k": [1, 2], "s": "x\\"y"}')`,
+			"INSERT INTO types VALUES(2, 'blue', '', '[true, null, 1.5]')",
+			"INSERT INTO types VALUES(3, NULL, NULL, NULL)",
+		},
+
+		{ // clean up commands
+			"DROP DATABASE " + db,
+		},
+	}
+
+	db = "DB029"
+	databases[db] = [][]string{
+		{
+			// setup commands
+			"DROP DATABASE IF EXISTS " + db,
+			"CREATE DATABASE " + db,
+			"USE " + db,
+
+			// legacy charsets
+			"CREATE TABLE latin1_table(id0 VARCHAR(16), col0 CHAR(8), col1 TEXT, PRIMARY KEY(id0)) CHARSET=latin1",
+			"INSERT INTO latin1_table VALUES('café', 'naïve', 'Grüße')",
+			"CREATE TABLE utf16_table(id0 CHAR(8), col0 VARCHAR(16), PRIMARY KEY(id0)) CHARSET=utf16",
+			"INSERT INTO utf16_table VALUES('é', 'ümlaut')",
+		},
+
+		{ // clean up commands
+			"DROP DATABASE " + db,
+		},
+	}
+
+	db = "DB030"
+	databases[db] = [][]string{
+		{
+			// setup commands
+			"DROP DATABASE IF EXISTS " + db,
+			"CREATE DATABASE " + db,
+			"USE " + db,
+
+			// key/value tables of the RESP listener
+			"CREATE TABLE kv(`key` VARCHAR(64), value VARBINARY(1024), PRIMARY KEY(`key`))",
+			"INSERT INTO kv VALUES('k1', 'v1')",
+			"INSERT INTO kv VALUES('k2', 0x00FF)",
+			"INSERT INTO kv VALUES('k3', NULL)",
+			"CREATE TABLE kv_text(`key` VARCHAR(64), value VARCHAR(64), PRIMARY KEY(`key`))",
+			"INSERT INTO kv_text VALUES('k1', 'v1')",
+			"CREATE TABLE kv_blob(`key` VARCHAR(64), value BLOB, PRIMARY KEY(`key`))",
+			"INSERT INTO kv_blob VALUES('k1', 'v1')",
 		},
 
 		{ // clean up commands
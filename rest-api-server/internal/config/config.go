@@ -51,13 +51,48 @@ type RestServer struct {
 	BufferSize      int
 	PreAllocBuffers uint32
 	GOMAXPROCS      int
+
+	// Explicit transactions
+	MaxOpenTransactions      uint32
+	TransactionIdleTimeoutMS uint32
+
+	// Key used to sign the scan page tokens. A random key is used if it is
+	// not set, in which case the tokens are only valid for this server
+	ScanPageTokenKey string
+
+	// Max size of the BLOB/TEXT columns that can be read. Reading a
+	// larger column fails the operation
+	MaxBlobReadSize uint32
+
+	// Remove the trailing spaces of CHAR columns and the trailing NUL bytes
+	// of BINARY columns from the responses. Read columns can override it
+	TrimCharPadding   bool
+	TrimBinaryPadding bool
+
+	// The gRPC API is served on its own port. Port 0 disables it
+	GRPCIP   string
+	GRPCPort uint16
+
+	// Redis protocol (RESP) listener for a key/value table. RESPTable is
+	// database.table. Port 0 disables it
+	RESPIP          string
+	RESPPort        uint16
+	RESPTable       string
+	RESPKeyColumn   string
+	RESPValueColumn string
 }
 
+// The MySQL server is used to read the ENUM, SET and JSON columns of the
+// tables. It is not used if the IP is not set
 type MySQLServer struct {
 	IP       string
 	Port     uint16
 	User     string
 	Password string
+
+	// The MySQL types of the ENUM, SET and JSON columns of a table are
+	// cached for this long. Changes to the columns are seen after this time
+	MetadataCacheTimeoutMS uint32
 }
 
 type RonDB struct {
@@ -73,6 +108,22 @@ func init() {
 		BufferSize:      320 * 1024,
 		GOMAXPROCS:      -1,
 		PreAllocBuffers: 1024,
+
+		MaxOpenTransactions:      256,
+		TransactionIdleTimeoutMS: 30000,
+
+		MaxBlobReadSize: 128 * 1024,
+
+		TrimCharPadding:   true,
+		TrimBinaryPadding: false,
+
+		GRPCIP:   "localhost",
+		GRPCPort: 0,
+
+		RESPIP:          "localhost",
+		RESPPort:        0,
+		RESPKeyColumn:   "key",
+		RESPValueColumn: "value",
 	}
 
 	ronDBConfig := RonDB{
@@ -85,6 +136,8 @@ func init() {
 		Port:     3306,
 		User:     "rondb",
 		Password: "rondb",
+
+		MetadataCacheTimeoutMS: 60000,
 	}
 
 	log := log.LogConfig{
@@ -0,0 +1,124 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package csv writes the rows returned by the data access layer as comma
+// separated values, see RFC 4180. encoding/csv is not used as it changes
+// the line breaks inside the values when the records end with CRLF
+package csv
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Writer collects the records of a CSV response
+type Writer struct {
+	buf strings.Builder
+}
+
+// WriteHeader adds the header record with the names of the columns
+func (w *Writer) WriteHeader(names []string) {
+	for i, name := range names {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		w.writeField(name)
+	}
+	w.buf.WriteString("\r\n")
+}
+
+// Write adds a record. The values are as decoded from the MessagePack
+// response of the data access layer. Null values are empty fields, and
+// empty strings are quoted so that they can be told apart
+func (w *Writer) Write(values []interface{}) error {
+	for i, value := range values {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		if value == nil {
+			continue
+		}
+
+		field, err := formatValue(value)
+		if err != nil {
+			return err
+		}
+		if field == "" {
+			w.buf.WriteString(`""`)
+		} else {
+			w.writeField(field)
+		}
+	}
+	w.buf.WriteString("\r\n")
+	return nil
+}
+
+// String returns the records written so far
+func (w *Writer) String() string {
+	return w.buf.String()
+}
+
+// writeField quotes the field if it contains a comma, a quote or a line
+// break. Quotes are escaped by doubling them
+func (w *Writer) writeField(field string) {
+	if !strings.ContainsAny(field, ",\"\r\n") {
+		w.buf.WriteString(field)
+		return
+	}
+	w.buf.WriteByte('"')
+	w.buf.WriteString(strings.ReplaceAll(field, `"`, `""`))
+	w.buf.WriteByte('"')
+}
+
+// formatValue returns the text of a value. Binary values are base64
+// encoded, as in JSON responses. The other encodings of the data return
+// types are already strings. The labels of SET columns are separated by
+// commas, as in MySQL
+func formatValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []interface{}:
+		labels := make([]string, len(v))
+		for i, label := range v {
+			str, ok := label.(string)
+			if !ok {
+				return "", fmt.Errorf("expecting a string. Got: %v", label)
+			}
+			labels[i] = str
+		}
+		return strings.Join(labels, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v", value)
+	}
+}
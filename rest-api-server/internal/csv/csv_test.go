@@ -0,0 +1,54 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package csv
+
+import (
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	w := Writer{}
+	w.WriteHeader([]string{"id", "name,full", `"q"`})
+
+	rows := [][]interface{}{
+		{int64(-1), "alice", nil},
+		{uint64(2), "", "line\r\nbreak"},
+		{1.5, `say "hi"`, []byte{0, 1, 2}},
+		{float32(0.1), []interface{}{"a", "b"}, true},
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatalf("Unable to write %v. Error: %v", row, err)
+		}
+	}
+
+	expected := "id,\"name,full\",\"\"\"q\"\"\"\r\n" +
+		"-1,alice,\r\n" +
+		"2,\"\",\"line\r\nbreak\"\r\n" +
+		"1.5,\"say \"\"hi\"\"\",AAEC\r\n" +
+		"0.1,\"a,b\",true\r\n"
+	if w.String() != expected {
+		t.Fatalf("Wrong records. Expecting: %q, Got: %q", expected, w.String())
+	}
+
+	for _, row := range [][]interface{}{{map[string]interface{}{}}, {[]interface{}{1}}} {
+		if err := w.Write(row); err == nil {
+			t.Fatalf("Writing %v should have failed", row)
+		}
+	}
+}
@@ -30,6 +30,9 @@ package dal
 import "C"
 import (
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
@@ -38,6 +41,9 @@ type DalError struct {
 	Message     string
 	ErrLineNo   int
 	ErrFileName string
+	// the transaction of the request does not exist. The response buffer
+	// is not set, unlike for other 404 errors
+	TxNotFound bool
 }
 
 func (e *DalError) Error() string {
@@ -93,6 +99,95 @@ func RonDBPKRead(request *NativeBuffer, response *NativeBuffer) *DalError {
 	return nil
 }
 
+func RonDBPKWrite(request *NativeBuffer, response *NativeBuffer) *DalError {
+	var crequest C.RS_Buffer
+	var cresponse C.RS_Buffer
+	crequest.buffer = (*C.char)(request.Buffer)
+	crequest.size = C.uint(request.Size)
+
+	cresponse.buffer = (*C.char)(response.Buffer)
+	cresponse.size = C.uint(response.Size)
+
+	ret := C.PKWrite(&crequest, &cresponse)
+
+	if ret.http_code != http.StatusOK {
+		return cToGoRet(&ret)
+	}
+
+	return nil
+}
+
+func RonDBScan(request *NativeBuffer, response *NativeBuffer) *DalError {
+	var crequest C.RS_Buffer
+	var cresponse C.RS_Buffer
+	crequest.buffer = (*C.char)(request.Buffer)
+	crequest.size = C.uint(request.Size)
+
+	cresponse.buffer = (*C.char)(response.Buffer)
+	cresponse.size = C.uint(response.Size)
+
+	ret := C.Scan(&crequest, &cresponse)
+
+	if ret.http_code != http.StatusOK {
+		return cToGoRet(&ret)
+	}
+
+	return nil
+}
+
+// BlobReader reads a BLOB/TEXT value in parts. The row is share locked
+// until the reader is closed
+type BlobReader struct {
+	reader unsafe.Pointer
+}
+
+// RonDBBlobOpen opens a reader for a BLOB/TEXT column and reads the part of
+// the value that starts at the offset set in the request. The part is as
+// large as the response buffer
+func RonDBBlobOpen(request *NativeBuffer, response *NativeBuffer) (*BlobReader, *DalError) {
+	var crequest C.RS_Buffer
+	var cresponse C.RS_Buffer
+	crequest.buffer = (*C.char)(request.Buffer)
+	crequest.size = C.uint(request.Size)
+
+	cresponse.buffer = (*C.char)(response.Buffer)
+	cresponse.size = C.uint(response.Size)
+
+	var reader unsafe.Pointer
+	ret := C.BlobOpen(&crequest, &cresponse, &reader)
+
+	if ret.http_code != http.StatusOK {
+		return nil, cToGoRet(&ret)
+	}
+
+	return &BlobReader{reader: reader}, nil
+}
+
+// Read reads the part of the value that starts at the offset set in the
+// request
+func (r *BlobReader) Read(request *NativeBuffer, response *NativeBuffer) *DalError {
+	var crequest C.RS_Buffer
+	var cresponse C.RS_Buffer
+	crequest.buffer = (*C.char)(request.Buffer)
+	crequest.size = C.uint(request.Size)
+
+	cresponse.buffer = (*C.char)(response.Buffer)
+	cresponse.size = C.uint(response.Size)
+
+	ret := C.BlobRead(r.reader, &crequest, &cresponse)
+
+	if ret.http_code != http.StatusOK {
+		return cToGoRet(&ret)
+	}
+
+	return nil
+}
+
+// Close closes the reader and releases the lock on the row
+func (r *BlobReader) Close() {
+	C.BlobClose(r.reader)
+}
+
 func RonDBBatchedPKRead(noOps uint32, requests []*NativeBuffer, responses []*NativeBuffer) *DalError {
 	reqMem := C.malloc(C.size_t(noOps) * C.size_t(C.sizeof_RS_Buffer))
 	defer C.free(reqMem)
@@ -119,9 +214,111 @@ func RonDBBatchedPKRead(noOps uint32, requests []*NativeBuffer, responses []*Nat
 	return nil
 }
 
+func RonDBBatchedPKOperation(noOps uint32, requests []*NativeBuffer, responses []*NativeBuffer, atomic bool) *DalError {
+	reqMem := C.malloc(C.size_t(noOps) * C.size_t(C.sizeof_RS_Buffer))
+	defer C.free(reqMem)
+	cReqs := unsafe.Slice((*C.RS_Buffer)(reqMem), noOps)
+
+	respMem := C.malloc(C.size_t(noOps) * C.size_t(C.sizeof_RS_Buffer))
+	defer C.free(respMem)
+	cResps := unsafe.Slice((*C.RS_Buffer)(respMem), noOps)
+
+	for i := 0; i < int(noOps); i++ {
+		cReqs[i].buffer = (*C.char)(requests[i].Buffer)
+		cReqs[i].size = C.uint(requests[i].Size)
+
+		cResps[i].buffer = (*C.char)(responses[i].Buffer)
+		cResps[i].size = C.uint(responses[i].Size)
+	}
+
+	ret := C.PKBatchOperation(C.uint(noOps), (*C.RS_Buffer)(reqMem), (*C.RS_Buffer)(respMem), C.bool(atomic))
+
+	if ret.http_code != http.StatusOK {
+		return cToGoRet(&ret)
+	}
+
+	return nil
+}
+
+func RonDBTxBegin() (string, *DalError) {
+	var txID [C.TX_ID_LEN + 1]C.char
+	ret := C.TxBegin(&txID[0])
+
+	if ret.http_code != http.StatusOK {
+		return "", cToGoRet(&ret)
+	}
+	return C.GoString(&txID[0]), nil
+}
+
+func RonDBTxCommit(txID string) *DalError {
+	cTxID := C.CString(txID)
+	defer C.free(unsafe.Pointer(cTxID))
+	ret := C.TxCommit(cTxID)
+
+	if ret.http_code != http.StatusOK {
+		return cToGoRet(&ret)
+	}
+	return nil
+}
+
+func RonDBTxAbort(txID string) *DalError {
+	cTxID := C.CString(txID)
+	defer C.free(unsafe.Pointer(cTxID))
+	ret := C.TxAbort(cTxID)
+
+	if ret.http_code != http.StatusOK {
+		return cToGoRet(&ret)
+	}
+	return nil
+}
+
+var txIdleTimeoutMS uint32
+var txReaperOnce sync.Once
+
+// ConfigureTransactions sets the max number of open explicit transactions
+// and the idle timeout after which the unused transactions are aborted
+func ConfigureTransactions(maxOpenTxs uint32, idleTimeoutMS uint32) {
+	C.TxConfigure(C.uint(maxOpenTxs), C.uint(idleTimeoutMS))
+	atomic.StoreUint32(&txIdleTimeoutMS, idleTimeoutMS)
+	txReaperOnce.Do(func() {
+		go abortIdleTransactions()
+	})
+}
+
+// ConfigureBlobs sets the max size of the BLOB/TEXT columns that can be read
+func ConfigureBlobs(maxReadSize uint32) {
+	C.BlobConfigure(C.uint(maxReadSize))
+}
+
+// ConfigurePadding sets whether the padding of CHAR and BINARY columns is
+// trimmed by default
+func ConfigurePadding(trimChar bool, trimBinary bool) {
+	var char, binary C.uint
+	if trimChar {
+		char = 1
+	}
+	if trimBinary {
+		binary = 1
+	}
+	C.PaddingConfigure(char, binary)
+}
+
+func abortIdleTransactions() {
+	for {
+		// check at least once a second so that a new timeout is picked up quickly
+		interval := atomic.LoadUint32(&txIdleTimeoutMS)/2 + 1
+		if interval > 1000 {
+			interval = 1000
+		}
+		time.Sleep(time.Duration(interval) * time.Millisecond)
+		C.TxAbortIdle()
+	}
+}
+
 func cToGoRet(ret *C.RS_Status) *DalError {
 	return &DalError{HttpCode: int(ret.http_code), Message: C.GoString(&ret.message[0]),
-		ErrLineNo: int(ret.err_line_no), ErrFileName: C.GoString(&ret.err_file_name[0])}
+		ErrLineNo: int(ret.err_line_no), ErrFileName: C.GoString(&ret.err_file_name[0]),
+		TxNotFound: ret.rdrs_code == C.RDRS_ERR_TX_NOT_FOUND}
 }
 
 func GetRonDBStats() (*RonDBStats, *DalError) {
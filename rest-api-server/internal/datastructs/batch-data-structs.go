@@ -16,7 +16,11 @@
  */
 package datastructs
 
-import "hopsworks.ai/rdrs/version"
+import (
+	"encoding/json"
+
+	"hopsworks.ai/rdrs/version"
+)
 
 const DBS_OPS_EP_GROUP = "/" + version.API_VERSION + "/"
 const BATCH_OPERATION = "batch"
@@ -24,12 +28,32 @@ const BATCH_HTTP_VERB = "POST"
 
 type BatchOperation struct {
 	Operations *[]BatchSubOperation `json:"operations" binding:"required,min=1,max=4096,unique,dive"`
+	// If set then either all operations are committed or none
+	Atomic *bool `json:"atomic"     binding:"omitempty"`
 }
 
 type BatchSubOperation struct {
-	Method      *string     `json:"method"        binding:"required,oneof=POST"`
-	RelativeURL *string     `json:"relative-url"  binding:"required,min=1"`
-	Body        *PKReadBody `json:"body"          binding:"required,min=1"`
+	Method      *string                `json:"method"        binding:"required,oneof=POST DELETE"`
+	RelativeURL *string                `json:"relative-url"  binding:"required,min=1"`
+	Body        *BatchSubOperationBody `json:"body"          binding:"required,min=1"`
+}
+
+// Body of a batch sub operation. Depending on the operation
+// it is validated as PKReadBody, UniqueReadBody, PKWriteBody or PKDeleteBody
+type BatchSubOperationBody struct {
+	Filters     *[]Filter                    `json:"filters"         binding:"required,min=1,max=4096,dive"`
+	ReadColumns *[]ReadColumn                `json:"readColumns"    binding:"omitempty,min=1,max=4096,unique"`
+	Values      *map[string]*json.RawMessage `json:"values"         binding:"omitempty,max=4096"`
+	Mode        *string                      `json:"mode"           binding:"omitempty,oneof=insert update upsert"`
+	OperationID *string                      `json:"operationId"    binding:"omitempty,min=1,max=64"`
+	LockMode    *string                      `json:"lockMode"       binding:"omitempty,oneof=committed shared exclusive"`
+	Index       *string                      `json:"index"          binding:"omitempty,min=1,max=64"`
+
+	BigNumbersAsStrings *bool   `json:"bigNumbersAsStrings"   binding:"omitempty"`
+	TemporalFormat      *string `json:"temporalFormat"        binding:"omitempty,oneof=mysql rfc3339 epochMillis"`
+	TimeZone            *string `json:"timeZone"              binding:"omitempty,min=1,max=64"`
+	OmitNulls           *bool   `json:"omitNulls"             binding:"omitempty"`
+	IncludeNullMask     *bool   `json:"includeNullMask"       binding:"omitempty"`
 }
 
 // data structs for testing
@@ -43,6 +67,8 @@ type BatchSubOperationTestInfo struct {
 }
 
 type BatchOperationTestInfo struct {
-	Operations []BatchSubOperationTestInfo
-	HttpCode   int
+	Operations   []BatchSubOperationTestInfo
+	Atomic       bool
+	HttpCode     int
+	BodyContains string
 }
@@ -0,0 +1,44 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package datastructs
+
+const BLOB_OPERATION = "blob"
+const BLOB_HTTP_VERB = "GET"
+const BLOB_COLUMN_PP = "column"
+const BLOB_EP = BLOB_OPERATION + "/:" + BLOB_COLUMN_PP
+
+// Primary key of the row. A JSON object, e.g., {"id0": 1}
+const BLOB_KEY_PARAM_NAME = "key"
+
+type BlobReadParams struct {
+	DB      *string   `json:"db"`
+	Table   *string   `json:"table"`
+	Column  *string   `json:"column"`
+	Filters *[]Filter `json:"filters"`
+}
+
+// Path parameters
+type BlobReadPP struct {
+	DB     *string `json:"db" uri:"db"  binding:"required,min=1,max=64"`
+	Table  *string `json:"table" uri:"table"  binding:"required,min=1,max=64"`
+	Column *string `json:"column" uri:"column"  binding:"required,min=1,max=64"`
+}
+
+type BlobReadQuery struct {
+	Key *string `json:"key"    form:"key"    binding:"required,min=2"`
+}
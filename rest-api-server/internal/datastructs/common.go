@@ -21,3 +21,34 @@ import "hopsworks.ai/rdrs/version"
 const DB_PP = "db"
 const TABLE_PP = "table"
 const DB_OPS_EP_GROUP = "/" + version.API_VERSION + "/:" + DB_PP + "/:" + TABLE_PP + "/"
+
+// newline delimited JSON. Scans and batches stream their results in this
+// format if the client accepts it
+const NDJSON_MIME = "application/x-ndjson"
+
+// binary formats of pk-read, unique-read and batch responses. Binary
+// columns are returned as raw bytes instead of base64 strings
+const MSGPACK_MIME = "application/msgpack"
+const CBOR_MIME = "application/cbor"
+
+// Arrow IPC stream. Scans and batches return their rows as a record batch
+const ARROW_MIME = "application/vnd.apache.arrow.stream"
+
+// comma separated values. Scans stream their rows and batches return a
+// record per operation. Binary values are base64 encoded
+const CSV_MIME = "text/csv"
+
+// MySQL types of the columns that NDB stores as other types. ENUM and SET
+// columns are stored as CHAR and JSON columns as BLOB
+const (
+	COLUMN_TYPE_ENUM = "enum"
+	COLUMN_TYPE_SET  = "set"
+	COLUMN_TYPE_JSON = "json"
+)
+
+// ColumnType is the MySQL type of an ENUM, SET or JSON column
+type ColumnType struct {
+	Column string
+	Type   string
+	Labels []string // ENUM and SET labels in the order of the column definition
+}
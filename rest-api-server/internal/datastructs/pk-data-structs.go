@@ -21,6 +21,9 @@ import "encoding/json"
 const PK_DB_OPERATION = "pk-read"
 const PK_HTTP_VERB = "POST"
 
+const UNIQUE_READ_OPERATION = "unique-read"
+const UNIQUE_READ_HTTP_VERB = "POST"
+
 // Primary key column filter
 const FILTER_PARAM_NAME = "filters"
 const READ_COL_PARAM_NAME = "read-columns"
@@ -32,6 +35,24 @@ type PKReadParams struct {
 	Filters     *[]Filter     `json:"filters"`
 	ReadColumns *[]ReadColumn `json:"readColumns"`
 	OperationID *string       `json:"operationId"`
+	TxID        *string       `json:"txId"`
+	LockMode    *string       `json:"lockMode"`
+	Index       *string       `json:"index"` // unique index. nil for primary key reads
+
+	// 64 bit integers and decimals are returned as JSON strings
+	BigNumbersAsStrings *bool `json:"bigNumbersAsStrings"`
+
+	// format and time zone of DATE, DATETIME, TIME and TIMESTAMP values
+	TemporalFormat *string `json:"temporalFormat"`
+	TimeZone       *string `json:"timeZone"`
+
+	// NULL columns are left out of the data, and/or listed in nullColumns
+	OmitNulls       *bool `json:"omitNulls"`
+	IncludeNullMask *bool `json:"includeNullMask"`
+
+	// MSGPACK_MIME, CBOR_MIME, or ARROW_MIME and CSV_MIME in batches. The
+	// response is JSON if it is empty
+	ResponseFormat string `json:"-"`
 }
 
 // Path parameters
@@ -44,6 +65,30 @@ type PKReadBody struct {
 	Filters     *[]Filter     `json:"filters"         form:"filters"         binding:"required,min=1,max=4096,dive"`
 	ReadColumns *[]ReadColumn `json:"readColumns"    form:"read-columns"    binding:"omitempty,min=1,max=4096,unique"`
 	OperationID *string       `json:"operationId"    form:"operation-id"    binding:"omitempty,min=1,max=64"`
+	TxID        *string       `json:"txId"           form:"tx-id"           binding:"omitempty,len=32,hexadecimal"`
+	LockMode    *string       `json:"lockMode"       form:"lock-mode"       binding:"omitempty,oneof=committed shared exclusive"`
+
+	BigNumbersAsStrings *bool   `json:"bigNumbersAsStrings"   form:"big-numbers-as-strings"   binding:"omitempty"`
+	TemporalFormat      *string `json:"temporalFormat"        form:"temporal-format"          binding:"omitempty,oneof=mysql rfc3339 epochMillis"`
+	TimeZone            *string `json:"timeZone"              form:"time-zone"                binding:"omitempty,min=1,max=64"`
+	OmitNulls           *bool   `json:"omitNulls"             form:"omit-nulls"               binding:"omitempty"`
+	IncludeNullMask     *bool   `json:"includeNullMask"       form:"include-null-mask"        binding:"omitempty"`
+}
+
+// Unique reads use the columns of the unique index as filters
+type UniqueReadBody struct {
+	Index       *string       `json:"index"           form:"index"           binding:"required,min=1,max=64"`
+	Filters     *[]Filter     `json:"filters"         form:"filters"         binding:"required,min=1,max=4096,dive"`
+	ReadColumns *[]ReadColumn `json:"readColumns"    form:"read-columns"    binding:"omitempty,min=1,max=4096,unique"`
+	OperationID *string       `json:"operationId"    form:"operation-id"    binding:"omitempty,min=1,max=64"`
+	TxID        *string       `json:"txId"           form:"tx-id"           binding:"omitempty,len=32,hexadecimal"`
+	LockMode    *string       `json:"lockMode"       form:"lock-mode"       binding:"omitempty,oneof=committed shared exclusive"`
+
+	BigNumbersAsStrings *bool   `json:"bigNumbersAsStrings"   form:"big-numbers-as-strings"   binding:"omitempty"`
+	TemporalFormat      *string `json:"temporalFormat"        form:"temporal-format"          binding:"omitempty,oneof=mysql rfc3339 epochMillis"`
+	TimeZone            *string `json:"timeZone"              form:"time-zone"                binding:"omitempty,min=1,max=64"`
+	OmitNulls           *bool   `json:"omitNulls"             form:"omit-nulls"               binding:"omitempty"`
+	IncludeNullMask     *bool   `json:"includeNullMask"       form:"include-null-mask"        binding:"omitempty"`
 }
 
 type Filter struct {
@@ -51,10 +96,29 @@ type Filter struct {
 	Value  *json.RawMessage `json:"value"    form:"value"    binding:"required"`
 }
 
+// Data return types. base64 and hex return the bytes of char, binary,
+// bit and BLOB/TEXT columns as encoded strings. string returns BIGINT and
+// DECIMAL columns as JSON strings
 const (
 	DRT_DEFAULT = "default"
-	DRT_BASE64  = "base64" // not implemented yet
-	DRT_HEX     = "hex"    // not implemented yet
+	DRT_BASE64  = "base64"
+	DRT_HEX     = "hex"
+	DRT_STRING  = "string"
+)
+
+// Formats of temporal values. Time zones are "Z", "UTC" or an offset
+// such as "+05:30"
+const (
+	TF_MYSQL        = "mysql"
+	TF_RFC3339      = "rfc3339"
+	TF_EPOCH_MILLIS = "epochMillis"
+)
+
+// Lock modes of the read operations
+const (
+	LOCK_MODE_COMMITTED = "committed"
+	LOCK_MODE_SHARED    = "shared"
+	LOCK_MODE_EXCLUSIVE = "exclusive"
 )
 
 type ReadColumn struct {
@@ -62,11 +126,15 @@ type ReadColumn struct {
 
 	// Data return type you can change the return type for the column data
 	// int/floats/decimal are returned as JSON Number type (default),
-	// varchar/char are returned as strings (default) and varbinary as base64 (default)
-	// Right now only default return type is supported
-	DataReturnType *string `json:"dataReturnType"    form:"column"    binding:"Enum=default,min=1,max=64"`
+	// varchar/char are returned as strings (default) and varbinary as base64 (default).
+	// The bytes of char, binary, bit and BLOB/TEXT columns can also be
+	// returned as base64 or hex encoded strings, and BIGINT and DECIMAL
+	// columns as strings
+	DataReturnType *string `json:"dataReturnType"    form:"data-return-type"    binding:"omitempty,oneof=default base64 hex string"`
 
-	// more parameter can be added later.
+	// Remove the trailing spaces of char and the trailing NUL bytes of
+	// binary columns. The default is set in the RestServer config
+	TrimPadding *bool `json:"trimPadding"    form:"trim-padding"    binding:"omitempty"`
 }
 
 type PKTestInfo struct {
@@ -0,0 +1,34 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+package datastructs
+
+const PK_DELETE_OPERATION = "pk-delete"
+const PK_DELETE_HTTP_VERB = "POST"
+
+type PKDeleteBody struct {
+	Filters     *[]Filter `json:"filters"         form:"filters"         binding:"required,min=1,max=4096,dive"`
+	OperationID *string   `json:"operationId"    form:"operation-id"    binding:"omitempty,min=1,max=64"`
+	TxID        *string   `json:"txId"           form:"tx-id"           binding:"omitempty,len=32,hexadecimal"`
+}
+
+type PKDeleteTestInfo struct {
+	PkReq        PKDeleteBody
+	Table        string
+	Db           string
+	HttpCode     int
+	BodyContains string
+}
@@ -0,0 +1,60 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+package datastructs
+
+import "encoding/json"
+
+const PK_WRITE_OPERATION = "pk-write"
+const PK_WRITE_HTTP_VERB = "POST"
+
+// Write modes
+const (
+	PK_WRITE_MODE_INSERT = "insert"
+	PK_WRITE_MODE_UPDATE = "update"
+	PK_WRITE_MODE_UPSERT = "upsert"
+	PK_WRITE_MODE_DELETE = "delete" // only used by pk-delete operations
+)
+
+type PKWriteParams struct {
+	DB          *string                      `json:"db" `
+	Table       *string                      `json:"table"`
+	Filters     *[]Filter                    `json:"filters"`
+	Values      *map[string]*json.RawMessage `json:"values"`
+	Mode        *string                      `json:"mode"`
+	OperationID *string                      `json:"operationId"`
+	TxID        *string                      `json:"txId"`
+
+	// MSGPACK_MIME or CBOR_MIME. The response is JSON if it is empty
+	ResponseFormat string `json:"-"`
+}
+
+type PKWriteBody struct {
+	Filters *[]Filter `json:"filters"         form:"filters"         binding:"required,min=1,max=4096,dive"`
+	// Values of the non primary key columns. JSON null sets the column to NULL
+	Values      *map[string]*json.RawMessage `json:"values"         form:"values"          binding:"omitempty,max=4096"`
+	Mode        *string                      `json:"mode"           form:"mode"            binding:"required,oneof=insert update upsert"`
+	OperationID *string                      `json:"operationId"    form:"operation-id"    binding:"omitempty,min=1,max=64"`
+	TxID        *string                      `json:"txId"           form:"tx-id"           binding:"omitempty,len=32,hexadecimal"`
+}
+
+type PKWriteTestInfo struct {
+	PkReq        PKWriteBody
+	Table        string
+	Db           string
+	HttpCode     int
+	BodyContains string
+}
@@ -0,0 +1,138 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package datastructs
+
+import "encoding/json"
+
+const SCAN_OPERATION = "scan"
+const SCAN_HTTP_VERB = "POST"
+
+// Name of the ordered index of the primary key. Paginated scans use it
+// to resume the scan
+const PRIMARY_INDEX = "PRIMARY"
+
+const INDEX_SCAN_OPERATION = "range"
+const INDEX_PP = "index"
+const INDEX_SCAN_EP = "index/:" + INDEX_PP + "/" + INDEX_SCAN_OPERATION
+
+// Order of the rows returned by ordered index scans
+const (
+	SCAN_ORDER_ASC  = "asc"
+	SCAN_ORDER_DESC = "desc"
+)
+
+// Scan filter operators
+const (
+	SCAN_FILTER_EQ     = "eq"
+	SCAN_FILTER_NE     = "ne"
+	SCAN_FILTER_LT     = "lt"
+	SCAN_FILTER_LE     = "le"
+	SCAN_FILTER_GT     = "gt"
+	SCAN_FILTER_GE     = "ge"
+	SCAN_FILTER_ISNULL = "isnull"
+	SCAN_FILTER_AND    = "and"
+	SCAN_FILTER_OR     = "or"
+	SCAN_FILTER_NOT    = "not"
+)
+
+type ScanParams struct {
+	DB          *string       `json:"db" `
+	Table       *string       `json:"table"`
+	Filter      *ScanFilter   `json:"filter"`
+	ReadColumns *[]ReadColumn `json:"readColumns"`
+	Limit       *uint32       `json:"limit"`
+	OperationID *string       `json:"operationId"`
+
+	// only used by ordered index scans
+	Index      *string     `json:"index"`
+	LowerBound *IndexBound `json:"lowerBound"`
+	UpperBound *IndexBound `json:"upperBound"`
+	Order      *string     `json:"order"`
+
+	// table scans are returned in pages. See PageToken in ScanBody
+	Paginate bool `json:"paginate"`
+
+	// rows are returned one per line, see ds.NDJSON_MIME
+	NDJSON bool `json:"ndjson"`
+
+	// ARROW_MIME or CSV_MIME. The response is JSON if it is empty
+	ResponseFormat string `json:"-"`
+
+	// 64 bit integers and decimals are returned as JSON strings
+	BigNumbersAsStrings *bool `json:"bigNumbersAsStrings"`
+
+	// format and time zone of DATE, DATETIME, TIME and TIMESTAMP values
+	TemporalFormat *string `json:"temporalFormat"`
+	TimeZone       *string `json:"timeZone"`
+
+	// NULL columns are left out of the rows
+	OmitNulls *bool `json:"omitNulls"`
+}
+
+// Path parameters
+type IndexScanPP struct {
+	DB    *string `json:"db" uri:"db"  binding:"required,min=1,max=64"`
+	Table *string `json:"table" uri:"table"  binding:"required,min=1,max=64"`
+	Index *string `json:"index" uri:"index"  binding:"required,min=1,max=64"`
+}
+
+type IndexScanBody struct {
+	LowerBound  *IndexBound   `json:"lowerBound"     form:"lower-bound"     binding:"omitempty"`
+	UpperBound  *IndexBound   `json:"upperBound"     form:"upper-bound"     binding:"omitempty"`
+	Order       *string       `json:"order"          form:"order"           binding:"omitempty,oneof=asc desc"`
+	Filter      *ScanFilter   `json:"filter"         form:"filter"          binding:"omitempty"`
+	ReadColumns *[]ReadColumn `json:"readColumns"    form:"read-columns"    binding:"omitempty,min=1,max=4096,unique"`
+	Limit       *uint32       `json:"limit"          form:"limit"           binding:"omitempty,min=1"`
+	OperationID *string       `json:"operationId"    form:"operation-id"    binding:"omitempty,min=1,max=64"`
+
+	BigNumbersAsStrings *bool   `json:"bigNumbersAsStrings"   form:"big-numbers-as-strings"   binding:"omitempty"`
+	TemporalFormat      *string `json:"temporalFormat"        form:"temporal-format"          binding:"omitempty,oneof=mysql rfc3339 epochMillis"`
+	TimeZone            *string `json:"timeZone"              form:"time-zone"                binding:"omitempty,min=1,max=64"`
+	OmitNulls           *bool   `json:"omitNulls"             form:"omit-nulls"               binding:"omitempty"`
+	IncludeNullMask     *bool   `json:"includeNullMask"       form:"include-null-mask"        binding:"omitempty"` // not supported. Rejected by the handler
+}
+
+// IndexBound is a prefix of the index columns. Bounds are inclusive by default
+type IndexBound struct {
+	Values    *[]Filter `json:"values"       form:"values"       binding:"required,min=1,max=4096,dive"`
+	Inclusive *bool     `json:"inclusive"    form:"inclusive"`
+}
+
+type ScanBody struct {
+	Filter      *ScanFilter   `json:"filter"         form:"filter"          binding:"omitempty"`
+	ReadColumns *[]ReadColumn `json:"readColumns"    form:"read-columns"    binding:"omitempty,min=1,max=4096,unique"`
+	Limit       *uint32       `json:"limit"          form:"limit"           binding:"omitempty,min=1"`
+	OperationID *string       `json:"operationId"    form:"operation-id"    binding:"omitempty,min=1,max=64"`
+	PageToken   *string       `json:"pageToken"      form:"page-token"      binding:"omitempty,min=1"` // nextPageToken of the previous page
+
+	BigNumbersAsStrings *bool   `json:"bigNumbersAsStrings"   form:"big-numbers-as-strings"   binding:"omitempty"`
+	TemporalFormat      *string `json:"temporalFormat"        form:"temporal-format"          binding:"omitempty,oneof=mysql rfc3339 epochMillis"`
+	TimeZone            *string `json:"timeZone"              form:"time-zone"                binding:"omitempty,min=1,max=64"`
+	OmitNulls           *bool   `json:"omitNulls"             form:"omit-nulls"               binding:"omitempty"`
+	IncludeNullMask     *bool   `json:"includeNullMask"       form:"include-null-mask"        binding:"omitempty"` // not supported. Rejected by the handler
+}
+
+// ScanFilter is a node of the filter predicate tree. Comparisons and
+// isnull use the column (and value), while and/or/not combine the
+// nested filters
+type ScanFilter struct {
+	Op      *string          `json:"op"         form:"op"         binding:"required,oneof=eq ne lt le gt ge isnull and or not"`
+	Column  *string          `json:"column"     form:"column"     binding:"omitempty,min=1,max=64"`
+	Value   *json.RawMessage `json:"value"      form:"value"`
+	Filters *[]ScanFilter    `json:"filters"    form:"filters"    binding:"omitempty,min=1,max=4096,dive"`
+}
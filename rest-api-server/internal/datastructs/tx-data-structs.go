@@ -0,0 +1,37 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package datastructs
+
+import "hopsworks.ai/rdrs/version"
+
+const TX_HTTP_VERB = "POST"
+const TX_BEGIN_OPERATION = "begin"
+const TX_COMMIT_OPERATION = "commit"
+const TX_ABORT_OPERATION = "abort"
+
+const TX_ID_PP = "id"
+const TX_EP_GROUP = "/" + version.API_VERSION + "/tx/"
+
+// Path parameters
+type TxPP struct {
+	TxID *string `json:"txId" uri:"id"  binding:"required,len=32,hexadecimal"`
+}
+
+type TxResponse struct {
+	TxID string `json:"txId"`
+}
@@ -0,0 +1,216 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package mysql reads the table metadata that is only known to the MySQL
+// server, e.g., the labels of ENUM columns
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"hopsworks.ai/rdrs/internal/config"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/log"
+)
+
+// The metadata is read with short timeouts, as the first request for a
+// table waits for it
+const (
+	connectTimeout = "2s"
+	queryTimeout   = 2 * time.Second
+)
+
+type cachedColumnTypes struct {
+	types    []ds.ColumnType
+	loadedAt time.Time
+}
+
+// columnTypesLoad is a read of the column types of a table. Concurrent
+// requests for the same table wait for the same read
+type columnTypesLoad struct {
+	done  chan struct{} // closed when the read ends
+	types []ds.ColumnType
+	err   error
+}
+
+var (
+	dbOnce sync.Once
+	db     *sql.DB
+	dbErr  error
+
+	cacheMutex sync.Mutex
+	cache      = make(map[string]cachedColumnTypes)
+	loads      = make(map[string]*columnTypesLoad)
+)
+
+func connection() (*sql.DB, error) {
+	dbOnce.Do(func() {
+		conf := config.Configuration().MySQLServer
+		db, dbErr = sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/?timeout=%s",
+			conf.User, conf.Password, conf.IP, conf.Port, connectTimeout))
+	})
+	return db, dbErr
+}
+
+// ColumnTypes returns the ENUM, SET and JSON columns of the table. The
+// types are cached, see MySQLServer.MetadataCacheTimeoutMS. Expired types
+// are returned while they are read again in the background, so only the
+// first request for a table waits for the MySQL server. An error is
+// returned if the types of the table have never been read and the MySQL
+// server can not be reached. No types are returned if MySQLServer.IP is
+// not set, and the columns are read as their NDB types
+func ColumnTypes(database string, table string) ([]ds.ColumnType, error) {
+	conf := config.Configuration().MySQLServer
+	if conf.IP == "" {
+		return nil, nil
+	}
+
+	key := database + "/" + table
+	timeout := time.Duration(conf.MetadataCacheTimeoutMS) * time.Millisecond
+
+	cacheMutex.Lock()
+	cached, ok := cache[key]
+	if ok && time.Since(cached.loadedAt) < timeout {
+		cacheMutex.Unlock()
+		return cached.types, nil
+	}
+	load := startLoad(key, database, table)
+	cacheMutex.Unlock()
+
+	if ok {
+		return cached.types, nil
+	}
+
+	<-load.done
+	if load.err != nil {
+		return nil, fmt.Errorf("unable to read the column types of %s.%s from MySQL. Error: %v",
+			database, table, load.err)
+	}
+	return load.types, nil
+}
+
+// startLoad reads the column types of the table in the background, unless
+// they are already being read. cacheMutex must be held
+func startLoad(key string, database string, table string) *columnTypesLoad {
+	if load, ok := loads[key]; ok {
+		return load
+	}
+
+	load := &columnTypesLoad{done: make(chan struct{})}
+	loads[key] = load
+	go func() {
+		load.types, load.err = readColumnTypes(database, table)
+
+		cacheMutex.Lock()
+		delete(loads, key)
+		if load.err == nil {
+			cache[key] = cachedColumnTypes{types: load.types, loadedAt: time.Now()}
+		} else {
+			// expired types are kept until they can be read again
+			log.Warnf("Unable to read the column types of %s.%s from MySQL. Error: %v", database, table, load.err)
+		}
+		cacheMutex.Unlock()
+		close(load.done)
+	}()
+	return load
+}
+
+func readColumnTypes(database string, table string) ([]ds.ColumnType, error) {
+	conn, err := connection()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	rows, err := conn.QueryContext(ctx, "SELECT COLUMN_NAME, DATA_TYPE, COLUMN_TYPE FROM information_schema.COLUMNS "+
+		"WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND DATA_TYPE IN ('enum', 'set', 'json')", database, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	types := []ds.ColumnType{}
+	for rows.Next() {
+		var column, dataType, columnType string
+		if err := rows.Scan(&column, &dataType, &columnType); err != nil {
+			return nil, err
+		}
+
+		colType := ds.ColumnType{Column: column, Type: strings.ToLower(dataType)}
+		if colType.Type != ds.COLUMN_TYPE_JSON {
+			colType.Labels, err = ParseLabels(columnType)
+			if err != nil {
+				return nil, err
+			}
+		}
+		types = append(types, colType)
+	}
+	return types, rows.Err()
+}
+
+// ParseLabels returns the labels of an ENUM or SET column definition,
+// e.g., enum('a','b'). Quotes in the labels are doubled
+func ParseLabels(columnType string) ([]string, error) {
+	start := strings.Index(columnType, "(")
+	if start < 0 || !strings.HasSuffix(columnType, ")") {
+		return nil, fmt.Errorf("invalid column type %s", columnType)
+	}
+	def := columnType[start+1 : len(columnType)-1]
+
+	labels := []string{}
+	for i := 0; i < len(def); {
+		if def[i] != '\'' {
+			return nil, fmt.Errorf("invalid column type %s", columnType)
+		}
+
+		var label strings.Builder
+		i++
+		for {
+			if i >= len(def) {
+				return nil, fmt.Errorf("invalid column type %s", columnType)
+			}
+			if def[i] == '\'' {
+				if i+1 < len(def) && def[i+1] == '\'' {
+					label.WriteByte('\'')
+					i += 2
+					continue
+				}
+				i++
+				break
+			}
+			label.WriteByte(def[i])
+			i++
+		}
+		labels = append(labels, label.String())
+
+		if i < len(def) {
+			if def[i] != ',' {
+				return nil, fmt.Errorf("invalid column type %s", columnType)
+			}
+			i++
+		}
+	}
+	return labels, nil
+}
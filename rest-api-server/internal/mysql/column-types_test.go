@@ -0,0 +1,90 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package mysql
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"hopsworks.ai/rdrs/internal/config"
+)
+
+func TestParseLabels(t *testing.T) {
+	tests := map[string][]string{
+		"enum('red','green','blue')": {"red", "green", "blue"},
+		"set('a','b''c','')":         {"a", "b'c", ""},
+		"enum('a,b','(c)')":          {"a,b", "(c)"},
+	}
+	for columnType, expected := range tests {
+		labels, err := ParseLabels(columnType)
+		if err != nil {
+			t.Fatalf("Unable to parse %s. Error: %v", columnType, err)
+		}
+		if !reflect.DeepEqual(labels, expected) {
+			t.Fatalf("Wrong labels for %s. Expecting: %v, Got: %v", columnType, expected, labels)
+		}
+	}
+
+	for _, columnType := range []string{"enum", "enum('a'", "enum('a)", "set('a' 'b')"} {
+		if _, err := ParseLabels(columnType); err == nil {
+			t.Fatalf("Parsing %s should have failed", columnType)
+		}
+	}
+}
+
+func loadConfig(t *testing.T, conf string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(conf), 0600); err != nil {
+		t.Fatalf("Unable to write the configuration. Error: %v", err)
+	}
+	config.LoadConfig(path, true)
+}
+
+func TestColumnTypesUnreachable(t *testing.T) {
+	// nothing listens on port 1
+	loadConfig(t, `{"MySQLServer": {"IP": "127.0.0.1", "Port": 1}}`)
+
+	// Test. Concurrent requests fail instead of returning no types
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if types, err := ColumnTypes("db", "table"); err == nil {
+				t.Errorf("Reading the column types should have failed. Got: %v", types)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Test. The failure is not cached
+	if _, err := ColumnTypes("db", "table"); err == nil {
+		t.Fatalf("Reading the column types should have failed")
+	}
+
+	// Test. The MySQL server is not used if the IP is not set
+	loadConfig(t, `{"MySQLServer": {"IP": ""}}`)
+	types, err := ColumnTypes("db", "table")
+	if err != nil || types != nil {
+		t.Fatalf("Expecting no types. Got: %v, Error: %v", types, err)
+	}
+}
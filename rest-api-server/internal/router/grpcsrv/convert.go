@@ -0,0 +1,214 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package grpcsrv
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/pkg/api"
+)
+
+// The native encoding copies the values of the columns as JSON, see
+// common.CopyGoStrToNDBStr. The gRPC values are converted to the JSON
+// values that a REST client would send
+
+func parseFilters(filters []*api.Filter) (*[]ds.Filter, error) {
+	parsed := make([]ds.Filter, len(filters))
+	for i, filter := range filters {
+		if _, ok := filter.GetValue().GetKind().(*api.Value_NullValue); ok {
+			return nil, fmt.Errorf("field validation for filter '%s' failed. The value can not be null", filter.Column)
+		}
+
+		value, err := jsonValue(filter.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("field validation for filter '%s' failed. %v", filter.Column, err)
+		}
+
+		column := filter.Column
+		parsed[i] = ds.Filter{Column: &column, Value: value}
+	}
+	return &parsed, nil
+}
+
+func parseValues(values map[string]*api.Value) (*map[string]*json.RawMessage, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	parsed := make(map[string]*json.RawMessage, len(values))
+	for column, value := range values {
+		var err error
+		parsed[column], err = jsonValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("field validation for value '%s' failed. %v", column, err)
+		}
+	}
+	return &parsed, nil
+}
+
+func parseReadColumns(cols []*api.ReadColumn) *[]ds.ReadColumn {
+	if len(cols) == 0 {
+		return nil
+	}
+
+	parsed := make([]ds.ReadColumn, len(cols))
+	for i, col := range cols {
+		column := col.Column
+		parsed[i] = ds.ReadColumn{
+			Column:         &column,
+			DataReturnType: optionalString(col.DataReturnType),
+			TrimPadding:    col.TrimPadding,
+		}
+	}
+	return &parsed
+}
+
+func jsonValue(value *api.Value) (*json.RawMessage, error) {
+	var raw []byte
+	var err error
+
+	switch kind := value.GetKind().(type) {
+	case *api.Value_NullValue:
+		raw = []byte("null")
+	case *api.Value_StringValue:
+		raw, err = json.Marshal(kind.StringValue)
+	case *api.Value_IntValue:
+		raw = []byte(strconv.FormatInt(kind.IntValue, 10))
+	case *api.Value_UintValue:
+		raw = []byte(strconv.FormatUint(kind.UintValue, 10))
+	case *api.Value_DoubleValue:
+		if math.IsNaN(kind.DoubleValue) || math.IsInf(kind.DoubleValue, 0) {
+			return nil, fmt.Errorf("NaN and infinite values are not supported")
+		}
+		raw = []byte(strconv.FormatFloat(kind.DoubleValue, 'g', -1, 64))
+	case *api.Value_BytesValue:
+		// binary values are sent base64 encoded
+		raw, err = json.Marshal(kind.BytesValue)
+	case *api.Value_ListValue:
+		return nil, fmt.Errorf("lists are only used in responses")
+	default:
+		return nil, fmt.Errorf("no value is set")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	msg := json.RawMessage(raw)
+	return &msg, nil
+}
+
+// operationBody is the MessagePack body of a pk-read, pk-write or
+// pk-delete response
+type operationBody struct {
+	OperationID string                 `codec:"operationId"`
+	Data        map[string]interface{} `codec:"data"`
+	NullColumns []string               `codec:"nullColumns"`
+	Error       string                 `codec:"error"`
+}
+
+// batchSubResponse is the response of an operation of a batch. Binary
+// batch responses have the code of every operation
+type batchSubResponse struct {
+	Code uint32        `codec:"code"`
+	Body operationBody `codec:"body"`
+}
+
+func newOperationResponse(code uint32, body *operationBody) (*api.OperationResponse, error) {
+	response := api.OperationResponse{
+		Code:        code,
+		OperationId: body.OperationID,
+		NullColumns: body.NullColumns,
+		Error:       body.Error,
+	}
+	if len(body.Data) == 0 {
+		return &response, nil
+	}
+
+	response.Data = make(map[string]*api.Value, len(body.Data))
+	for column, value := range body.Data {
+		var err error
+		response.Data[column], err = typedValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert column '%s'. %v", column, err)
+		}
+	}
+	return &response, nil
+}
+
+// typedValue converts a decoded MessagePack value. Integers that fit in
+// sint64 are int_value, regardless of how they were encoded
+func typedValue(value interface{}) (*api.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return &api.Value{Kind: &api.Value_NullValue{NullValue: true}}, nil
+	case string:
+		return &api.Value{Kind: &api.Value_StringValue{StringValue: v}}, nil
+	case int64:
+		return &api.Value{Kind: &api.Value_IntValue{IntValue: v}}, nil
+	case uint64:
+		if v <= math.MaxInt64 {
+			return &api.Value{Kind: &api.Value_IntValue{IntValue: int64(v)}}, nil
+		}
+		return &api.Value{Kind: &api.Value_UintValue{UintValue: v}}, nil
+	case float32:
+		return &api.Value{Kind: &api.Value_DoubleValue{DoubleValue: float64(v)}}, nil
+	case float64:
+		return &api.Value{Kind: &api.Value_DoubleValue{DoubleValue: v}}, nil
+	case []byte:
+		return &api.Value{Kind: &api.Value_BytesValue{BytesValue: v}}, nil
+	case []interface{}:
+		list := api.ValueList{Values: make([]*api.Value, len(v))}
+		for i, elem := range v {
+			var err error
+			if list.Values[i], err = typedValue(elem); err != nil {
+				return nil, err
+			}
+		}
+		return &api.Value{Kind: &api.Value_ListValue{ListValue: &list}}, nil
+	default:
+		return nil, fmt.Errorf("unexpected type %T", value)
+	}
+}
+
+// Empty strings and false are the same as leaving the field out of a
+// REST request
+
+func optionalString(str string) *string {
+	if str == "" {
+		return nil
+	}
+	return &str
+}
+
+func optionalBool(b bool) *bool {
+	if !b {
+		return nil
+	}
+	return &b
+}
+
+func optionalTxID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,423 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package grpcsrv implements the gRPC API, see pkg/api/rdrs.proto. The
+// requests are validated like the REST requests and use the same native
+// encoding. The rows are read as MessagePack and converted to typed values
+package grpcsrv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/router/handler/batchops"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+	"hopsworks.ai/rdrs/internal/router/handler/pkwrite"
+	"hopsworks.ai/rdrs/internal/router/handler/stat"
+	"hopsworks.ai/rdrs/pkg/api"
+)
+
+type RDRSServer struct {
+	api.UnimplementedRDRSServer
+}
+
+var _ api.RDRSServer = (*RDRSServer)(nil)
+
+func Register(server *grpc.Server) {
+	api.RegisterRDRSServer(server, &RDRSServer{})
+}
+
+func (s *RDRSServer) PKRead(ctx context.Context, req *api.PKReadRequest) (*api.OperationResponse, error) {
+	pkReadParams, err := parsePKRead(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%-v", err))
+	}
+
+	request, response, err := pkread.CreateNativeRequest(pkReadParams)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("%v", err))
+	}
+	defer dal.ReturnBuffer(request)
+	defer dal.ReturnBuffer(response)
+
+	return operationResponse(dal.RonDBPKRead(request, response), response)
+}
+
+func (s *RDRSServer) PKWrite(ctx context.Context, req *api.PKWriteRequest) (*api.OperationResponse, error) {
+	pkWriteParams, err := parsePKWrite(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%-v", err))
+	}
+	return performPKWrite(pkWriteParams)
+}
+
+func (s *RDRSServer) PKDelete(ctx context.Context, req *api.PKDeleteRequest) (*api.OperationResponse, error) {
+	pkDeleteParams, err := parsePKDelete(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%-v", err))
+	}
+	return performPKWrite(pkDeleteParams)
+}
+
+func (s *RDRSServer) Batch(ctx context.Context, req *api.BatchRequest) (*api.BatchResponse, error) {
+	pkOperations, err := parseBatch(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%-v", err))
+	}
+
+	responses, dalErr, err := batchops.ExecuteBatch(pkOperations, req.Atomic, ds.MSGPACK_MIME)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("%v", err))
+	}
+	if dalErr != nil {
+		return nil, status.Error(statusCode(dalErr.HttpCode), batchops.DalErrorMessage(dalErr))
+	}
+
+	batchResponse := api.BatchResponse{Responses: make([]*api.OperationResponse, len(responses))}
+	for i, response := range responses {
+		var subResponse batchSubResponse
+		if err := common.DecodeMsgPackResponse(response, &subResponse); err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("%v", err))
+		}
+		batchResponse.Responses[i], err = newOperationResponse(subResponse.Code, &subResponse.Body)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("%v", err))
+		}
+	}
+	return &batchResponse, nil
+}
+
+func (s *RDRSServer) Stat(ctx context.Context, req *api.StatRequest) (*api.StatResponse, error) {
+	stats, err := stat.Stats()
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("%-v", err))
+	}
+
+	return &api.StatResponse{
+		NativeBufferStats: &api.NativeBufferStats{
+			AllocationsCount:   stats.NativeBufferStats.AllocationsCount,
+			DeallocationsCount: stats.NativeBufferStats.DeallocationsCount,
+			BuffersCount:       stats.NativeBufferStats.BuffersCount,
+			FreeBuffers:        stats.NativeBufferStats.FreeBuffers,
+		},
+		RonDbStats: &api.RonDBStats{
+			NdbObjectsCreationCount: stats.RonDBStats.NdbObjectsCreationCount,
+			NdbObjectsDeletionCount: stats.RonDBStats.NdbObjectsDeletionCount,
+			NdbObjectsTotalCount:    stats.RonDBStats.NdbObjectsTotalCount,
+			NdbObjectsFreeCount:     stats.RonDBStats.NdbObjectsFreeCount,
+		},
+	}, nil
+}
+
+func performPKWrite(pkWriteParams *ds.PKWriteParams) (*api.OperationResponse, error) {
+	request, response, err := pkwrite.CreateNativeRequest(pkWriteParams)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("%v", err))
+	}
+	defer dal.ReturnBuffer(request)
+	defer dal.ReturnBuffer(response)
+
+	return operationResponse(dal.RonDBPKWrite(request, response), response)
+}
+
+// operationResponse decodes the response of the operation before the
+// buffer is returned to the pool. A missing row is not an error, the same
+// as in the REST API
+func operationResponse(dalErr *dal.DalError, response *dal.NativeBuffer) (*api.OperationResponse, error) {
+	code := http.StatusOK
+	if dalErr != nil {
+		// the response buffer is not set if the transaction was not found
+		if dalErr.HttpCode != http.StatusNotFound || dalErr.TxNotFound {
+			return nil, status.Error(statusCode(dalErr.HttpCode), batchops.DalErrorMessage(dalErr))
+		}
+		code = http.StatusNotFound
+	}
+
+	var body operationBody
+	err := common.DecodeMsgPackResponse(common.ProcessFormattedResponse(response.Buffer, ds.MSGPACK_MIME), &body)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("%v", err))
+	}
+	operationResponse, err := newOperationResponse(uint32(code), &body)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("%v", err))
+	}
+	return operationResponse, nil
+}
+
+func statusCode(httpCode int) codes.Code {
+	switch httpCode {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
+func parsePKRead(req *api.PKReadRequest) (*ds.PKReadParams, error) {
+	if err := validateTable(req.Db, req.Table); err != nil {
+		return nil, err
+	}
+
+	filters, err := parseFilters(req.Filters)
+	if err != nil {
+		return nil, err
+	}
+	readColumns := parseReadColumns(req.ReadColumns)
+
+	params := ds.PKReadParams{
+		DB:                  &req.Db,
+		Table:               &req.Table,
+		Filters:             filters,
+		ReadColumns:         readColumns,
+		OperationID:         optionalString(req.OperationId),
+		TxID:                optionalTxID(req.TxId),
+		LockMode:            optionalString(req.LockMode),
+		BigNumbersAsStrings: optionalBool(req.BigNumbersAsStrings),
+		TemporalFormat:      optionalString(req.TemporalFormat),
+		TimeZone:            optionalString(req.TimeZone),
+		OmitNulls:           optionalBool(req.OmitNulls),
+		IncludeNullMask:     optionalBool(req.IncludeNullMask),
+		ResponseFormat:      ds.MSGPACK_MIME,
+	}
+
+	if req.Index == "" {
+		body := ds.PKReadBody{
+			Filters:             params.Filters,
+			ReadColumns:         params.ReadColumns,
+			OperationID:         params.OperationID,
+			TxID:                params.TxID,
+			LockMode:            params.LockMode,
+			BigNumbersAsStrings: params.BigNumbersAsStrings,
+			TemporalFormat:      params.TemporalFormat,
+			TimeZone:            params.TimeZone,
+			OmitNulls:           params.OmitNulls,
+			IncludeNullMask:     params.IncludeNullMask,
+		}
+		if err := binding.Validator.ValidateStruct(&body); err != nil {
+			return nil, err
+		}
+		if err := pkread.ValidateBody(&body); err != nil {
+			return nil, err
+		}
+		return &params, nil
+	}
+
+	params.Index = &req.Index
+	body := ds.UniqueReadBody{
+		Index:               params.Index,
+		Filters:             params.Filters,
+		ReadColumns:         params.ReadColumns,
+		OperationID:         params.OperationID,
+		TxID:                params.TxID,
+		LockMode:            params.LockMode,
+		BigNumbersAsStrings: params.BigNumbersAsStrings,
+		TemporalFormat:      params.TemporalFormat,
+		TimeZone:            params.TimeZone,
+		OmitNulls:           params.OmitNulls,
+		IncludeNullMask:     params.IncludeNullMask,
+	}
+	if err := binding.Validator.ValidateStruct(&body); err != nil {
+		return nil, err
+	}
+	if err := pkread.ValidateUniqueReadBody(&body); err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+func parsePKWrite(req *api.PKWriteRequest) (*ds.PKWriteParams, error) {
+	if err := validateTable(req.Db, req.Table); err != nil {
+		return nil, err
+	}
+
+	filters, err := parseFilters(req.Filters)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parseValues(req.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	body := ds.PKWriteBody{
+		Filters:     filters,
+		Values:      values,
+		Mode:        optionalString(req.Mode),
+		OperationID: optionalString(req.OperationId),
+		TxID:        optionalTxID(req.TxId),
+	}
+	if err := binding.Validator.ValidateStruct(&body); err != nil {
+		return nil, err
+	}
+	if err := pkwrite.ValidateBody(&body); err != nil {
+		return nil, err
+	}
+
+	return &ds.PKWriteParams{
+		DB:             &req.Db,
+		Table:          &req.Table,
+		Filters:        body.Filters,
+		Values:         body.Values,
+		Mode:           body.Mode,
+		OperationID:    body.OperationID,
+		TxID:           body.TxID,
+		ResponseFormat: ds.MSGPACK_MIME,
+	}, nil
+}
+
+func parsePKDelete(req *api.PKDeleteRequest) (*ds.PKWriteParams, error) {
+	if err := validateTable(req.Db, req.Table); err != nil {
+		return nil, err
+	}
+
+	filters, err := parseFilters(req.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	body := ds.PKDeleteBody{
+		Filters:     filters,
+		OperationID: optionalString(req.OperationId),
+		TxID:        optionalTxID(req.TxId),
+	}
+	if err := binding.Validator.ValidateStruct(&body); err != nil {
+		return nil, err
+	}
+	if err := pkwrite.ValidateDeleteBody(&body); err != nil {
+		return nil, err
+	}
+
+	mode := ds.PK_WRITE_MODE_DELETE
+	return &ds.PKWriteParams{
+		DB:             &req.Db,
+		Table:          &req.Table,
+		Filters:        body.Filters,
+		Mode:           &mode,
+		OperationID:    body.OperationID,
+		TxID:           body.TxID,
+		ResponseFormat: ds.MSGPACK_MIME,
+	}, nil
+}
+
+// parseBatch converts the operations to REST batch sub operations, so
+// that they are validated the same way
+func parseBatch(req *api.BatchRequest) ([]batchops.SubOperation, error) {
+	operations := make([]ds.BatchSubOperation, len(req.Operations))
+	for i, operation := range req.Operations {
+		if err := parseBatchOperation(operation, &operations[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	batch := ds.BatchOperation{Operations: &operations, Atomic: &req.Atomic}
+	if err := binding.Validator.ValidateStruct(&batch); err != nil {
+		return nil, err
+	}
+
+	pkOperations := make([]batchops.SubOperation, len(operations))
+	for i := range operations {
+		if err := batchops.ParseOperation(&operations[i], &pkOperations[i]); err != nil {
+			return nil, err
+		}
+	}
+	return pkOperations, nil
+}
+
+func parseBatchOperation(operation *api.BatchOperation, subOp *ds.BatchSubOperation) error {
+	var db, table, op string
+	body := ds.BatchSubOperationBody{}
+
+	var err error
+	switch operation.GetOperation().(type) {
+	case *api.BatchOperation_Read:
+		req := operation.GetRead()
+		if req.TxId != "" {
+			return fmt.Errorf("field validation for 'TxId' failed. Batches do not support transactions")
+		}
+		db, table, op = req.Db, req.Table, ds.PK_DB_OPERATION
+		if req.Index != "" {
+			op = ds.UNIQUE_READ_OPERATION
+			body.Index = &req.Index
+		}
+		if body.Filters, err = parseFilters(req.Filters); err != nil {
+			return err
+		}
+		body.ReadColumns = parseReadColumns(req.ReadColumns)
+		body.OperationID = optionalString(req.OperationId)
+		body.LockMode = optionalString(req.LockMode)
+		body.BigNumbersAsStrings = optionalBool(req.BigNumbersAsStrings)
+		body.TemporalFormat = optionalString(req.TemporalFormat)
+		body.TimeZone = optionalString(req.TimeZone)
+		body.OmitNulls = optionalBool(req.OmitNulls)
+		body.IncludeNullMask = optionalBool(req.IncludeNullMask)
+	case *api.BatchOperation_Write:
+		req := operation.GetWrite()
+		if req.TxId != "" {
+			return fmt.Errorf("field validation for 'TxId' failed. Batches do not support transactions")
+		}
+		db, table, op = req.Db, req.Table, ds.PK_WRITE_OPERATION
+		if body.Filters, err = parseFilters(req.Filters); err != nil {
+			return err
+		}
+		if body.Values, err = parseValues(req.Values); err != nil {
+			return err
+		}
+		body.Mode = optionalString(req.Mode)
+		body.OperationID = optionalString(req.OperationId)
+	case *api.BatchOperation_Delete:
+		req := operation.GetDelete()
+		if req.TxId != "" {
+			return fmt.Errorf("field validation for 'TxId' failed. Batches do not support transactions")
+		}
+		db, table, op = req.Db, req.Table, ds.PK_DELETE_OPERATION
+		if body.Filters, err = parseFilters(req.Filters); err != nil {
+			return err
+		}
+		body.OperationID = optionalString(req.OperationId)
+	default:
+		return fmt.Errorf("field validation for 'Operation' failed. No operation is set")
+	}
+
+	method := http.MethodPost
+	relativeURL := db + "/" + table + "/" + op
+	subOp.Method = &method
+	subOp.RelativeURL = &relativeURL
+	subOp.Body = &body
+	return nil
+}
+
+func validateTable(db string, table string) error {
+	if err := pkread.ValidateDBIdentifier(db); err != nil {
+		return err
+	}
+	return pkread.ValidateDBIdentifier(table)
+}
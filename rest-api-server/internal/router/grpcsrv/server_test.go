@@ -0,0 +1,331 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package grpcsrv
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"hopsworks.ai/rdrs/internal/common"
+	tu "hopsworks.ai/rdrs/internal/router/handler/utils"
+	"hopsworks.ai/rdrs/pkg/api"
+)
+
+// withClient serves the gRPC API on an in memory listener
+func withClient(t *testing.T, fn func(client api.RDRSClient)) {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	Register(server)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect to the gRPC server. %v", err)
+	}
+	defer conn.Close()
+
+	fn(api.NewRDRSClient(conn))
+}
+
+func stringValue(s string) *api.Value {
+	return &api.Value{Kind: &api.Value_StringValue{StringValue: s}}
+}
+
+func intValue(i int64) *api.Value {
+	return &api.Value{Kind: &api.Value_IntValue{IntValue: i}}
+}
+
+func checkStatus(t *testing.T, err error, code codes.Code, msg string) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Fatalf("expecting status %v. Got: %v", code, err)
+	}
+	if !strings.Contains(status.Convert(err).Message(), msg) {
+		t.Fatalf("expecting the error to contain '%s'. Got: %v", msg, err)
+	}
+}
+
+func nullValue() *api.Value {
+	return &api.Value{Kind: &api.Value_NullValue{NullValue: true}}
+}
+
+func checkResponse(t *testing.T, resp *api.OperationResponse, err error, code uint32) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("operation failed. %v", err)
+	}
+	if resp.Code != code {
+		t.Fatalf("expecting code %d. Got: %d. Response: %v", code, resp.Code, resp)
+	}
+}
+
+// checkData compares the read columns of a response
+func checkData(t *testing.T, resp *api.OperationResponse, expected map[string]*api.Value) {
+	t.Helper()
+	if len(resp.Data) != len(expected) {
+		t.Fatalf("expecting %d columns. Got: %v", len(expected), resp)
+	}
+	for column, value := range expected {
+		if !proto.Equal(resp.Data[column], value) {
+			t.Fatalf("unexpected value of column '%s'. Expecting: %v. Got: %v", column, value, resp)
+		}
+	}
+}
+
+func TestTypedValue(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected *api.Value
+	}{
+		{nil, nullValue()},
+		{"str", stringValue("str")},
+		{int64(-1), intValue(-1)},
+		{uint64(1), intValue(1)},
+		{uint64(math.MaxUint64), &api.Value{Kind: &api.Value_UintValue{UintValue: math.MaxUint64}}},
+		{float32(1.5), &api.Value{Kind: &api.Value_DoubleValue{DoubleValue: 1.5}}},
+		{float64(2.5), &api.Value{Kind: &api.Value_DoubleValue{DoubleValue: 2.5}}},
+		{[]byte{0, 1}, &api.Value{Kind: &api.Value_BytesValue{BytesValue: []byte{0, 1}}}},
+		{[]interface{}{"a", "b"}, &api.Value{Kind: &api.Value_ListValue{ListValue: &api.ValueList{
+			Values: []*api.Value{stringValue("a"), stringValue("b")}}}}},
+	}
+	for _, test := range tests {
+		value, err := typedValue(test.value)
+		if err != nil {
+			t.Fatalf("failed to convert %v. %v", test.value, err)
+		}
+		if !proto.Equal(value, test.expected) {
+			t.Fatalf("unexpected value of %v. Expecting: %v. Got: %v", test.value, test.expected, value)
+		}
+	}
+
+	// Test. Other types are not returned by the data layer
+	if _, err := typedValue(map[string]interface{}{}); err == nil {
+		t.Fatalf("expecting maps to fail")
+	}
+}
+
+func TestGRPCValidation(t *testing.T) {
+	server := &RDRSServer{}
+	ctx := context.Background()
+	filters := []*api.Filter{{Column: "id0", Value: intValue(1)}}
+
+	// Test. Missing filters
+	_, err := server.PKRead(ctx, &api.PKReadRequest{Db: "db", Table: "table"})
+	checkStatus(t, err, codes.InvalidArgument, "Field validation for 'Filters' failed on the 'min' tag")
+
+	// Test. Invalid table names
+	_, err = server.PKRead(ctx, &api.PKReadRequest{Db: "", Table: "table", Filters: filters})
+	checkStatus(t, err, codes.InvalidArgument, "field length validation failed")
+
+	// Test. Filters without values and NULL primary keys
+	_, err = server.PKRead(ctx, &api.PKReadRequest{Db: "db", Table: "table",
+		Filters: []*api.Filter{{Column: "id0"}}})
+	checkStatus(t, err, codes.InvalidArgument, "no value is set")
+
+	_, err = server.PKRead(ctx, &api.PKReadRequest{Db: "db", Table: "table",
+		Filters: []*api.Filter{{Column: "id0", Value: nullValue()}}})
+	checkStatus(t, err, codes.InvalidArgument, "The value can not be null")
+
+	// Test. Lists are only returned
+	_, err = server.PKWrite(ctx, &api.PKWriteRequest{Db: "db", Table: "table", Filters: filters, Mode: "upsert",
+		Values: map[string]*api.Value{"col0": {Kind: &api.Value_ListValue{ListValue: &api.ValueList{}}}}})
+	checkStatus(t, err, codes.InvalidArgument, "lists are only used in responses")
+
+	// Test. The REST validations apply to the read options
+	_, err = server.PKRead(ctx, &api.PKReadRequest{Db: "db", Table: "table", Filters: filters,
+		LockMode: "none"})
+	checkStatus(t, err, codes.InvalidArgument, "Field validation for 'LockMode' failed on the 'oneof' tag")
+
+	_, err = server.PKRead(ctx, &api.PKReadRequest{Db: "db", Table: "table", Filters: filters,
+		ReadColumns: []*api.ReadColumn{{Column: "id0"}}})
+	checkStatus(t, err, codes.InvalidArgument, "'id0' already included in filter")
+
+	// Test. Writes require a mode
+	_, err = server.PKWrite(ctx, &api.PKWriteRequest{Db: "db", Table: "table", Filters: filters})
+	checkStatus(t, err, codes.InvalidArgument, "Field validation for 'Mode' failed on the 'required' tag")
+
+	// Test. Batches do not support transactions
+	_, err = server.Batch(ctx, &api.BatchRequest{Operations: []*api.BatchOperation{
+		{Operation: &api.BatchOperation_Read{Read: &api.PKReadRequest{Db: "db", Table: "table",
+			Filters: filters, TxId: "0123456789abcdef0123456789abcdef"}}}}})
+	checkStatus(t, err, codes.InvalidArgument, "Batches do not support transactions")
+
+	_, err = server.Batch(ctx, &api.BatchRequest{Operations: []*api.BatchOperation{{}}})
+	checkStatus(t, err, codes.InvalidArgument, "No operation is set")
+}
+
+func TestGRPCPKRead(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB001"), common.Database("DB004")},
+		[]tu.RegisterTestHandler{}, func(router *gin.Engine) {
+			withClient(t, func(client api.RDRSClient) {
+				ctx := context.Background()
+
+				// Test. Read a row
+				resp, err := client.PKRead(ctx, &api.PKReadRequest{
+					Db:          "DB001",
+					Table:       "table_1",
+					Filters:     []*api.Filter{{Column: "id0", Value: stringValue("id0_data")}},
+					ReadColumns: []*api.ReadColumn{{Column: "col_0"}, {Column: "col_1"}},
+					OperationId: "op1",
+				})
+				checkResponse(t, resp, err, http.StatusOK)
+				checkData(t, resp, map[string]*api.Value{
+					"col_0": stringValue("col_0_data"), "col_1": stringValue("col_1_data")})
+				if resp.OperationId != "op1" {
+					t.Fatalf("expecting the operation id. Got: %v", resp)
+				}
+
+				// Test. Signed and unsigned keys
+				resp, err = client.PKRead(ctx, &api.PKReadRequest{
+					Db:    "DB004",
+					Table: "int_table",
+					Filters: []*api.Filter{
+						{Column: "id0", Value: intValue(-2147483648)},
+						{Column: "id1", Value: &api.Value{Kind: &api.Value_UintValue{UintValue: 0}}},
+					},
+				})
+				checkResponse(t, resp, err, http.StatusOK)
+				if !proto.Equal(resp.Data["col0"], intValue(-2147483648)) {
+					t.Fatalf("unexpected row. Got: %v", resp)
+				}
+
+				// Test. A missing row is not an error
+				resp, err = client.PKRead(ctx, &api.PKReadRequest{
+					Db:      "DB001",
+					Table:   "table_1",
+					Filters: []*api.Filter{{Column: "id0", Value: stringValue("missing")}},
+				})
+				checkResponse(t, resp, err, http.StatusNotFound)
+				checkData(t, resp, nil)
+
+				// Test. Errors of the data layer
+				_, err = client.PKRead(ctx, &api.PKReadRequest{
+					Db:      "DB001",
+					Table:   "table_x",
+					Filters: []*api.Filter{{Column: "id0", Value: stringValue("id0_data")}},
+				})
+				checkStatus(t, err, codes.InvalidArgument, common.ERROR_011())
+			})
+		})
+}
+
+func TestGRPCWritesAndBatches(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")},
+		[]tu.RegisterTestHandler{}, func(router *gin.Engine) {
+			withClient(t, func(client api.RDRSClient) {
+				ctx := context.Background()
+				key := []*api.Filter{{Column: "id0", Value: intValue(2)}}
+
+				// Test. Insert a row. Inserting it again fails
+				write := &api.PKWriteRequest{
+					Db:      "DB025",
+					Table:   "table_1",
+					Filters: key,
+					Values: map[string]*api.Value{
+						"col_0": stringValue("grpc \"data\""),
+						"col_1": intValue(2),
+						"col_2": {Kind: &api.Value_DoubleValue{DoubleValue: 2.5}},
+					},
+					Mode: "insert",
+				}
+				resp, err := client.PKWrite(ctx, write)
+				checkResponse(t, resp, err, http.StatusOK)
+
+				_, err = client.PKWrite(ctx, write)
+				checkStatus(t, err, codes.AlreadyExists, "")
+
+				// Test. Set a column to NULL
+				resp, err = client.PKWrite(ctx, &api.PKWriteRequest{
+					Db:      "DB025",
+					Table:   "table_1",
+					Filters: key,
+					Values:  map[string]*api.Value{"col_2": nullValue()},
+					Mode:    "update",
+				})
+				checkResponse(t, resp, err, http.StatusOK)
+
+				// Test. Read the rows and delete the new row in a batch. The
+				// operations without an operation id also have a code
+				batch, err := client.Batch(ctx, &api.BatchRequest{
+					Atomic: true,
+					Operations: []*api.BatchOperation{
+						{Operation: &api.BatchOperation_Read{Read: &api.PKReadRequest{
+							Db: "DB025", Table: "table_1", Filters: key, OperationId: "read",
+							ReadColumns: []*api.ReadColumn{{Column: "col_0"}, {Column: "col_1"}, {Column: "col_2"}}}}},
+						{Operation: &api.BatchOperation_Read{Read: &api.PKReadRequest{
+							Db: "DB025", Table: "table_1",
+							Filters: []*api.Filter{{Column: "id0", Value: intValue(1)}}}}},
+						{Operation: &api.BatchOperation_Read{Read: &api.PKReadRequest{
+							Db: "DB025", Table: "table_1",
+							Filters: []*api.Filter{{Column: "id0", Value: intValue(100)}}}}},
+						{Operation: &api.BatchOperation_Delete{Delete: &api.PKDeleteRequest{
+							Db: "DB025", Table: "table_1", Filters: key, OperationId: "delete"}}},
+					},
+				})
+				if err != nil {
+					t.Fatalf("batch failed. %v", err)
+				}
+				if len(batch.Responses) != 4 {
+					t.Fatalf("expecting 4 responses. Got: %v", batch.Responses)
+				}
+				checkResponse(t, batch.Responses[0], nil, http.StatusOK)
+				checkData(t, batch.Responses[0], map[string]*api.Value{
+					"col_0": stringValue("grpc \"data\""), "col_1": intValue(2), "col_2": nullValue()})
+				if batch.Responses[0].OperationId != "read" {
+					t.Fatalf("expecting the operation id. Got: %v", batch.Responses[0])
+				}
+				checkResponse(t, batch.Responses[1], nil, http.StatusOK)
+				if !proto.Equal(batch.Responses[1].Data["col_0"], stringValue("col_0_data")) {
+					t.Fatalf("expecting the existing row. Got: %v", batch.Responses[1])
+				}
+				checkResponse(t, batch.Responses[2], nil, http.StatusNotFound)
+				checkResponse(t, batch.Responses[3], nil, http.StatusOK)
+
+				// Test. The row was deleted
+				resp, err = client.PKRead(ctx, &api.PKReadRequest{Db: "DB025", Table: "table_1", Filters: key})
+				checkResponse(t, resp, err, http.StatusNotFound)
+
+				// Test. Stats
+				stats, err := client.Stat(ctx, &api.StatRequest{})
+				if err != nil {
+					t.Fatalf("stat failed. %v", err)
+				}
+				if stats.NativeBufferStats.BuffersCount == 0 || stats.RonDbStats.NdbObjectsTotalCount == 0 {
+					t.Fatalf("unexpected stats. Got: %v", stats)
+				}
+			})
+		})
+}
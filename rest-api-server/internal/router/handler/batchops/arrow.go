@@ -0,0 +1,112 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package batchops
+
+import (
+	"fmt"
+	"net/http"
+
+	"hopsworks.ai/rdrs/internal/arrow"
+	"hopsworks.ai/rdrs/internal/common"
+)
+
+// The rows of an Arrow batch response are the operations of the batch.
+// The operationId and code columns are followed by the columns that the
+// read operations return, in the order in which they first appear. The
+// operations that do not return a column, e.g., writes, reads of other
+// tables and reads of missing rows, have a null value in the column
+
+const (
+	arrowOperationIDColumn = "operationId"
+	arrowCodeColumn        = "code"
+)
+
+// arrowOpResponse is the response of an operation as returned by the data
+// access layer. Read operations have the Arrow types of their columns
+type arrowOpResponse struct {
+	Code int64 `json:"code"`
+	Body struct {
+		OperationID *string                `json:"operationId"`
+		Schema      []arrow.Field          `json:"schema"`
+		Data        map[string]interface{} `json:"data"`
+	} `json:"body"`
+}
+
+// batchRows returns the columns and the rows of an Arrow batch response.
+// sameTypes requires a column to have the same type in all operations,
+// formats that do not store the types, e.g., CSV, do not need it
+func batchRows(responses []string, sameTypes bool) ([]arrow.Field, [][]interface{}, int, *common.ErrorResponse) {
+	fields := []arrow.Field{{Name: arrowOperationIDColumn, Type: "u"}, {Name: arrowCodeColumn, Type: "i"}}
+	types := map[string]string{arrowOperationIDColumn: "", arrowCodeColumn: ""}
+
+	ops := make([]arrowOpResponse, len(responses))
+	for i, resp := range responses {
+		if err := common.DecodeMsgPackResponse(resp, &ops[i]); err != nil {
+			return nil, nil, http.StatusInternalServerError,
+				&common.ErrorResponse{Error: fmt.Sprintf("Failed to read the response of operation %d. Error: %v", i, err)}
+		}
+
+		for _, field := range ops[i].Body.Schema {
+			fieldType, ok := types[field.Name]
+			switch {
+			case !ok:
+				types[field.Name] = field.Type
+				fields = append(fields, field)
+			case fieldType == "":
+				return nil, nil, http.StatusBadRequest,
+					&common.ErrorResponse{Error: fmt.Sprintf("Column '%s' can not be read in Arrow and CSV batches. The name is used by the response", field.Name)}
+			case sameTypes && fieldType != field.Type:
+				return nil, nil, http.StatusBadRequest,
+					&common.ErrorResponse{Error: fmt.Sprintf("Column '%s' has different types in the operations of the batch. Types: %s, %s", field.Name, fieldType, field.Type)}
+			}
+		}
+	}
+
+	rows := make([][]interface{}, len(ops))
+	for i, op := range ops {
+		rows[i] = make([]interface{}, len(fields))
+		if op.Body.OperationID != nil {
+			rows[i][0] = *op.Body.OperationID
+		}
+		rows[i][1] = op.Code
+		for j := 2; j < len(fields); j++ {
+			rows[i][j] = op.Body.Data[fields[j].Name]
+		}
+	}
+	return fields, rows, http.StatusOK, nil
+}
+
+// arrowBatchResponse converts the MessagePack responses of the operations
+// to an Arrow IPC stream with a single record batch
+func arrowBatchResponse(responses []string) (string, int, *common.ErrorResponse) {
+	fields, rows, code, errResp := batchRows(responses, true)
+	if errResp != nil {
+		return "", code, errResp
+	}
+
+	builder, err := arrow.NewRecordBuilder(fields)
+	if err != nil {
+		return "", http.StatusInternalServerError, &common.ErrorResponse{Error: fmt.Sprintf("%v", err)}
+	}
+	for _, row := range rows {
+		if err := builder.Append(row); err != nil {
+			return "", http.StatusInternalServerError, &common.ErrorResponse{Error: fmt.Sprintf("%v", err)}
+		}
+	}
+	return string(builder.Stream(nil)), http.StatusOK, nil
+}
@@ -17,6 +17,7 @@
 package batchops
 
 import (
+	"encoding/binary"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -24,11 +25,13 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"hopsworks.ai/rdrs/internal/common"
 	"hopsworks.ai/rdrs/internal/dal"
 	ds "hopsworks.ai/rdrs/internal/datastructs"
 	"hopsworks.ai/rdrs/internal/log"
 	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+	"hopsworks.ai/rdrs/internal/router/handler/pkwrite"
 	"hopsworks.ai/rdrs/version"
 )
 
@@ -53,9 +56,9 @@ func BatchOpsHandler(c *gin.Context) {
 		return
 	}
 
-	pkOperations := make([]ds.PKReadParams, len(*operations.Operations))
+	pkOperations := make([]SubOperation, len(*operations.Operations))
 	for i, operation := range *operations.Operations {
-		err := parseOperation(&operation, &pkOperations[i])
+		err := ParseOperation(&operation, &pkOperations[i])
 		if err != nil {
 			if log.IsDebug() {
 				log.Debugf("Error: %v", err)
@@ -65,49 +68,137 @@ func BatchOpsHandler(c *gin.Context) {
 		}
 	}
 
+	atomic := operations.Atomic != nil && *operations.Atomic
+	format := common.ResponseFormat(c)
+	if common.AcceptsArrow(c) {
+		format = ds.ARROW_MIME
+	} else if common.AcceptsCSV(c) {
+		format = ds.CSV_MIME
+	}
+	if format == "" && common.AcceptsNDJSON(c) {
+		streamBatch(c, pkOperations, atomic)
+		return
+	}
+
+	responses, dalErr, err := ExecuteBatch(pkOperations, atomic, format)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"OK": false, "msg": fmt.Sprintf("%v", err)})
+		return
+	}
+	if dalErr != nil {
+		common.SetResponseError(c, dalErr.HttpCode, common.ErrorResponse{Error: DalErrorMessage(dalErr)})
+		return
+	}
+
+	if format == ds.ARROW_MIME || format == ds.CSV_MIME {
+		convert := arrowBatchResponse
+		if format == ds.CSV_MIME {
+			convert = csvBatchResponse
+		}
+		body, code, errResp := convert(responses)
+		if errResp != nil {
+			common.SetResponseError(c, code, *errResp)
+			return
+		}
+		common.SetFormattedResponse(c, code, format, body)
+		return
+	}
+
+	common.SetFormattedResponse(c, http.StatusOK, format, batchResponse(format, responses))
+}
+
+// batchResponse wraps the responses of the operations in an array. Binary
+// arrays have a 32 bit length, like the maps of the responses
+func batchResponse(format string, responses []string) string {
+	var header []byte
+	switch format {
+	case ds.MSGPACK_MIME:
+		header = []byte{0xdd, 0, 0, 0, 0}
+	case ds.CBOR_MIME:
+		header = []byte{0x9a, 0, 0, 0, 0}
+	default:
+		return "[" + strings.Join(responses, ",") + "]"
+	}
+	binary.BigEndian.PutUint32(header[1:], uint32(len(responses)))
+	return string(header) + strings.Join(responses, "")
+}
+
+// streamBatch sends the response of every operation as a line of newline
+// delimited JSON. The batch is executed in a single transaction, the same
+// as when the response is not streamed, so the status is known before the
+// first line is sent
+func streamBatch(c *gin.Context, pkOperations []SubOperation, atomic bool) {
+	responses, dalErr, err := ExecuteBatch(pkOperations, atomic, "")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"OK": false, "msg": fmt.Sprintf("%v", err)})
+		return
+	}
+	if dalErr != nil {
+		common.SetResponseError(c, dalErr.HttpCode, common.ErrorResponse{Error: DalErrorMessage(dalErr)})
+		return
+	}
+
+	common.StartStreamedResponse(c, ds.NDJSON_MIME)
+	for _, response := range responses {
+		common.WriteStreamed(c, response+"\n")
+	}
+}
+
+// ExecuteBatch executes the operations in a single transaction and returns
+// their responses in the format, see common.ResponseFormat. The native
+// buffers are returned to the pool before the responses are sent to the
+// client
+func ExecuteBatch(pkOperations []SubOperation, atomic bool, format string) ([]string, *dal.DalError, error) {
 	noOps := uint32(len(pkOperations))
 	reqPtrs := make([]*dal.NativeBuffer, noOps)
 	respPtrs := make([]*dal.NativeBuffer, noOps)
 
+	var err error
 	for i, pkOp := range pkOperations {
-		reqPtrs[i], respPtrs[i], err = pkread.CreateNativeRequest(&pkOp)
+		if pkOp.write != nil {
+			pkOp.write.ResponseFormat = format
+			reqPtrs[i], respPtrs[i], err = pkwrite.CreateNativeRequest(pkOp.write)
+		} else {
+			pkOp.read.ResponseFormat = format
+			reqPtrs[i], respPtrs[i], err = pkread.CreateNativeRequest(pkOp.read)
+		}
 		defer dal.ReturnBuffer(reqPtrs[i])
 		defer dal.ReturnBuffer(respPtrs[i])
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"OK": false, "msg": fmt.Sprintf("%v", err)})
-			return
+			return nil, nil, err
 		}
 	}
 
-	dalErr := dal.RonDBBatchedPKRead(noOps, reqPtrs, respPtrs)
-
-	var message string
+	dalErr := dal.RonDBBatchedPKOperation(noOps, reqPtrs, respPtrs, atomic)
 	if dalErr != nil {
-		if dalErr.HttpCode >= http.StatusInternalServerError {
-			message = fmt.Sprintf("%v File: %v, Line: %v ", dalErr.Message, dalErr.ErrFileName, dalErr.ErrLineNo)
-		} else {
-			message = fmt.Sprintf("%v", dalErr.Message)
-		}
-		common.SetResponseError(c, dalErr.HttpCode, common.ErrorResponse{Error: message})
-	} else {
+		return nil, dalErr, nil
+	}
 
-		c.Writer.Write(([]byte)(string("[")))
-		for i := uint32(0); i < noOps; i++ {
-			setResponseBodyUnsafe(c, http.StatusOK, respPtrs[i], i != (noOps-1))
-		}
-		c.Writer.Write(([]byte)(string("]")))
+	responses := make([]string, noOps)
+	for i := range respPtrs {
+		responses[i] = common.ProcessFormattedResponse(respPtrs[i].Buffer, format)
 	}
+	return responses, nil, nil
 }
 
-func setResponseBodyUnsafe(c *gin.Context, code int, resp *dal.NativeBuffer, appendComma bool) {
-	c.Writer.WriteHeader(code)
-	c.Writer.Write(([]byte)(common.ProcessResponse(resp.Buffer)))
-	if appendComma {
-		c.Writer.Write(([]byte)(string(",")))
+// DalErrorMessage returns the error message of a failed operation. Server
+// errors include the location of the error in the data layer
+func DalErrorMessage(dalErr *dal.DalError) string {
+	if dalErr.HttpCode >= http.StatusInternalServerError {
+		return fmt.Sprintf("%v File: %v, Line: %v ", dalErr.Message, dalErr.ErrFileName, dalErr.ErrLineNo)
 	}
+	return fmt.Sprintf("%v", dalErr.Message)
 }
 
-func parseOperation(operation *ds.BatchSubOperation, pkReadarams *ds.PKReadParams) error {
+// SubOperation is either a read or a write operation of a batch
+type SubOperation struct {
+	read  *ds.PKReadParams
+	write *ds.PKWriteParams
+}
+
+// ParseOperation validates the operation and converts it to the parameters
+// of the native request. gRPC batches are converted to the same operations
+func ParseOperation(operation *ds.BatchSubOperation, subOp *SubOperation) error {
 
 	//remove leading / character
 	if strings.HasPrefix(*operation.RelativeURL, "/") {
@@ -115,21 +206,11 @@ func parseOperation(operation *ds.BatchSubOperation, pkReadarams *ds.PKReadParam
 		operation.RelativeURL = &trimmed
 	}
 
-	match, err := regexp.MatchString("^[a-zA-Z0-9$_]+/[a-zA-Z0-9$_]+/pk-read",
-		*operation.RelativeURL)
+	match, err := regexp.MatchString("^[a-zA-Z0-9$_]+/[a-zA-Z0-9$_]+/("+ds.PK_DB_OPERATION+"|"+
+		ds.UNIQUE_READ_OPERATION+"|"+ds.PK_WRITE_OPERATION+"|"+ds.PK_DELETE_OPERATION+")$", *operation.RelativeURL)
 	if !match || err != nil {
 		return fmt.Errorf("Invalid Relative URL: %s", *operation.RelativeURL)
-	} else {
-		err := parsePKRead(operation, pkReadarams)
-		if err != nil {
-			return err
-		}
 	}
-	return nil
-}
-
-func parsePKRead(operation *ds.BatchSubOperation, pkReadarams *ds.PKReadParams) error {
-	params := *operation.Body
 
 	//split the relative url to extract path parameters
 	splits := strings.Split(*operation.RelativeURL, "/")
@@ -137,15 +218,172 @@ func parsePKRead(operation *ds.BatchSubOperation, pkReadarams *ds.PKReadParams)
 		return fmt.Errorf("Failed to extract database and table information from relative url")
 	}
 
+	if *operation.Method == http.MethodDelete && splits[2] != ds.PK_DELETE_OPERATION {
+		return fmt.Errorf("Method %s is not supported for %s", *operation.Method, *operation.RelativeURL)
+	}
+
+	if operation.Body.Index != nil && splits[2] != ds.UNIQUE_READ_OPERATION {
+		return fmt.Errorf("field validation for 'Index' failed. Index is only supported by %s", ds.UNIQUE_READ_OPERATION)
+	}
+
+	switch splits[2] {
+	case ds.UNIQUE_READ_OPERATION:
+		subOp.read = &ds.PKReadParams{}
+		err = parseUniqueRead(operation, splits[0], splits[1], subOp.read)
+	case ds.PK_WRITE_OPERATION:
+		subOp.write = &ds.PKWriteParams{}
+		err = parsePKWrite(operation, splits[0], splits[1], subOp.write)
+	case ds.PK_DELETE_OPERATION:
+		subOp.write = &ds.PKWriteParams{}
+		err = parsePKDelete(operation, splits[0], splits[1], subOp.write)
+	default:
+		subOp.read = &ds.PKReadParams{}
+		err = parsePKRead(operation, splits[0], splits[1], subOp.read)
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func parsePKRead(operation *ds.BatchSubOperation, db string, table string, pkReadarams *ds.PKReadParams) error {
+	body := operation.Body
+	if body.Values != nil || body.Mode != nil {
+		return fmt.Errorf("field validation failed. 'values' and 'mode' are not supported by %s", ds.PK_DB_OPERATION)
+	}
+
+	params := ds.PKReadBody{
+		Filters:             body.Filters,
+		ReadColumns:         body.ReadColumns,
+		OperationID:         body.OperationID,
+		LockMode:            body.LockMode,
+		BigNumbersAsStrings: body.BigNumbersAsStrings,
+		TemporalFormat:      body.TemporalFormat,
+		TimeZone:            body.TimeZone,
+		OmitNulls:           body.OmitNulls,
+		IncludeNullMask:     body.IncludeNullMask,
+	}
 	err := pkread.ValidateBody(&params)
 	if err != nil {
 		return err
 	}
 
-	pkReadarams.DB = &splits[0]
-	pkReadarams.Table = &splits[1]
+	pkReadarams.DB = &db
+	pkReadarams.Table = &table
 	pkReadarams.Filters = params.Filters
 	pkReadarams.ReadColumns = params.ReadColumns
 	pkReadarams.OperationID = params.OperationID
+	pkReadarams.LockMode = params.LockMode
+	pkReadarams.BigNumbersAsStrings = params.BigNumbersAsStrings
+	pkReadarams.TemporalFormat = params.TemporalFormat
+	pkReadarams.TimeZone = params.TimeZone
+	pkReadarams.OmitNulls = params.OmitNulls
+	pkReadarams.IncludeNullMask = params.IncludeNullMask
+	return nil
+}
+
+func parseUniqueRead(operation *ds.BatchSubOperation, db string, table string, pkReadParams *ds.PKReadParams) error {
+	body := operation.Body
+	if body.Values != nil || body.Mode != nil {
+		return fmt.Errorf("field validation failed. 'values' and 'mode' are not supported by %s", ds.UNIQUE_READ_OPERATION)
+	}
+
+	params := ds.UniqueReadBody{
+		Index:               body.Index,
+		Filters:             body.Filters,
+		ReadColumns:         body.ReadColumns,
+		OperationID:         body.OperationID,
+		LockMode:            body.LockMode,
+		BigNumbersAsStrings: body.BigNumbersAsStrings,
+		TemporalFormat:      body.TemporalFormat,
+		TimeZone:            body.TimeZone,
+		OmitNulls:           body.OmitNulls,
+		IncludeNullMask:     body.IncludeNullMask,
+	}
+	// run the binding validations of the unique-read body, e.g., index is required
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return err
+	}
+
+	if err := pkread.ValidateUniqueReadBody(&params); err != nil {
+		return err
+	}
+
+	pkReadParams.DB = &db
+	pkReadParams.Table = &table
+	pkReadParams.Index = params.Index
+	pkReadParams.Filters = params.Filters
+	pkReadParams.ReadColumns = params.ReadColumns
+	pkReadParams.OperationID = params.OperationID
+	pkReadParams.LockMode = params.LockMode
+	pkReadParams.BigNumbersAsStrings = params.BigNumbersAsStrings
+	pkReadParams.TemporalFormat = params.TemporalFormat
+	pkReadParams.TimeZone = params.TimeZone
+	pkReadParams.OmitNulls = params.OmitNulls
+	pkReadParams.IncludeNullMask = params.IncludeNullMask
+	return nil
+}
+
+func parsePKWrite(operation *ds.BatchSubOperation, db string, table string, pkWriteParams *ds.PKWriteParams) error {
+	body := operation.Body
+	if body.ReadColumns != nil {
+		return fmt.Errorf("field validation for 'ReadColumns' failed. Read columns are not supported by %s", ds.PK_WRITE_OPERATION)
+	}
+	if body.LockMode != nil {
+		return fmt.Errorf("field validation for 'LockMode' failed. Lock mode is not supported by %s", ds.PK_WRITE_OPERATION)
+	}
+	if body.BigNumbersAsStrings != nil {
+		return fmt.Errorf("field validation for 'BigNumbersAsStrings' failed. It is not supported by %s", ds.PK_WRITE_OPERATION)
+	}
+	if body.TemporalFormat != nil || body.TimeZone != nil {
+		return fmt.Errorf("field validation failed. 'temporalFormat' and 'timeZone' are not supported by %s", ds.PK_WRITE_OPERATION)
+	}
+	if body.OmitNulls != nil || body.IncludeNullMask != nil {
+		return fmt.Errorf("field validation failed. 'omitNulls' and 'includeNullMask' are not supported by %s", ds.PK_WRITE_OPERATION)
+	}
+
+	params := ds.PKWriteBody{
+		Filters:     body.Filters,
+		Values:      body.Values,
+		Mode:        body.Mode,
+		OperationID: body.OperationID,
+	}
+	// run the binding validations of the pk-write body, e.g., mode is required
+	if err := binding.Validator.ValidateStruct(&params); err != nil {
+		return err
+	}
+
+	if err := pkwrite.ValidateBody(&params); err != nil {
+		return err
+	}
+
+	pkWriteParams.DB = &db
+	pkWriteParams.Table = &table
+	pkWriteParams.Filters = params.Filters
+	pkWriteParams.Values = params.Values
+	pkWriteParams.Mode = params.Mode
+	pkWriteParams.OperationID = params.OperationID
+	return nil
+}
+
+func parsePKDelete(operation *ds.BatchSubOperation, db string, table string, pkDeleteParams *ds.PKWriteParams) error {
+	body := operation.Body
+	if body.ReadColumns != nil || body.Values != nil || body.Mode != nil || body.LockMode != nil ||
+		body.BigNumbersAsStrings != nil || body.TemporalFormat != nil || body.TimeZone != nil ||
+		body.OmitNulls != nil || body.IncludeNullMask != nil {
+		return fmt.Errorf("field validation failed. 'readColumns', 'values', 'mode', 'lockMode', 'bigNumbersAsStrings', 'temporalFormat', 'timeZone', 'omitNulls' and 'includeNullMask' are not supported by %s", ds.PK_DELETE_OPERATION)
+	}
+
+	err := pkwrite.ValidateDeleteBody(&ds.PKDeleteBody{Filters: body.Filters, OperationID: body.OperationID})
+	if err != nil {
+		return err
+	}
+
+	mode := ds.PK_WRITE_MODE_DELETE
+	pkDeleteParams.DB = &db
+	pkDeleteParams.Table = &table
+	pkDeleteParams.Filters = body.Filters
+	pkDeleteParams.Mode = &mode
+	pkDeleteParams.OperationID = body.OperationID
 	return nil
 }
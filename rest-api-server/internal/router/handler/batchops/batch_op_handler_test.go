@@ -23,7 +23,9 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"hopsworks.ai/rdrs/internal/common"
 	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
 	tu "hopsworks.ai/rdrs/internal/router/handler/utils"
 )
 
@@ -37,7 +39,7 @@ func TestBatchSimple1(t *testing.T) {
 					SubOperation: ds.BatchSubOperation{
 						Method:      &[]string{ds.PK_HTTP_VERB}[0],
 						RelativeURL: &[]string{string("DB004/int_table/" + ds.PK_DB_OPERATION)}[0],
-						Body: &ds.PKReadBody{
+						Body: &ds.BatchSubOperationBody{
 							Filters:     tu.NewFiltersKVs("id0", 0, "id1", 0),
 							ReadColumns: tu.NewReadColumns("col", 2),
 							OperationID: tu.NewOperationID(64),
@@ -58,7 +60,7 @@ func TestBatchSimple1(t *testing.T) {
 					SubOperation: ds.BatchSubOperation{
 						Method:      &[]string{ds.PK_HTTP_VERB}[0],
 						RelativeURL: &[]string{string("DB004/int_table/" + ds.PK_DB_OPERATION)}[0],
-						Body: &ds.PKReadBody{
+						Body: &ds.BatchSubOperationBody{
 							Filters:     tu.NewFiltersKVs("id0", 0, "id1", 0),
 							ReadColumns: tu.NewReadColumns("col", 2),
 							OperationID: tu.NewOperationID(64),
@@ -74,7 +76,7 @@ func TestBatchSimple1(t *testing.T) {
 					SubOperation: ds.BatchSubOperation{
 						Method:      &[]string{ds.PK_HTTP_VERB}[0],
 						RelativeURL: &[]string{string("DB005/bigint_table/" + ds.PK_DB_OPERATION)}[0],
-						Body: &ds.PKReadBody{
+						Body: &ds.BatchSubOperationBody{
 							Filters:     tu.NewFiltersKVs("id0", 0, "id1", 0),
 							ReadColumns: tu.NewReadColumns("col", 2),
 							OperationID: tu.NewOperationID(64),
@@ -95,7 +97,7 @@ func TestBatchSimple1(t *testing.T) {
 					SubOperation: ds.BatchSubOperation{
 						Method:      &[]string{ds.PK_HTTP_VERB}[0],
 						RelativeURL: &[]string{string("DB004/int_table/" + ds.PK_DB_OPERATION)}[0],
-						Body: &ds.PKReadBody{
+						Body: &ds.BatchSubOperationBody{
 							Filters:     tu.NewFiltersKVs("id0", 0, "id1", 0),
 							ReadColumns: tu.NewReadColumns("col", 2),
 							OperationID: tu.NewOperationID(64),
@@ -111,7 +113,7 @@ func TestBatchSimple1(t *testing.T) {
 					SubOperation: ds.BatchSubOperation{
 						Method:      &[]string{ds.PK_HTTP_VERB}[0],
 						RelativeURL: &[]string{string("DB005/bigint_table/" + ds.PK_DB_OPERATION)}[0],
-						Body: &ds.PKReadBody{
+						Body: &ds.BatchSubOperationBody{
 							Filters:     tu.NewFiltersKVs("id0", 0, "id1", 0),
 							ReadColumns: tu.NewReadColumns("col", 2),
 							OperationID: tu.NewOperationID(64),
@@ -127,7 +129,7 @@ func TestBatchSimple1(t *testing.T) {
 					SubOperation: ds.BatchSubOperation{
 						Method:      &[]string{ds.PK_HTTP_VERB}[0],
 						RelativeURL: &[]string{string("DB006/tinyint_table/" + ds.PK_DB_OPERATION)}[0],
-						Body: &ds.PKReadBody{
+						Body: &ds.BatchSubOperationBody{
 							Filters:     tu.NewFiltersKVs("id0", -128, "id1", 0),
 							ReadColumns: tu.NewReadColumns("col", 2),
 							OperationID: tu.NewOperationID(64),
@@ -143,7 +145,7 @@ func TestBatchSimple1(t *testing.T) {
 					SubOperation: ds.BatchSubOperation{
 						Method:      &[]string{ds.PK_HTTP_VERB}[0],
 						RelativeURL: &[]string{string("DB007/smallint_table/" + ds.PK_DB_OPERATION)}[0],
-						Body: &ds.PKReadBody{
+						Body: &ds.BatchSubOperationBody{
 							Filters:     tu.NewFiltersKVs("id0", 32767, "id1", 65535),
 							ReadColumns: tu.NewReadColumns("col", 2),
 							OperationID: tu.NewOperationID(64),
@@ -159,7 +161,7 @@ func TestBatchSimple1(t *testing.T) {
 					SubOperation: ds.BatchSubOperation{
 						Method:      &[]string{ds.PK_HTTP_VERB}[0],
 						RelativeURL: &[]string{string("DB007/smallint_table/" + ds.PK_DB_OPERATION)}[0],
-						Body: &ds.PKReadBody{
+						Body: &ds.BatchSubOperationBody{
 							Filters:     tu.NewFiltersKVs("id0", 1, "id1", 1),
 							ReadColumns: tu.NewReadColumns("col", 2),
 							OperationID: tu.NewOperationID(64),
@@ -180,7 +182,7 @@ func TestBatchSimple1(t *testing.T) {
 					SubOperation: ds.BatchSubOperation{
 						Method:      &[]string{ds.PK_HTTP_VERB}[0],
 						RelativeURL: &[]string{string("DB004/int_table/" + ds.PK_DB_OPERATION)}[0],
-						Body: &ds.PKReadBody{
+						Body: &ds.BatchSubOperationBody{
 							Filters:     tu.NewFiltersKVs("id0", 100, "id1", 100),
 							ReadColumns: tu.NewReadColumns("col", 2),
 							OperationID: tu.NewOperationID(64),
@@ -196,7 +198,7 @@ func TestBatchSimple1(t *testing.T) {
 					SubOperation: ds.BatchSubOperation{
 						Method:      &[]string{ds.PK_HTTP_VERB}[0],
 						RelativeURL: &[]string{string("DB005/bigint_table/" + ds.PK_DB_OPERATION)}[0],
-						Body: &ds.PKReadBody{
+						Body: &ds.BatchSubOperationBody{
 							Filters:     tu.NewFiltersKVs("id0", 100, "id1", 100),
 							ReadColumns: tu.NewReadColumns("col", 2),
 							OperationID: tu.NewOperationID(64),
@@ -318,7 +320,7 @@ func createSubOperation(t *testing.T, table string, database string, pk string,
 		SubOperation: ds.BatchSubOperation{
 			Method:      &[]string{ds.PK_HTTP_VERB}[0],
 			RelativeURL: &[]string{string(database + "/" + table + "/" + ds.PK_DB_OPERATION)}[0],
-			Body: &ds.PKReadBody{
+			Body: &ds.BatchSubOperationBody{
 				Filters:     tu.NewFiltersKVs("id0", pk),
 				ReadColumns: tu.NewReadColumns("col", 1),
 				OperationID: tu.NewOperationID(5),
@@ -412,7 +414,7 @@ func arrayColumnBatchTestSubOp(t *testing.T, table string, database string, isBi
 		SubOperation: ds.BatchSubOperation{
 			Method:      &[]string{ds.PK_HTTP_VERB}[0],
 			RelativeURL: &[]string{string(database + "/" + table + "/" + ds.PK_DB_OPERATION)}[0],
-			Body: &ds.PKReadBody{
+			Body: &ds.BatchSubOperationBody{
 				Filters:     tu.NewFiltersKVs("id0", tu.Encode(pk, isBinary, colWidth, padding)),
 				ReadColumns: tu.NewReadColumns("col", 1),
 				OperationID: tu.NewOperationID(5),
@@ -481,7 +483,11 @@ func NewOperationTBD(t *testing.T) ds.BatchSubOperation {
 	op := ds.BatchSubOperation{
 		Method:      &method,
 		RelativeURL: &relativeURL,
-		Body:        &pkOp,
+		Body: &ds.BatchSubOperationBody{
+			Filters:     pkOp.Filters,
+			ReadColumns: pkOp.ReadColumns,
+			OperationID: pkOp.OperationID,
+		},
 	}
 
 	return op
@@ -490,3 +496,452 @@ func NewOperationTBD(t *testing.T) ds.BatchSubOperation {
 func BatchURL() string {
 	return fmt.Sprintf("%s%s", ds.DBS_OPS_EP_GROUP, ds.BATCH_OPERATION)
 }
+
+func TestBatchDelete(t *testing.T) {
+	tests := map[string]ds.BatchOperationTestInfo{
+		"delete": { // mixed batch of reads and deletes
+			HttpCode: http.StatusOK,
+			Operations: []ds.BatchSubOperationTestInfo{
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{ds.PK_HTTP_VERB}[0],
+						RelativeURL: &[]string{string("DB025/table_1/" + ds.PK_DELETE_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Filters:     tu.NewFiltersKVs("id0", 1),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:        "table_1",
+					DB:           "DB025",
+					HttpCode:     http.StatusOK,
+					BodyContains: "",
+				},
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{ds.PK_HTTP_VERB}[0],
+						RelativeURL: &[]string{string("DB025/table_1/" + ds.PK_DELETE_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Filters:     tu.NewFiltersKVs("id0", 100),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:        "table_1",
+					DB:           "DB025",
+					HttpCode:     http.StatusNotFound,
+					BodyContains: "",
+				},
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{ds.PK_HTTP_VERB}[0],
+						RelativeURL: &[]string{string("DB004/int_table/" + ds.PK_DB_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Filters:     tu.NewFiltersKVs("id0", 0, "id1", 0),
+							ReadColumns: tu.NewReadColumns("col", 2),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:        "int_table",
+					DB:           "DB004",
+					HttpCode:     http.StatusOK,
+					BodyContains: "",
+					RespKVs:      []interface{}{"col0", "col1"},
+				},
+			},
+		},
+		"deletewithreadcols": { // read columns are not allowed for deletes
+			HttpCode: http.StatusBadRequest,
+			Operations: []ds.BatchSubOperationTestInfo{
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{ds.PK_HTTP_VERB}[0],
+						RelativeURL: &[]string{string("DB025/table_1/" + ds.PK_DELETE_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Filters:     tu.NewFiltersKVs("id0", 1),
+							ReadColumns: tu.NewReadColumns("col_", 1),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:    "table_1",
+					DB:       "DB025",
+					HttpCode: http.StatusBadRequest,
+				},
+			},
+		},
+	}
+
+	tu.BatchTest(t, tests, false, RegisterBatchTestHandler)
+}
+
+func TestBatchUniqueRead(t *testing.T) {
+	tests := map[string]ds.BatchOperationTestInfo{
+		"uniqueread": { // unique reads and primary key reads in the same batch
+			HttpCode: http.StatusOK,
+			Operations: []ds.BatchSubOperationTestInfo{
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{ds.PK_HTTP_VERB}[0],
+						RelativeURL: &[]string{string("DB026/users/" + ds.UNIQUE_READ_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Index:       &[]string{"email_idx"}[0],
+							Filters:     tu.NewFiltersKVs("email", "alice@example.com"),
+							ReadColumns: tu.NewReadColumn("name"),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:        "users",
+					DB:           "DB026",
+					HttpCode:     http.StatusOK,
+					BodyContains: "",
+					RespKVs:      []interface{}{"name"},
+				},
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{ds.PK_HTTP_VERB}[0],
+						RelativeURL: &[]string{string("DB026/users/" + ds.UNIQUE_READ_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Index:       &[]string{"email_idx"}[0],
+							Filters:     tu.NewFiltersKVs("email", "carol@example.com"),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:        "users",
+					DB:           "DB026",
+					HttpCode:     http.StatusNotFound,
+					BodyContains: "",
+				},
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{ds.PK_HTTP_VERB}[0],
+						RelativeURL: &[]string{string("DB026/users/" + ds.PK_DB_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Filters:     tu.NewFiltersKVs("id0", 2),
+							ReadColumns: tu.NewReadColumn("email"),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:        "users",
+					DB:           "DB026",
+					HttpCode:     http.StatusOK,
+					BodyContains: "",
+					RespKVs:      []interface{}{"email"},
+				},
+			},
+		},
+		"missingindex": { // unique reads require the index
+			HttpCode: http.StatusBadRequest,
+			Operations: []ds.BatchSubOperationTestInfo{
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{ds.PK_HTTP_VERB}[0],
+						RelativeURL: &[]string{string("DB026/users/" + ds.UNIQUE_READ_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Filters:     tu.NewFiltersKVs("email", "alice@example.com"),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:    "users",
+					DB:       "DB026",
+					HttpCode: http.StatusBadRequest,
+				},
+			},
+		},
+		"indexwithpkread": { // index is not allowed for primary key reads
+			HttpCode: http.StatusBadRequest,
+			Operations: []ds.BatchSubOperationTestInfo{
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{ds.PK_HTTP_VERB}[0],
+						RelativeURL: &[]string{string("DB026/users/" + ds.PK_DB_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Index:       &[]string{"email_idx"}[0],
+							Filters:     tu.NewFiltersKVs("id0", 1),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:    "users",
+					DB:       "DB026",
+					HttpCode: http.StatusBadRequest,
+				},
+			},
+		},
+	}
+
+	tu.BatchTest(t, tests, false, RegisterBatchTestHandler)
+}
+
+func TestBatchReadWrite(t *testing.T) {
+	tests := map[string]ds.BatchOperationTestInfo{
+		"mixed": { // failed write operations do not affect other operations
+			HttpCode: http.StatusOK,
+			Operations: []ds.BatchSubOperationTestInfo{
+				writeSubOperation(ds.PK_WRITE_MODE_INSERT, 2, http.StatusOK),
+				writeSubOperation(ds.PK_WRITE_MODE_INSERT, 1, http.StatusConflict),
+				writeSubOperation(ds.PK_WRITE_MODE_UPDATE, 100, http.StatusNotFound),
+				writeSubOperation(ds.PK_WRITE_MODE_UPSERT, 3, http.StatusOK),
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{http.MethodDelete}[0],
+						RelativeURL: &[]string{string("DB025/table_1/" + ds.PK_DELETE_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Filters:     tu.NewFiltersKVs("id0", 1),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:    "table_1",
+					DB:       "DB025",
+					HttpCode: http.StatusOK,
+				},
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{ds.PK_HTTP_VERB}[0],
+						RelativeURL: &[]string{string("DB004/int_table/" + ds.PK_DB_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Filters:     tu.NewFiltersKVs("id0", 0, "id1", 0),
+							ReadColumns: tu.NewReadColumns("col", 2),
+							OperationID: tu.NewOperationID(64),
+						},
+					},
+					Table:    "int_table",
+					DB:       "DB004",
+					HttpCode: http.StatusOK,
+					RespKVs:  []interface{}{"col0", "col1"},
+				},
+			},
+		},
+		"atomic": { // all operations succeed
+			HttpCode: http.StatusOK,
+			Atomic:   true,
+			Operations: []ds.BatchSubOperationTestInfo{
+				writeSubOperation(ds.PK_WRITE_MODE_INSERT, 2, http.StatusOK),
+				writeSubOperation(ds.PK_WRITE_MODE_UPDATE, 1, http.StatusOK),
+			},
+		},
+		"atomicfailure": { // duplicate key aborts the whole batch
+			HttpCode:     http.StatusConflict,
+			BodyContains: common.ERROR_033(),
+			Atomic:       true,
+			Operations: []ds.BatchSubOperationTestInfo{
+				writeSubOperation(ds.PK_WRITE_MODE_INSERT, 2, http.StatusOK),
+				writeSubOperation(ds.PK_WRITE_MODE_INSERT, 1, http.StatusConflict),
+			},
+		},
+		"wrongmethod": { // DELETE is only supported for pk-delete
+			HttpCode: http.StatusBadRequest,
+			Operations: []ds.BatchSubOperationTestInfo{
+				ds.BatchSubOperationTestInfo{
+					SubOperation: ds.BatchSubOperation{
+						Method:      &[]string{http.MethodDelete}[0],
+						RelativeURL: &[]string{string("DB025/table_1/" + ds.PK_DB_OPERATION)}[0],
+						Body: &ds.BatchSubOperationBody{
+							Filters: tu.NewFiltersKVs("id0", 1),
+						},
+					},
+					Table:    "table_1",
+					DB:       "DB025",
+					HttpCode: http.StatusBadRequest,
+				},
+			},
+		},
+	}
+
+	tu.BatchTest(t, tests, false, RegisterBatchTestHandler)
+}
+
+func TestBatchAtomicRollback(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")},
+		[]tu.RegisterTestHandler{RegisterBatchTestHandler, pkread.RegisterPKTestHandler},
+		func(router *gin.Engine) {
+			atomic := true
+			subOps := []ds.BatchSubOperation{
+				writeSubOperation(ds.PK_WRITE_MODE_INSERT, 2, http.StatusOK).SubOperation,
+				writeSubOperation(ds.PK_WRITE_MODE_UPDATE, 100, http.StatusNotFound).SubOperation,
+			}
+			batch := ds.BatchOperation{Operations: &subOps, Atomic: &atomic}
+			body, _ := json.MarshalIndent(batch, "", "\t")
+			tu.ProcessRequest(t, router, ds.BATCH_HTTP_VERB, tu.NewBatchReadURL(), string(body),
+				http.StatusNotFound, common.ERROR_033())
+
+			// the inserted row must not exist
+			readBody, _ := json.MarshalIndent(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 2)}, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB025", "table_1"),
+				string(readBody), http.StatusNotFound, "")
+		})
+}
+
+func writeSubOperation(mode string, pk int, expectedStatus int) ds.BatchSubOperationTestInfo {
+	return ds.BatchSubOperationTestInfo{
+		SubOperation: ds.BatchSubOperation{
+			Method:      &[]string{ds.PK_WRITE_HTTP_VERB}[0],
+			RelativeURL: &[]string{string("DB025/table_1/" + ds.PK_WRITE_OPERATION)}[0],
+			Body: &ds.BatchSubOperationBody{
+				Filters:     tu.NewFiltersKVs("id0", pk),
+				Values:      tu.NewValuesKVs("col_0", fmt.Sprintf("%s_%d", mode, pk)),
+				Mode:        &mode,
+				OperationID: tu.NewOperationID(64),
+			},
+		},
+		Table:    "table_1",
+		DB:       "DB025",
+		HttpCode: expectedStatus,
+	}
+}
+
+func TestBatchNDJSON(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterBatchTestHandler},
+		func(router *gin.Engine) {
+			numOps := 300
+			subOps := make([]ds.BatchSubOperation, numOps)
+			for i := range subOps {
+				subOps[i] = ds.BatchSubOperation{
+					Method:      &[]string{ds.PK_HTTP_VERB}[0],
+					RelativeURL: &[]string{string("DB026/users/" + ds.PK_DB_OPERATION)}[0],
+					Body: &ds.BatchSubOperationBody{
+						Filters:     tu.NewFiltersKVs("id0", i%3+1),
+						ReadColumns: tu.NewReadColumn("name"),
+						OperationID: &[]string{fmt.Sprintf("%d", i)}[0],
+					},
+				}
+			}
+
+			// Test. One line per operation in the order of the operations
+			body, _ := json.MarshalIndent(ds.BatchOperation{Operations: &subOps}, "", "\t")
+			_, lines := tu.ProcessNDJSONRequest(t, router, ds.BATCH_HTTP_VERB, tu.NewBatchReadURL(), string(body),
+				http.StatusOK, "")
+			if len(lines) != numOps {
+				t.Fatalf("Test failed. Expected %d lines, got %d", numOps, len(lines))
+			}
+			for i, line := range lines {
+				var res struct {
+					Code int
+					Body struct {
+						OperationId string
+					}
+				}
+				if err := json.Unmarshal([]byte(line), &res); err != nil {
+					t.Fatalf("Test failed. Unable to parse line. Error: %v. Line: %s", err, line)
+				}
+
+				expectedCode := http.StatusOK
+				if i%3 == 2 { // there is no user with id 3
+					expectedCode = http.StatusNotFound
+				}
+				if res.Code != expectedCode || res.Body.OperationId != fmt.Sprintf("%d", i) {
+					t.Fatalf("Test failed. Unexpected response of operation %d. Line: %s", i, line)
+				}
+			}
+
+			// Test. Atomic batches fail before any line is sent
+			atomic := true
+			body, _ = json.MarshalIndent(ds.BatchOperation{Operations: &subOps, Atomic: &atomic}, "", "\t")
+			tu.ProcessNDJSONRequest(t, router, ds.BATCH_HTTP_VERB, tu.NewBatchReadURL(), string(body),
+				http.StatusNotFound, common.ERROR_033())
+		})
+}
+
+func TestBatchBinaryFormats(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterBatchTestHandler},
+		func(router *gin.Engine) {
+			subOps := make([]ds.BatchSubOperation, 3)
+			for i := range subOps {
+				subOps[i] = ds.BatchSubOperation{
+					Method:      &[]string{ds.PK_HTTP_VERB}[0],
+					RelativeURL: &[]string{string("DB026/users/" + ds.PK_DB_OPERATION)}[0],
+					Body: &ds.BatchSubOperationBody{
+						Filters:     tu.NewFiltersKVs("id0", i+1),
+						ReadColumns: tu.NewReadColumn("name"),
+						OperationID: &[]string{fmt.Sprintf("%d", i)}[0],
+					},
+				}
+			}
+			body, _ := json.Marshal(ds.BatchOperation{Operations: &subOps})
+
+			// Test. The responses of the operations are in an array. There
+			// is no user with id 3
+			for _, format := range []string{ds.MSGPACK_MIME, ds.CBOR_MIME} {
+				res := tu.ProcessBinaryRequest(t, router, ds.BATCH_HTTP_VERB, tu.NewBatchReadURL(), string(body),
+					format, http.StatusOK).([]interface{})
+				if len(res) != len(subOps) {
+					t.Fatalf("Test failed. Expected %d responses, got %d", len(subOps), len(res))
+				}
+
+				expected := []struct {
+					code string
+					name interface{}
+				}{{"200", "alice"}, {"200", "bob"}, {"404", nil}}
+				for i, r := range res {
+					op := r.(map[string]interface{})
+					opBody := op["body"].(map[string]interface{})
+					var name interface{}
+					if data, ok := opBody["data"].(map[string]interface{}); ok {
+						name = data["name"]
+					}
+					if fmt.Sprint(op["code"]) != expected[i].code || opBody["operationId"] != fmt.Sprintf("%d", i) ||
+						name != expected[i].name {
+						t.Fatalf("Test failed. Unexpected response of operation %d: %v", i, op)
+					}
+				}
+			}
+		})
+}
+
+func TestBatchArrow(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterBatchTestHandler},
+		func(router *gin.Engine) {
+			read := func(table string, id int, column string, opID *string) ds.BatchSubOperation {
+				return ds.BatchSubOperation{
+					Method:      &[]string{ds.PK_HTTP_VERB}[0],
+					RelativeURL: &[]string{"DB026/" + table + "/" + ds.PK_DB_OPERATION}[0],
+					Body: &ds.BatchSubOperationBody{
+						Filters:     tu.NewFiltersKVs("id0", id),
+						ReadColumns: tu.NewReadColumn(column),
+						OperationID: opID,
+					},
+				}
+			}
+			subOps := []ds.BatchSubOperation{
+				read("users", 1, "name", &[]string{"0"}[0]),
+				read("users", 3, "name", &[]string{"1"}[0]),
+				read("table_1", 1, "col_2", nil),
+			}
+			body, _ := json.Marshal(ds.BatchOperation{Operations: &subOps})
+
+			// Test. There is a row per operation. The columns that an operation
+			// does not read are null, as are the columns of the missing user
+			tu.ProcessArrowRequest(t, router, ds.BATCH_HTTP_VERB, tu.NewBatchReadURL(), string(body),
+				http.StatusOK, nil, []tu.ArrowColumn{
+					{Field: "operationId: utf8", Values: `["0" "1" (null)]`},
+					{Field: "code: int32", Values: `[200 404 200]`},
+					{Field: "name: utf8", Values: `["alice" (null) (null)]`},
+					{Field: "col_2: float64", Values: `[(null) (null) 1.5]`},
+				})
+		})
+}
+
+func TestBatchCSV(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterBatchTestHandler},
+		func(router *gin.Engine) {
+			subOps := make([]ds.BatchSubOperation, 3)
+			for i := range subOps {
+				subOps[i] = ds.BatchSubOperation{
+					Method:      &[]string{ds.PK_HTTP_VERB}[0],
+					RelativeURL: &[]string{string("DB026/users/" + ds.PK_DB_OPERATION)}[0],
+					Body: &ds.BatchSubOperationBody{
+						Filters:     tu.NewFiltersKVs("id0", i+1),
+						ReadColumns: &[]ds.ReadColumn{{Column: &[]string{"name"}[0]}, {Column: &[]string{"email"}[0]}},
+						OperationID: &[]string{fmt.Sprintf("op,%d", i)}[0],
+					},
+				}
+			}
+			body, _ := json.Marshal(ds.BatchOperation{Operations: &subOps})
+
+			// Test. A record per operation. There is no user with id 3
+			res := tu.ProcessCSVRequest(t, router, ds.BATCH_HTTP_VERB, tu.NewBatchReadURL(), string(body), http.StatusOK)
+			expected := "operationId,code,name,email\r\n" +
+				"\"op,0\",200,alice,alice@example.com\r\n" +
+				"\"op,1\",200,bob,bob@example.com\r\n" +
+				"\"op,2\",404,,\r\n"
+			if res != expected {
+				t.Fatalf("Test failed. Expected: %q, Got: %q", expected, res)
+			}
+		})
+}
@@ -0,0 +1,49 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package batchops
+
+import (
+	"fmt"
+	"net/http"
+
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/csv"
+)
+
+// csvBatchResponse converts the responses of the operations to comma
+// separated values. The records are the rows of the Arrow response, so
+// a column can have different types in different operations
+func csvBatchResponse(responses []string) (string, int, *common.ErrorResponse) {
+	fields, rows, code, errResp := batchRows(responses, false)
+	if errResp != nil {
+		return "", code, errResp
+	}
+
+	w := csv.Writer{}
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	w.WriteHeader(names)
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return "", http.StatusInternalServerError, &common.ErrorResponse{Error: fmt.Sprintf("%v", err)}
+		}
+	}
+	return w.String(), http.StatusOK, nil
+}
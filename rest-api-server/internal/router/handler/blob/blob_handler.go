@@ -0,0 +1,179 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package blob
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/log"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+)
+
+func RegisterBlobTestHandler(e *gin.Engine) {
+	group := e.Group(ds.DB_OPS_EP_GROUP)
+	group.GET(ds.BLOB_EP, BlobHandler)
+}
+
+// BlobHandler sends the raw bytes of a BLOB/TEXT column. Range requests
+// are supported, so large values can be downloaded in parts
+func BlobHandler(c *gin.Context) {
+	blobParams := ds.BlobReadParams{}
+
+	err := parseRequest(c, &blobParams)
+	if err != nil {
+		if log.IsDebug() {
+			log.Debugf("Unable to parse request. Error: %v. URL: %s\n", err, c.Request.URL)
+		}
+		common.SetResponseError(c, http.StatusBadRequest, common.ErrorResponse{Error: fmt.Sprintf("%-v", err)})
+		return
+	}
+
+	performBlobRead(c, &blobParams)
+}
+
+// max number of bytes read per chunk. If it is 0 then a chunk is as large
+// as the response buffer
+var maxChunkSize uint32 = 0
+
+func performBlobRead(c *gin.Context, blobParams *ds.BlobReadParams) {
+	request, response, err := CreateNativeRequest(blobParams)
+	if err != nil {
+		common.SetResponseError(c, http.StatusInternalServerError, common.ErrorResponse{Error: fmt.Sprintf("%v", err)})
+		return
+	}
+	defer dal.ReturnBuffer(request)
+	defer dal.ReturnBuffer(response)
+
+	// the first chunk is read before anything is sent, so that errors
+	// can still be returned with the right status code
+	reader := blobReader{request: request, response: chunkBuffer(response, maxChunkSize)}
+	reader.offset = rangeStart(c.GetHeader("Range"))
+	defer reader.close()
+	if dalErr := reader.fill(reader.offset); dalErr != nil {
+		var message string
+		if dalErr.HttpCode >= http.StatusInternalServerError {
+			message = fmt.Sprintf("%v File: %v, Line: %v ", dalErr.Message, dalErr.ErrFileName, dalErr.ErrLineNo)
+		} else {
+			message = fmt.Sprintf("%v", dalErr.Message)
+		}
+		common.SetResponseError(c, dalErr.HttpCode, common.ErrorResponse{Error: message})
+		return
+	}
+
+	if reader.chunk.isNull {
+		common.SetResponseError(c, http.StatusNotFound, common.ErrorResponse{Error: "The BLOB/TEXT value is NULL."})
+		return
+	}
+
+	contentType := "application/octet-stream"
+	if reader.chunk.isText {
+		contentType = "text/plain"
+	}
+	c.Header("Content-Type", contentType)
+
+	// ServeContent sets the Content-Length and handles the Range header
+	http.ServeContent(c.Writer, c.Request, "", time.Time{}, &reader)
+}
+
+// rangeStart returns the start of the first range in the Range header, so
+// that the first chunk read is also the first one sent. It is only a hint.
+// The header is validated by http.ServeContent
+func rangeStart(header string) int64 {
+	spec := strings.TrimPrefix(header, "bytes=")
+	end := strings.IndexAny(spec, "-,")
+	if spec == header || end <= 0 {
+		return 0
+	}
+
+	start, err := strconv.ParseUint(strings.TrimSpace(spec[:end]), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int64(start)
+}
+
+func parseRequest(c *gin.Context, blobParams *ds.BlobReadParams) error {
+	pp := ds.BlobReadPP{}
+	if err := c.ShouldBindUri(&pp); err != nil {
+		return err
+	}
+
+	for _, identifier := range []string{*pp.DB, *pp.Table} {
+		if err := pkread.ValidateDBIdentifier(identifier); err != nil {
+			return err
+		}
+	}
+
+	query := ds.BlobReadQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return err
+	}
+
+	filters, err := parseKey(*query.Key)
+	if err != nil {
+		return err
+	}
+
+	// same checks as for the filters and read columns of pk reads
+	body := ds.PKReadBody{Filters: filters, ReadColumns: &[]ds.ReadColumn{{Column: pp.Column}}}
+	if err := pkread.ValidateBody(&body); err != nil {
+		return err
+	}
+
+	blobParams.DB = pp.DB
+	blobParams.Table = pp.Table
+	blobParams.Column = pp.Column
+	blobParams.Filters = filters
+	return nil
+}
+
+// parseKey converts the key, a JSON object of the primary key columns
+// and their values, to primary key filters
+func parseKey(key string) (*[]ds.Filter, error) {
+	values := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(key), &values); err != nil {
+		return nil, fmt.Errorf("invalid '%s' parameter. Expecting a JSON object of the primary key columns. Error: %v",
+			ds.BLOB_KEY_PARAM_NAME, err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("invalid '%s' parameter. No primary key columns", ds.BLOB_KEY_PARAM_NAME)
+	}
+
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	filters := make([]ds.Filter, 0, len(columns))
+	for i := range columns {
+		value := values[columns[i]]
+		filters = append(filters, ds.Filter{Column: &columns[i], Value: &value})
+	}
+	return &filters, nil
+}
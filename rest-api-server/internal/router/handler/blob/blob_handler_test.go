@@ -0,0 +1,178 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package blob
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+	"hopsworks.ai/rdrs/internal/common"
+	tu "hopsworks.ai/rdrs/internal/router/handler/utils"
+)
+
+func TestBlobValidation(t *testing.T) {
+	router, err := tu.InitRouter(t, []tu.RegisterTestHandler{RegisterBlobTestHandler})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tests := map[string]struct {
+		url string
+		msg string
+	}{
+		"missing key":    {strings.Split(tu.NewBlobURL("db", "table", "col", ""), "?")[0], "Error:Field validation for 'Key'"},
+		"invalid key":    {tu.NewBlobURL("db", "table", "col", `[1, 2]`), "invalid 'key' parameter"},
+		"empty key":      {tu.NewBlobURL("db", "table", "col", `{}`), "No primary key columns"},
+		"column in key":  {tu.NewBlobURL("db", "table", "col", `{"col": 1}`), "already included in filter"},
+		"invalid column": {tu.NewBlobURL("db", "table", "col`", `{"id0": 1}`), "Invalid character"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tu.ProcessBlobRequest(t, router, test.url, "", http.StatusBadRequest, test.msg)
+		})
+	}
+}
+
+func TestBlobRead(t *testing.T) {
+	// the data column of the model artifact
+	artifact := bytes.Repeat([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, 1000)
+	model := `{"id0": 1, "name": "model"}`
+	empty := `{"id0": 2, "name": "empty"}`
+
+	tu.WithDBs(t, [][][]string{common.Database("DB027")}, []tu.RegisterTestHandler{RegisterBlobTestHandler},
+		func(router *gin.Engine) {
+			// Test. Whole value
+			resp := tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", model), "",
+				http.StatusOK, "")
+			checkBody(t, resp.Body.Bytes(), artifact)
+			checkHeader(t, resp.Header(), "Content-Type", "application/octet-stream")
+			checkHeader(t, resp.Header(), "Content-Length", strconv.Itoa(len(artifact)))
+			checkHeader(t, resp.Header(), "Accept-Ranges", "bytes")
+
+			// Test. Ranges
+			resp = tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", model),
+				"bytes=100-199", http.StatusPartialContent, "")
+			checkBody(t, resp.Body.Bytes(), artifact[100:200])
+			checkHeader(t, resp.Header(), "Content-Range", "bytes 100-199/16000")
+			checkHeader(t, resp.Header(), "Content-Length", "100")
+
+			resp = tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", model),
+				"bytes=15990-", http.StatusPartialContent, "")
+			checkBody(t, resp.Body.Bytes(), artifact[15990:])
+
+			resp = tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", model),
+				"bytes=-16", http.StatusPartialContent, "")
+			checkBody(t, resp.Body.Bytes(), artifact[len(artifact)-16:])
+
+			resp = tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", model),
+				"bytes=16000-", http.StatusRequestedRangeNotSatisfiable, "")
+			checkHeader(t, resp.Header(), "Content-Range", "bytes */16000")
+
+			// Test. TEXT column
+			resp = tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "notes", model), "",
+				http.StatusOK, "")
+			checkBody(t, resp.Body.Bytes(), []byte("trained on DB026"))
+			checkHeader(t, resp.Header(), "Content-Type", "text/plain")
+
+			// Test. Empty and NULL values
+			resp = tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", empty), "",
+				http.StatusOK, "")
+			checkBody(t, resp.Body.Bytes(), []byte{})
+			tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "notes", empty), "",
+				http.StatusNotFound, "NULL")
+
+			// Test. Row not found
+			tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", `{"id0": 3, "name": "model"}`), "",
+				http.StatusNotFound, "")
+
+			// Test. Invalid columns and keys
+			tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "version", model), "",
+				http.StatusBadRequest, common.ERROR_049())
+			tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "col", model), "",
+				http.StatusBadRequest, common.ERROR_012())
+			tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", `{"id0": 1}`), "",
+				http.StatusBadRequest, common.ERROR_013())
+			tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", `{"id0": 1, "id1": "model"}`), "",
+				http.StatusBadRequest, common.ERROR_014())
+			tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "missing", "data", model), "",
+				http.StatusBadRequest, common.ERROR_011())
+		})
+}
+
+// TestBlobReadChunks reads the value in many small chunks
+func TestBlobReadChunks(t *testing.T) {
+	artifact := bytes.Repeat([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, 1000)
+	model := `{"id0": 1, "name": "model"}`
+
+	maxChunkSize = 1000
+	defer func() { maxChunkSize = 0 }()
+
+	tu.WithDBs(t, [][][]string{common.Database("DB027")}, []tu.RegisterTestHandler{RegisterBlobTestHandler},
+		func(router *gin.Engine) {
+			resp := tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", model), "",
+				http.StatusOK, "")
+			checkBody(t, resp.Body.Bytes(), artifact)
+
+			// the range starts and ends in the middle of a chunk
+			resp = tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", model),
+				"bytes=1500-4499", http.StatusPartialContent, "")
+			checkBody(t, resp.Body.Bytes(), artifact[1500:4500])
+
+			resp = tu.ProcessBlobRequest(t, router, tu.NewBlobURL("DB027", "artifacts", "data", model),
+				"bytes=-2500", http.StatusPartialContent, "")
+			checkBody(t, resp.Body.Bytes(), artifact[len(artifact)-2500:])
+		})
+}
+
+func checkBody(t *testing.T, body []byte, expected []byte) {
+	t.Helper()
+	if !bytes.Equal(body, expected) {
+		t.Fatalf("Test failed. Expected %d bytes, Got %d bytes. The data does not match", len(expected), len(body))
+	}
+}
+
+func checkHeader(t *testing.T, header http.Header, name string, expected string) {
+	t.Helper()
+	if got := header.Get(name); got != expected {
+		t.Fatalf("Test failed. Expected %s: %s, Got: %s", name, expected, got)
+	}
+}
+
+func TestRangeStart(t *testing.T) {
+	tests := map[string]int64{
+		"":                   0,
+		"bytes=100-199":      100,
+		"bytes=100-":         100,
+		"bytes=-100":         0,
+		"bytes=5-9, 1-2":     5,
+		"items=100-199":      0,
+		"bytes=abc-":         0,
+		"bytes=99999999999-": 0,
+	}
+	for header, expected := range tests {
+		if got := rangeStart(header); got != expected {
+			t.Fatalf("Test failed. Header: %q Expected: %d, Got: %d", header, expected, got)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"hopsworks.ai/rdrs/internal/dal"
+)
+
+// blobReader reads a BLOB/TEXT value in chunks as large as the response
+// buffer. All the chunks are read in one transaction that share locks the
+// row, so the value does not change while it is read. The request and
+// response buffers are reused for all the chunks
+type blobReader struct {
+	request  *dal.NativeBuffer
+	response *dal.NativeBuffer
+	reader   *dal.BlobReader // nil until the first chunk is read
+
+	chunk       blobChunk // last chunk read
+	chunkOffset int64     // position of the last chunk in the value
+	offset      int64     // position of the next Read
+}
+
+// fill reads the chunk starting at the given position. The first call
+// locks the row and also returns whether the value is NULL and the length
+// of the value
+func (r *blobReader) fill(offset int64) *dal.DalError {
+	setOffset(r.request, uint32(offset))
+	if r.reader == nil {
+		reader, dalErr := dal.RonDBBlobOpen(r.request, r.response)
+		if dalErr != nil {
+			return dalErr
+		}
+		r.reader = reader
+	} else if dalErr := r.reader.Read(r.request, r.response); dalErr != nil {
+		return dalErr
+	}
+
+	r.chunk = parseResponse(r.response)
+	r.chunkOffset = offset
+	return nil
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	length := int64(r.chunk.length)
+	if r.offset >= length {
+		return 0, io.EOF
+	}
+
+	if r.offset < r.chunkOffset || r.offset >= r.chunkOffset+int64(len(r.chunk.data)) {
+		if dalErr := r.fill(r.offset); dalErr != nil {
+			return 0, fmt.Errorf("%v", dalErr.Message)
+		}
+		// the offset is before the end of the value, so the chunk can not
+		// be empty
+		if len(r.chunk.data) == 0 {
+			return 0, errors.New("failed to read the BLOB/TEXT value. No data was returned")
+		}
+	}
+
+	n := copy(p, r.chunk.data[r.offset-r.chunkOffset:])
+	r.offset += int64(n)
+	return n, nil
+}
+
+// close releases the lock on the row
+func (r *blobReader) close() {
+	if r.reader != nil {
+		r.reader.Close()
+		r.reader = nil
+	}
+}
+
+func (r *blobReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += int64(r.chunk.length)
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	r.offset = offset
+	return offset, nil
+}
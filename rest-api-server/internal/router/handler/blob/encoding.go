@@ -0,0 +1,109 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package blob
+
+/*
+#include "./../../../../../data-access-rondb/src/rdrs-const.h"
+#include "./../../../../../data-access-rondb/src/rdrs-dal.h"
+*/
+import "C"
+import (
+	"unsafe"
+
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+)
+
+//  BLOB READ Request
+//  =================
+//
+//  The request uses the PK read request header and layout, see
+//  internal/router/handler/pkread/encoding.go. The only read column is
+//  the BLOB/TEXT column. The Blob Offset field is the position in the
+//  value where the read starts. It is updated for every chunk and the
+//  rest of the request is reused
+//
+//  [ PK read header ... ][   4B   ]
+//                          Blob
+//                          Offset
+//
+//  BLOB READ Response
+//  ==================
+//
+//  [   4B   ][   4B   ][   4B   ][   4B   ][ bytes ... ]
+//     Null     Length    Bytes     Text      Data
+//
+//  Length is the length of the whole value and Bytes is the number of
+//  bytes of the value copied to the response, starting at the offset.
+//  Text is 1 for TEXT columns
+
+func CreateNativeRequest(params *ds.BlobReadParams) (*dal.NativeBuffer, *dal.NativeBuffer, error) {
+	readColumns := []ds.ReadColumn{{Column: params.Column}}
+	pkrParams := ds.PKReadParams{
+		DB:          params.DB,
+		Table:       params.Table,
+		Filters:     params.Filters,
+		ReadColumns: &readColumns,
+	}
+
+	request, response, err := pkread.CreateNativeRequest(&pkrParams)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iBuf := unsafe.Slice((*uint32)(request.Buffer), request.Size)
+	iBuf[C.PKR_OP_TYPE_IDX] = uint32(C.RDRS_BLOB_READ_REQ_ID)
+	iBuf[C.PKR_BLOB_OFFSET_IDX] = 0
+	return request, response, nil
+}
+
+func setOffset(request *dal.NativeBuffer, offset uint32) {
+	iBuf := unsafe.Slice((*uint32)(request.Buffer), request.Size)
+	iBuf[C.PKR_BLOB_OFFSET_IDX] = offset
+}
+
+// chunkBuffer returns the part of the response buffer that can hold a
+// chunk of the given size. The whole buffer is used if the size is 0
+func chunkBuffer(response *dal.NativeBuffer, size uint32) *dal.NativeBuffer {
+	if size == 0 || C.BLOB_RESP_HEADER_END+size >= response.Size {
+		return response
+	}
+	return &dal.NativeBuffer{Buffer: response.Buffer, Size: C.BLOB_RESP_HEADER_END + size}
+}
+
+// blobChunk is a decoded blob read response. The data points to the
+// response buffer and is only valid until the buffer is reused
+type blobChunk struct {
+	isNull bool
+	isText bool
+	length uint32
+	data   []byte
+}
+
+func parseResponse(response *dal.NativeBuffer) blobChunk {
+	iBuf := unsafe.Slice((*uint32)(response.Buffer), C.BLOB_RESP_HEADER_END/C.ADDRESS_SIZE)
+	data := unsafe.Slice((*byte)(unsafe.Add(response.Buffer, C.BLOB_RESP_HEADER_END)),
+		iBuf[C.BLOB_RESP_BYTES_IDX])
+	return blobChunk{
+		isNull: iBuf[C.BLOB_RESP_NULL_IDX] == 1,
+		isText: iBuf[C.BLOB_RESP_TEXT_IDX] == 1,
+		length: iBuf[C.BLOB_RESP_LENGTH_IDX],
+		data:   data,
+	}
+}
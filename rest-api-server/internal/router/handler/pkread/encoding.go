@@ -26,11 +26,13 @@ import "C"
 import (
 	"fmt"
 	"math"
+	"time"
 	"unsafe"
 
 	"hopsworks.ai/rdrs/internal/common"
 	"hopsworks.ai/rdrs/internal/dal"
 	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/mysql"
 )
 
 // Also checkout internal/router/handler/pkread/encoding-scheme.png
@@ -40,9 +42,34 @@ import (
 //
 //  HEADER
 //  ======
-//  [   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ] ....
-//    Type     Capacity  Length     DB         Table      PK     Read Cols    Op_ID    TX_ID
-//                               Offset      Offset    Offset     Offset     Offset   Offset
+//  [   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ]
+//    Type     Capacity  Length     DB         Table      PK     Read Cols    Op_ID    TX_ID    Values    Lock
+//                               Offset      Offset    Offset     Offset     Offset    Offset   Offset    Mode
+//
+//  Values offset is only used by the write operations, see
+//  internal/router/handler/pkwrite/encoding.go. Lock mode is 0 for the
+//  default committed read. Unique reads store the offset of the null
+//  terminated index name in the Index slot of the header, see the scan
+//  request in internal/router/handler/scan/encoding.go. It is 0 for
+//  primary key reads.
+//
+//  TX_ID is the offset of the null terminated ID of the explicit
+//  transaction. It is 0 if the request is not part of an explicit
+//  transaction
+//
+//  The response flags, RDRS_RF_* in rdrs-const.h, are stored in the
+//  RESP_FLAGS slot of the header, after the scan fields. They control how
+//  the read columns are rendered, e.g., 64 bit integers as JSON strings.
+//  The TIME_FORMAT slot stores the format of temporal values, RDRS_TF_*,
+//  and the TIME_ZONE slot the offset of the time zone from UTC in seconds
+//
+//  The COL_TYPES slot stores the offset of the ENUM, SET and JSON columns
+//  of the table. These types are only known to the MySQL server, see
+//  internal/mysql. It is 0 if the table has no such columns
+//
+//  The RESP_FORMAT slot stores the format of the response, RDRS_FORMAT_*.
+//  Write requests use it too, as they return a response in batches
+//
 //  BODY
 //  ====
 //  [ bytes ... ]
@@ -62,14 +89,33 @@ import (
 //  [   4B   ] [  4B     ] [  4B     ] ...
 //    Count   col1 offset   col2 offset
 //
-//  [  4B ] [   bytes ... ] [  4B ] [   bytes ... ] ...
-//  type     null terminated column names
+//  [  4B ] [  4B ] [   bytes ... ] [  4B ] [  4B ] [   bytes ... ] ...
+//  type     trim    null terminated column names
 //
 //  [ bytes ... ] ...
 //    null terminated  operation Id
 //
+//  [ bytes ... ]
+//    null terminated  transaction Id
+//
+//  [   4B   ] [  4B     ] [  4B     ] ...
+//    Count     ct1 offset  ct2 offset
+//
+//  [  4B ] [    4B     ] [   bytes ... ] [   bytes ... ] ...
+//   type    label count    column name      labels, all null terminated
+//
 
 func CreateNativeRequest(pkrParams *ds.PKReadParams) (*dal.NativeBuffer, *dal.NativeBuffer, error) {
+	lockMode, err := readLockMode(pkrParams.LockMode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tzOffset, err := TimeZoneOffset(pkrParams.TimeZone)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	response := dal.GetBuffer()
 	request := dal.GetBuffer()
 	iBuf := unsafe.Slice((*uint32)(request.Buffer), request.Size)
@@ -79,7 +125,7 @@ func CreateNativeRequest(pkrParams *ds.PKReadParams) (*dal.NativeBuffer, *dal.Na
 
 	dbOffSet := head
 
-	head, err := common.CopyGoStrToCStr([]byte(*pkrParams.DB), request, head)
+	head, err = common.CopyGoStrToCStr([]byte(*pkrParams.DB), request, head)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -93,27 +139,119 @@ func CreateNativeRequest(pkrParams *ds.PKReadParams) (*dal.NativeBuffer, *dal.Na
 	// PK Filters
 	head = common.AlignWord(head)
 	pkOffset := head
-	iBuf[head/C.ADDRESS_SIZE] = uint32(len(*pkrParams.Filters))
+	head, err = EncodePKFilters(pkrParams.Filters, request, head)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Read Columns
+	head = common.AlignWord(head)
+	var readColsOffset uint32 = 0
+	if pkrParams.ReadColumns != nil {
+		readColsOffset = head
+		head, err = EncodeReadColumns(pkrParams.ReadColumns, request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Unique index
+	var indexOffset uint32 = 0
+	if pkrParams.Index != nil {
+		indexOffset = head
+		head, err = common.CopyGoStrToCStr([]byte(*pkrParams.Index), request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Operation ID
+	var opIdOffset uint32 = 0
+	if pkrParams.OperationID != nil {
+		opIdOffset = head
+		head, err = common.CopyGoStrToCStr([]byte(*pkrParams.OperationID), request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Transaction ID
+	var txIdOffset uint32 = 0
+	if pkrParams.TxID != nil {
+		txIdOffset = head
+		head, err = common.CopyGoStrToCStr([]byte(*pkrParams.TxID), request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Column types
+	head = common.AlignWord(head)
+	var colTypesOffset uint32 = 0
+	colTypes, err := mysql.ColumnTypes(*pkrParams.DB, *pkrParams.Table)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(colTypes) > 0 {
+		colTypesOffset = head
+		head, err = EncodeColumnTypes(colTypes, request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// request buffer header
+	iBuf[C.PKR_OP_TYPE_IDX] = uint32(C.RDRS_PK_REQ_ID)
+	iBuf[C.PKR_CAPACITY_IDX] = uint32(request.Size)
+	iBuf[C.PKR_LENGTH_IDX] = uint32(head)
+	iBuf[C.PKR_DB_IDX] = uint32(dbOffSet)
+	iBuf[C.PKR_TABLE_IDX] = uint32(tableOffSet)
+	iBuf[C.PKR_PK_COLS_IDX] = uint32(pkOffset)
+	iBuf[C.PKR_READ_COLS_IDX] = uint32(readColsOffset)
+	iBuf[C.PKR_OP_ID_IDX] = uint32(opIdOffset)
+	iBuf[C.PKR_TX_ID_IDX] = uint32(txIdOffset)
+	iBuf[C.PKR_VALUES_IDX] = 0
+	iBuf[C.PKR_LOCK_MODE_IDX] = lockMode
+	iBuf[C.PKR_INDEX_IDX] = indexOffset
+	iBuf[C.PKR_RESP_FLAGS_IDX] = ResponseFlags(pkrParams.BigNumbersAsStrings, pkrParams.OmitNulls,
+		pkrParams.IncludeNullMask)
+	iBuf[C.PKR_TIME_FORMAT_IDX] = TemporalFormat(pkrParams.TemporalFormat)
+	iBuf[C.PKR_TIME_ZONE_IDX] = uint32(tzOffset)
+	iBuf[C.PKR_COL_TYPES_IDX] = colTypesOffset
+	iBuf[C.PKR_RESP_FORMAT_IDX] = ResponseFormat(pkrParams.ResponseFormat)
+
+	//xxd.Print(0, bBuf[:])
+	return request, response, nil
+}
+
+// EncodePKFilters writes the primary key filters at the given word aligned
+// location in the request buffer. The same layout is used for the key of
+// the read, write and delete operations
+func EncodePKFilters(filters *[]ds.Filter, request *dal.NativeBuffer, head uint32) (uint32, error) {
+	iBuf := unsafe.Slice((*uint32)(request.Buffer), request.Size)
+
+	iBuf[head/C.ADDRESS_SIZE] = uint32(len(*filters))
 	head += C.ADDRESS_SIZE
 
 	kvi := head / C.ADDRESS_SIZE // index for storing offsets for each key/value pair
 	// skip for N number of offsets one for each key/value pair
-	head = head + (uint32(len(*pkrParams.Filters)) * C.ADDRESS_SIZE)
-	for _, filter := range *pkrParams.Filters {
+	head = head + (uint32(len(*filters)) * C.ADDRESS_SIZE)
+	for _, filter := range *filters {
 		head = common.AlignWord(head)
 
 		tupleOffset := head
 
 		head = head + 8 //  for key and value offsets
 		keyOffset := head
+		var err error
 		head, err = common.CopyGoStrToCStr([]byte(*filter.Column), request, head)
 		if err != nil {
-			return nil, nil, err
+			return 0, err
 		}
 		valueOffset := head
 		head, err = common.CopyGoStrToNDBStr(*filter.Value, request, head)
 		if err != nil {
-			return nil, nil, err
+			return 0, err
 		}
 
 		iBuf[kvi] = tupleOffset
@@ -121,67 +259,100 @@ func CreateNativeRequest(pkrParams *ds.PKReadParams) (*dal.NativeBuffer, *dal.Na
 		iBuf[tupleOffset/C.ADDRESS_SIZE] = keyOffset
 		iBuf[(tupleOffset/C.ADDRESS_SIZE)+1] = valueOffset
 	}
+	return head, nil
+}
 
-	// Read Columns
-	head = common.AlignWord(head)
-	var readColsOffset uint32 = 0
-	if pkrParams.ReadColumns != nil {
-		readColsOffset = head
-		iBuf[head/C.ADDRESS_SIZE] = uint32(len(*pkrParams.ReadColumns))
-		head += C.ADDRESS_SIZE
-
-		rci := head / C.ADDRESS_SIZE // index for storing ofsets for each read column
-		// skip for N number of offsets one for each column name
-		head = head + (uint32(len(*pkrParams.ReadColumns)) * C.ADDRESS_SIZE)
+// EncodeReadColumns writes the read columns at the given word aligned
+// location in the request buffer
+func EncodeReadColumns(cols *[]ds.ReadColumn, request *dal.NativeBuffer, head uint32) (uint32, error) {
+	iBuf := unsafe.Slice((*uint32)(request.Buffer), request.Size)
+	var err error
 
-		for _, col := range *pkrParams.ReadColumns {
-			head = common.AlignWord(head)
+	iBuf[head/C.ADDRESS_SIZE] = uint32(len(*cols))
+	head += C.ADDRESS_SIZE
 
-			iBuf[rci] = head
-			rci++
+	rci := head / C.ADDRESS_SIZE // index for storing ofsets for each read column
+	// skip for N number of offsets one for each column name
+	head = head + (uint32(len(*cols)) * C.ADDRESS_SIZE)
 
-			// return type
-			var drt uint32 = C.DEFAULT_DRT
-			if col.DataReturnType != nil {
-				drt, err = dataReturnType(col.DataReturnType)
-				if err != nil {
-					return nil, nil, err
-				}
-			}
+	for _, col := range *cols {
+		head = common.AlignWord(head)
 
-			iBuf[head/C.ADDRESS_SIZE] = drt
-			head += C.ADDRESS_SIZE
+		iBuf[rci] = head
+		rci++
 
-			// col name
-			head, err = common.CopyGoStrToCStr([]byte(*col.Column), request, head)
+		// return type
+		var drt uint32 = C.DEFAULT_DRT
+		if col.DataReturnType != nil {
+			drt, err = dataReturnType(col.DataReturnType)
 			if err != nil {
-				return nil, nil, err
+				return 0, err
 			}
 		}
-	}
 
-	// Operation ID
-	var opIdOffset uint32 = 0
-	if pkrParams.OperationID != nil {
-		opIdOffset = head
-		head, err = common.CopyGoStrToCStr([]byte(*pkrParams.OperationID), request, head)
+		iBuf[head/C.ADDRESS_SIZE] = drt
+		head += C.ADDRESS_SIZE
+
+		// padding
+		iBuf[head/C.ADDRESS_SIZE] = trimPadding(col.TrimPadding)
+		head += C.ADDRESS_SIZE
+
+		// col name
+		head, err = common.CopyGoStrToCStr([]byte(*col.Column), request, head)
 		if err != nil {
-			return nil, nil, err
+			return 0, err
 		}
 	}
+	return head, nil
+}
 
-	// request buffer header
-	iBuf[C.PKR_OP_TYPE_IDX] = uint32(C.RDRS_PK_REQ_ID)
-	iBuf[C.PKR_CAPACITY_IDX] = uint32(request.Size)
-	iBuf[C.PKR_LENGTH_IDX] = uint32(head)
-	iBuf[C.PKR_DB_IDX] = uint32(dbOffSet)
-	iBuf[C.PKR_TABLE_IDX] = uint32(tableOffSet)
-	iBuf[C.PKR_PK_COLS_IDX] = uint32(pkOffset)
-	iBuf[C.PKR_READ_COLS_IDX] = uint32(readColsOffset)
-	iBuf[C.PKR_OP_ID_IDX] = uint32(opIdOffset)
+// EncodeColumnTypes writes the MySQL types of the columns at the given
+// word aligned location in the request buffer
+func EncodeColumnTypes(colTypes []ds.ColumnType, request *dal.NativeBuffer, head uint32) (uint32, error) {
+	iBuf := unsafe.Slice((*uint32)(request.Buffer), request.Size)
+	var err error
 
-	//xxd.Print(0, bBuf[:])
-	return request, response, nil
+	iBuf[head/C.ADDRESS_SIZE] = uint32(len(colTypes))
+	head += C.ADDRESS_SIZE
+
+	cti := head / C.ADDRESS_SIZE // index for storing offsets for each column type
+	// skip for N number of offsets one for each column type
+	head = head + (uint32(len(colTypes)) * C.ADDRESS_SIZE)
+
+	for _, colType := range colTypes {
+		head = common.AlignWord(head)
+
+		iBuf[cti] = head
+		cti++
+
+		var ct uint32
+		switch colType.Type {
+		case ds.COLUMN_TYPE_ENUM:
+			ct = C.RDRS_CT_ENUM
+		case ds.COLUMN_TYPE_SET:
+			ct = C.RDRS_CT_SET
+		case ds.COLUMN_TYPE_JSON:
+			ct = C.RDRS_CT_JSON
+		default:
+			return 0, fmt.Errorf("unsupported column type %s", colType.Type)
+		}
+
+		iBuf[head/C.ADDRESS_SIZE] = ct
+		iBuf[(head/C.ADDRESS_SIZE)+1] = uint32(len(colType.Labels))
+		head += 2 * C.ADDRESS_SIZE
+
+		head, err = common.CopyGoStrToCStr([]byte(colType.Column), request, head)
+		if err != nil {
+			return 0, err
+		}
+		for _, label := range colType.Labels {
+			head, err = common.CopyGoStrToCStr([]byte(label), request, head)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	return head, nil
 }
 
 func processResponse(buffer unsafe.Pointer) string {
@@ -189,9 +360,110 @@ func processResponse(buffer unsafe.Pointer) string {
 }
 
 func dataReturnType(drt *string) (uint32, error) {
-	if *drt == ds.DRT_DEFAULT {
+	switch *drt {
+	case ds.DRT_DEFAULT:
 		return C.DEFAULT_DRT, nil
-	} else {
+	case ds.DRT_BASE64:
+		return C.BASE64_DRT, nil
+	case ds.DRT_HEX:
+		return C.HEX_DRT, nil
+	case ds.DRT_STRING:
+		return C.STRING_DRT, nil
+	default:
 		return math.MaxUint32, fmt.Errorf("Return data type is not supported. Data type: " + *drt)
 	}
 }
+
+// ResponseFlags returns the value stored in the RESP_FLAGS slot of the
+// request header
+func ResponseFlags(bigNumbersAsStrings *bool, omitNulls *bool, includeNullMask *bool) uint32 {
+	var flags uint32 = 0
+	if bigNumbersAsStrings != nil && *bigNumbersAsStrings {
+		flags |= C.RDRS_RF_BIG_NUMBERS_AS_STRINGS
+	}
+	if omitNulls != nil && *omitNulls {
+		flags |= C.RDRS_RF_OMIT_NULLS
+	}
+	if includeNullMask != nil && *includeNullMask {
+		flags |= C.RDRS_RF_INCLUDE_NULL_MASK
+	}
+	return flags
+}
+
+// ResponseFormat returns the value stored in the RESP_FORMAT slot of the
+// request header
+func ResponseFormat(mimeType string) uint32 {
+	switch mimeType {
+	case ds.MSGPACK_MIME:
+		return C.RDRS_FORMAT_MSGPACK
+	case ds.CBOR_MIME:
+		return C.RDRS_FORMAT_CBOR
+	case ds.ARROW_MIME, ds.CSV_MIME:
+		// CSV responses are converted from Arrow responses, which have
+		// the names of the read columns in order
+		return C.RDRS_FORMAT_ARROW
+	default:
+		return C.RDRS_FORMAT_JSON
+	}
+}
+
+// trimPadding returns whether the padding of a read column is trimmed.
+// RDRS_TRIM_DEFAULT uses the server default
+func trimPadding(trim *bool) uint32 {
+	if trim == nil {
+		return C.RDRS_TRIM_DEFAULT
+	}
+	if *trim {
+		return C.RDRS_TRIM_PADDING
+	}
+	return C.RDRS_KEEP_PADDING
+}
+
+// TemporalFormat returns the value stored in the TIME_FORMAT slot of the
+// request header. The format is validated by the binding
+func TemporalFormat(tf *string) uint32 {
+	if tf == nil {
+		return C.RDRS_TF_MYSQL
+	}
+
+	switch *tf {
+	case ds.TF_RFC3339:
+		return C.RDRS_TF_RFC3339
+	case ds.TF_EPOCH_MILLIS:
+		return C.RDRS_TF_EPOCH_MILLIS
+	default:
+		return C.RDRS_TF_MYSQL
+	}
+}
+
+// TimeZoneOffset returns the offset of the time zone from UTC in seconds.
+// The zone is "Z", "UTC" or an offset such as "+05:30". UTC is the default
+func TimeZoneOffset(tz *string) (int32, error) {
+	if tz == nil || *tz == "Z" || *tz == "UTC" {
+		return 0, nil
+	}
+
+	zone, err := time.Parse("-07:00", *tz)
+	if err != nil {
+		return 0, fmt.Errorf("time zone is not supported. Expecting 'Z', 'UTC' or an offset such as '+05:30'. Time zone: %s", *tz)
+	}
+	_, offset := zone.Zone()
+	return int32(offset), nil
+}
+
+func readLockMode(lm *string) (uint32, error) {
+	if lm == nil {
+		return 0, nil
+	}
+
+	switch *lm {
+	case ds.LOCK_MODE_COMMITTED:
+		return C.RDRS_LM_COMMITTED, nil
+	case ds.LOCK_MODE_SHARED:
+		return C.RDRS_LM_SHARED, nil
+	case ds.LOCK_MODE_EXCLUSIVE:
+		return C.RDRS_LM_EXCLUSIVE, nil
+	default:
+		return 0, fmt.Errorf("Lock mode is not supported. Lock mode: %s", *lm)
+	}
+}
@@ -50,7 +50,14 @@ func PkReadHandler(c *gin.Context) {
 		return
 	}
 
-	request, response, err := CreateNativeRequest(&pkReadParams)
+	performPKRead(c, &pkReadParams)
+}
+
+// performPKRead reads the row and writes the response. It is used by
+// both primary key and unique index reads
+func performPKRead(c *gin.Context, pkReadParams *ds.PKReadParams) {
+	pkReadParams.ResponseFormat = common.ResponseFormat(c)
+	request, response, err := CreateNativeRequest(pkReadParams)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"OK": false, "msg": fmt.Sprintf("%v", err)})
 		return
@@ -63,8 +70,9 @@ func PkReadHandler(c *gin.Context) {
 	var message string
 	if dalErr != nil {
 
-		if dalErr.HttpCode == http.StatusNotFound {
-			setResponseBodyUnsafe(c, http.StatusNotFound, response)
+		// the response buffer is not set if the transaction was not found
+		if dalErr.HttpCode == http.StatusNotFound && !dalErr.TxNotFound {
+			setResponseBodyUnsafe(c, http.StatusNotFound, pkReadParams.ResponseFormat, response)
 		} else {
 			if dalErr.HttpCode >= http.StatusInternalServerError {
 				message = fmt.Sprintf("%v File: %v, Line: %v ", dalErr.Message, dalErr.ErrFileName, dalErr.ErrLineNo)
@@ -75,13 +83,12 @@ func PkReadHandler(c *gin.Context) {
 		}
 
 	} else {
-		setResponseBodyUnsafe(c, http.StatusOK, response)
+		setResponseBodyUnsafe(c, http.StatusOK, pkReadParams.ResponseFormat, response)
 	}
 }
 
-func setResponseBodyUnsafe(c *gin.Context, code int, resp *dal.NativeBuffer) {
-	c.Writer.WriteHeader(code)
-	c.Writer.Write(([]byte)(common.ProcessResponse(resp.Buffer)))
+func setResponseBodyUnsafe(c *gin.Context, code int, format string, resp *dal.NativeBuffer) {
+	common.SetFormattedResponse(c, code, format, common.ProcessFormattedResponse(resp.Buffer, format))
 }
 
 func parseRequest(c *gin.Context, pkReadParams *ds.PKReadParams) error {
@@ -89,7 +96,7 @@ func parseRequest(c *gin.Context, pkReadParams *ds.PKReadParams) error {
 	body := ds.PKReadBody{}
 	pp := ds.PKReadPP{}
 
-	if err := parseURI(c, &pp); err != nil {
+	if err := ParseURI(c, &pp); err != nil {
 		return err
 	}
 
@@ -102,6 +109,13 @@ func parseRequest(c *gin.Context, pkReadParams *ds.PKReadParams) error {
 	pkReadParams.Filters = body.Filters
 	pkReadParams.ReadColumns = body.ReadColumns
 	pkReadParams.OperationID = body.OperationID
+	pkReadParams.TxID = body.TxID
+	pkReadParams.LockMode = body.LockMode
+	pkReadParams.BigNumbersAsStrings = body.BigNumbersAsStrings
+	pkReadParams.TemporalFormat = body.TemporalFormat
+	pkReadParams.TimeZone = body.TimeZone
+	pkReadParams.OmitNulls = body.OmitNulls
+	pkReadParams.IncludeNullMask = body.IncludeNullMask
 	return nil
 }
 
@@ -125,7 +139,7 @@ func ValidateBody(params *ds.PKReadBody) error {
 
 	for _, filter := range *params.Filters {
 		// make sure filter columns are valid
-		if err := validateDBIdentifier(*filter.Column); err != nil {
+		if err := ValidateDBIdentifier(*filter.Column); err != nil {
 			return err
 		}
 	}
@@ -142,8 +156,12 @@ func ValidateBody(params *ds.PKReadBody) error {
 
 	// make sure read columns are valid
 	if params.ReadColumns != nil {
-		for _, col := range *params.ReadColumns {
-			if err := validateDBIdentifier(*col.Column); err != nil {
+		for i := range *params.ReadColumns {
+			col := &(*params.ReadColumns)[i]
+			if err := ValidateDBIdentifier(*col.Column); err != nil {
+				return err
+			}
+			if err := ValidateDataReturnType(col); err != nil {
 				return err
 			}
 		}
@@ -166,27 +184,38 @@ func ValidateBody(params *ds.PKReadBody) error {
 		}
 	}
 
-	return nil
+	_, err := TimeZoneOffset(params.TimeZone)
+	return err
+}
+
+// ValidateDataReturnType checks that the data return type of the read
+// column is known. The data layer checks that the column type supports it
+func ValidateDataReturnType(col *ds.ReadColumn) error {
+	if col.DataReturnType == nil {
+		return nil
+	}
+	_, err := dataReturnType(col.DataReturnType)
+	return err
 }
 
-func parseURI(c *gin.Context, resource *ds.PKReadPP) error {
+func ParseURI(c *gin.Context, resource *ds.PKReadPP) error {
 	err := c.ShouldBindUri(&resource)
 	if err != nil {
 		return err
 	}
 
-	if err = validateDBIdentifier(*resource.DB); err != nil {
+	if err = ValidateDBIdentifier(*resource.DB); err != nil {
 		return err
 	}
 
-	if err = validateDBIdentifier(*resource.Table); err != nil {
+	if err = ValidateDBIdentifier(*resource.Table); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func validateDBIdentifier(identifier string) error {
+func ValidateDBIdentifier(identifier string) error {
 	if len(identifier) < 1 || len(identifier) > 64 {
 		return fmt.Errorf("field length validation failed")
 	}
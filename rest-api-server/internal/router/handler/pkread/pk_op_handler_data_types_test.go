@@ -18,11 +18,16 @@
 package pkread
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	_ "github.com/ianlancetaylor/cgosymbolizer"
 	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/config"
+	"hopsworks.ai/rdrs/internal/dal"
 	ds "hopsworks.ai/rdrs/internal/datastructs"
 	tu "hopsworks.ai/rdrs/internal/router/handler/utils"
 )
@@ -564,16 +569,51 @@ func TestDataTypesFloat(t *testing.T) {
 	validateColumns := []interface{}{"col0", "col1"}
 	tests := map[string]ds.PKTestInfo{
 
-		"floatPK": { // NDB does not support floats PKs
+		"floatPK": {
 			PkReq: ds.PKReadBody{
-				Filters:     tu.NewFiltersKVs("id0", 0),
+				Filters:     tu.NewFiltersKVs("id0", 1.5),
 				ReadColumns: tu.NewReadColumns("col", 2),
 				OperationID: tu.NewOperationID(64),
 			},
 			Table:        "float_table2",
 			Db:           testDb,
+			HttpCode:     http.StatusOK,
+			BodyContains: "",
+			RespKVs:      validateColumns,
+		},
+
+		"floatPKNegative": {
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "-2.5"),
+				ReadColumns: tu.NewReadColumns("col", 2),
+			},
+			Table:        "float_table2",
+			Db:           testDb,
+			HttpCode:     http.StatusOK,
+			BodyContains: "",
+			RespKVs:      validateColumns,
+		},
+
+		"floatPKNotFound": {
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", 1.25),
+				ReadColumns: tu.NewReadColumns("col", 2),
+			},
+			Table:        "float_table2",
+			Db:           testDb,
+			HttpCode:     http.StatusNotFound,
+			BodyContains: "",
+		},
+
+		"floatPKWrongType": {
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "abc"),
+				ReadColumns: tu.NewReadColumns("col", 2),
+			},
+			Table:        "float_table2",
+			Db:           testDb,
 			HttpCode:     http.StatusBadRequest,
-			BodyContains: common.ERROR_017(),
+			BodyContains: common.ERROR_015(),
 		},
 
 		"simple": {
@@ -623,16 +663,51 @@ func TestDataTypesDouble(t *testing.T) {
 	validateColumns := []interface{}{"col0", "col1"}
 	tests := map[string]ds.PKTestInfo{
 
-		"floatPK": {
+		"doublePK": {
 			PkReq: ds.PKReadBody{
-				Filters:     tu.NewFiltersKVs("id0", 0),
+				Filters:     tu.NewFiltersKVs("id0", 1.5),
 				ReadColumns: tu.NewReadColumns("col", 2),
 				OperationID: tu.NewOperationID(64),
 			},
 			Table:        "double_table2",
 			Db:           testDb,
+			HttpCode:     http.StatusOK,
+			BodyContains: "",
+			RespKVs:      validateColumns,
+		},
+
+		"doublePKNegative": {
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "-2.5"),
+				ReadColumns: tu.NewReadColumns("col", 2),
+			},
+			Table:        "double_table2",
+			Db:           testDb,
+			HttpCode:     http.StatusOK,
+			BodyContains: "",
+			RespKVs:      validateColumns,
+		},
+
+		"doublePKNotFound": {
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", 1.25),
+				ReadColumns: tu.NewReadColumns("col", 2),
+			},
+			Table:        "double_table2",
+			Db:           testDb,
+			HttpCode:     http.StatusNotFound,
+			BodyContains: "",
+		},
+
+		"doublePKWrongType": {
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "abc"),
+				ReadColumns: tu.NewReadColumns("col", 2),
+			},
+			Table:        "double_table2",
+			Db:           testDb,
 			HttpCode:     http.StatusBadRequest,
-			BodyContains: common.ERROR_017(),
+			BodyContains: common.ERROR_015(),
 		},
 
 		"simple": {
@@ -741,18 +816,22 @@ func TestDataTypesBlobs(t *testing.T) {
 	testDb := "DB013"
 	tests := map[string]ds.PKTestInfo{
 
-		"blob1": {
+		"blob1": { // BLOB columns are returned as base64
 			PkReq: ds.PKReadBody{
 				Filters:     tu.NewFiltersKVs("id0", "1"),
-				ReadColumns: tu.NewReadColumns("col", 2),
+				ReadColumns: tu.NewReadColumn("col0"),
 				OperationID: tu.NewOperationID(5),
 			},
 			Table:        "blob_table",
 			Db:           testDb,
-			HttpCode:     http.StatusInternalServerError,
-			BodyContains: common.ERROR_026(),
-			RespKVs:      []interface{}{},
+			HttpCode:     http.StatusOK,
+			BodyContains: "",
+			RespKVs:      []interface{}{"col0"},
 		},
+	}
+	tu.PkTest(t, tests, true, RegisterPKTestHandler)
+
+	tests = map[string]ds.PKTestInfo{
 
 		"blob2": {
 			PkReq: ds.PKReadBody{
@@ -767,7 +846,7 @@ func TestDataTypesBlobs(t *testing.T) {
 			RespKVs:      []interface{}{"col1"},
 		},
 
-		"text1": {
+		"text1": { // TEXT columns are returned as strings
 			PkReq: ds.PKReadBody{
 				Filters:     tu.NewFiltersKVs("id0", "1"),
 				ReadColumns: tu.NewReadColumns("col", 2),
@@ -775,9 +854,9 @@ func TestDataTypesBlobs(t *testing.T) {
 			},
 			Table:        "text_table",
 			Db:           testDb,
-			HttpCode:     http.StatusInternalServerError,
+			HttpCode:     http.StatusOK,
 			BodyContains: "",
-			RespKVs:      []interface{}{},
+			RespKVs:      []interface{}{"col0", "col1"},
 		},
 
 		"text2": {
@@ -792,11 +871,238 @@ func TestDataTypesBlobs(t *testing.T) {
 			BodyContains: "",
 			RespKVs:      []interface{}{"col1"},
 		},
+
+		"text3": { // all columns are read if the read columns are not set
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "1"),
+				OperationID: tu.NewOperationID(5),
+			},
+			Table:        "text_table",
+			Db:           testDb,
+			HttpCode:     http.StatusOK,
+			BodyContains: "",
+			RespKVs:      []interface{}{"col0", "col1"},
+		},
 	}
 
 	tu.PkTest(t, tests, false, RegisterPKTestHandler)
 }
 
+func TestDataTypesBlobsMaxSize(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB013")}, []tu.RegisterTestHandler{RegisterPKTestHandler},
+		func(router *gin.Engine) {
+			dal.ConfigureBlobs(3)
+			defer dal.ConfigureBlobs(config.Configuration().RestServer.MaxBlobReadSize)
+
+			// Test. The 2 byte blob can be read
+			body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 1), ReadColumns: tu.NewReadColumn("col0")})
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB013", "blob_table"), string(body),
+				http.StatusOK, "")
+
+			// Test. The 4 byte text can not be read
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB013", "text_table"), string(body),
+				http.StatusBadRequest, common.ERROR_047())
+		})
+}
+
+func TestDataTypesReturnTypes(t *testing.T) {
+	readColumn := func(col string, drt string) *[]ds.ReadColumn {
+		return &[]ds.ReadColumn{{Column: &col, DataReturnType: &drt}}
+	}
+
+	tests := []struct {
+		db       string
+		table    string
+		column   string
+		drt      string
+		expected string
+	}{
+		{"DB013", "blob_table", "col0", ds.DRT_HEX, `"col0":"ffff"`},
+		{"DB013", "blob_table", "col0", ds.DRT_BASE64, `"col0":"//8="`},
+		{"DB013", "text_table", "col0", ds.DRT_HEX, `"col0":"46464646"`},
+		{"DB013", "text_table", "col0", ds.DRT_BASE64, `"col0":"RkZGRg=="`},
+		{"DB026", "table_1", "col_0", ds.DRT_HEX, `"col_0":"726564"`},
+		{"DB026", "table_1", "col_0", ds.DRT_BASE64, `"col_0":"cmVk"`},
+		{"DB026", "table_1", "col_0", ds.DRT_DEFAULT, `"col_0":"red"`},
+	}
+
+	tu.WithDBs(t, [][][]string{common.Database("DB013"), common.Database("DB026")},
+		[]tu.RegisterTestHandler{RegisterPKTestHandler}, func(router *gin.Engine) {
+			for _, test := range tests {
+				body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 1),
+					ReadColumns: readColumn(test.column, test.drt)})
+				tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL(test.db, test.table), string(body),
+					http.StatusOK, test.expected)
+			}
+
+			// Test. Only the bytes of char, binary, bit and BLOB/TEXT columns can be encoded
+			body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 1),
+				ReadColumns: readColumn("col_1", ds.DRT_HEX)})
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB026", "table_1"), string(body),
+				http.StatusBadRequest, common.ERROR_025())
+
+			// Test. Unknown return type
+			body, _ = json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 1),
+				ReadColumns: readColumn("col_0", "xml")})
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB026", "table_1"), string(body),
+				http.StatusBadRequest, "Return data type is not supported")
+		})
+}
+
+func TestDataTypesBigNumbersAsStrings(t *testing.T) {
+	readColumn := func(col string, drt string) ds.ReadColumn {
+		return ds.ReadColumn{Column: &col, DataReturnType: &drt}
+	}
+	asStrings := true
+
+	tu.WithDBs(t, [][][]string{common.Database("DB003"), common.Database("DB005"), common.Database("DB011")},
+		[]tu.RegisterTestHandler{RegisterPKTestHandler}, func(router *gin.Engine) {
+			// Test. All the BIGINT and DECIMAL columns are returned as strings
+			body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 1), BigNumbersAsStrings: &asStrings})
+			_, res := tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB003", "number_table"),
+				string(body), http.StatusOK, "")
+			for _, expected := range []string{`"col3":99,`, `"col4":"99"`, `"col5":"99"`, `"col6":99.99`} {
+				if !strings.Contains(res, expected) {
+					t.Fatalf("Response body does not contain %s. Body: %s", expected, res)
+				}
+			}
+
+			// Test. Unsigned values above 2^53
+			body, _ = json.Marshal(ds.PKReadBody{
+				Filters:             tu.NewFiltersKVs("id0", 9223372036854775807, "id1", uint64(18446744073709551615)),
+				ReadColumns:         tu.NewReadColumns("col", 2),
+				BigNumbersAsStrings: &asStrings,
+			})
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB005", "bigint_table"), string(body),
+				http.StatusOK, `"col0":"9223372036854775807","col1":"18446744073709551615"`)
+
+			// Test. NULL values are not quoted
+			body, _ = json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 1, "id1", 1),
+				ReadColumns: tu.NewReadColumns("col", 2), BigNumbersAsStrings: &asStrings})
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB005", "bigint_table"), string(body),
+				http.StatusOK, `"col0":null,"col1":null`)
+
+			// Test. Per column return type
+			body, _ = json.Marshal(ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", 9223372036854775807, "id1", uint64(18446744073709551615)),
+				ReadColumns: &[]ds.ReadColumn{readColumn("col0", ds.DRT_DEFAULT), readColumn("col1", ds.DRT_STRING)},
+			})
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB005", "bigint_table"), string(body),
+				http.StatusOK, `"col0":9223372036854775807,"col1":"18446744073709551615"`)
+
+			body, _ = json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", "-12345.12345", "id1", "12345.12345"),
+				ReadColumns: &[]ds.ReadColumn{readColumn("col0", ds.DRT_STRING), readColumn("col1", ds.DRT_STRING)}})
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB011", "decimal_table"), string(body),
+				http.StatusOK, `"col0":"-12345.12345","col1":"12345.12345"`)
+
+			// Test. Only BIGINT and DECIMAL columns can be returned as strings
+			body, _ = json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 1),
+				ReadColumns: &[]ds.ReadColumn{readColumn("col3", ds.DRT_STRING)}})
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB003", "number_table"), string(body),
+				http.StatusBadRequest, common.ERROR_025())
+		})
+}
+
+func TestDataTypesTemporalFormats(t *testing.T) {
+	format := func(tf string) *string { return &tf }
+
+	tu.WithDBs(t, [][][]string{common.Database("DB019"), common.Database("DB020"), common.Database("DB021"),
+		common.Database("DB022")}, []tu.RegisterTestHandler{RegisterPKTestHandler}, func(router *gin.Engine) {
+		tests := map[string]struct {
+			db       string
+			table    string
+			key      interface{}
+			format   *string
+			zone     *string
+			expected string
+		}{
+			"date rfc3339":       {"DB019", "date_table", "1111-11-11", format(ds.TF_RFC3339), nil, `"col0":"1111-11-11"`},
+			"date epoch":         {"DB019", "date_table", "1111-11-11", format(ds.TF_EPOCH_MILLIS), nil, `"col0":-27080352000000`},
+			"datetime rfc3339":   {"DB020", "date_table3", "1111-11-11T11:11:11.123", format(ds.TF_RFC3339), nil, `"col0":"1111-11-11T11:11:11.123"`},
+			"time epoch":         {"DB021", "time_table3", "11:11:11.123", format(ds.TF_EPOCH_MILLIS), nil, `"col0":40271123`},
+			"time epoch key":     {"DB021", "time_table3", 40271123, format(ds.TF_EPOCH_MILLIS), nil, `"col0":40271123`},
+			"timestamp rfc3339":  {"DB022", "ts_table3", "2022-11-11 11:11:11.123", format(ds.TF_RFC3339), nil, `"col0":"2022-11-11T11:11:11.123Z"`},
+			"timestamp zone":     {"DB022", "ts_table3", "2022-11-11T16:41:11.123+05:30", format(ds.TF_RFC3339), format("+05:30"), `"col0":"2022-11-11T16:41:11.123+05:30"`},
+			"timestamp mysql":    {"DB022", "ts_table3", "2022-11-11 16:41:11.123", nil, format("+05:30"), `"col0":"2022-11-11 16:41:11.123"`},
+			"timestamp epoch":    {"DB022", "ts_table3", 1668165071123, format(ds.TF_EPOCH_MILLIS), format("-05:00"), `"col0":1668165071123`},
+			"timestamp epoch z":  {"DB022", "ts_table3", "2022-11-11T11:11:11.123Z", format(ds.TF_EPOCH_MILLIS), nil, `"col0":1668165071123`},
+			"timestamp utc zone": {"DB022", "ts_table3", "2022-11-11 11:11:11.123", format(ds.TF_MYSQL), format("UTC"), `"col0":"2022-11-11 11:11:11.123"`},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", test.key),
+					ReadColumns: tu.NewReadColumns("col", 1), TemporalFormat: test.format, TimeZone: test.zone})
+				tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL(test.db, test.table), string(body),
+					http.StatusOK, test.expected)
+			})
+		}
+
+		// Test. Unknown format and time zone
+		body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", "2022-11-11 11:11:11.123"),
+			TemporalFormat: format("iso8601")})
+		tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB022", "ts_table3"), string(body),
+			http.StatusBadRequest, "Error:Field validation for 'TemporalFormat'")
+
+		body, _ = json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", "2022-11-11 11:11:11.123"),
+			TimeZone: format("+5")})
+		tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB022", "ts_table3"), string(body),
+			http.StatusBadRequest, "time zone is not supported")
+	})
+}
+
+func TestDataTypesMySQLTypes(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB028")}, []tu.RegisterTestHandler{RegisterPKTestHandler},
+		func(router *gin.Engine) {
+			tests := map[string]struct {
+				key      int
+				expected string
+			}{
+				"labels":     {1, `"col0":"green","col1":["a","c"],"col2":{"k": [1, 2], "s": "x\"y"}`},
+				"empty set":  {2, `"col0":"blue","col1":[],"col2":[true, null, 1.5]`},
+				"null value": {3, `"col0":null,"col1":null,"col2":null`},
+			}
+
+			for name, test := range tests {
+				t.Run(name, func(t *testing.T) {
+					body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", test.key),
+						ReadColumns: tu.NewReadColumns("col", 3)})
+					tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB028", "types"), string(body),
+						http.StatusOK, test.expected)
+				})
+			}
+		})
+}
+
+func TestDataTypesCharsets(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB029")}, []tu.RegisterTestHandler{RegisterPKTestHandler},
+		func(router *gin.Engine) {
+			tests := map[string]struct {
+				table    string
+				key      string
+				cols     int
+				expected string
+			}{
+				"latin1": {"latin1_table", "café", 2, `"col0":"naïve","col1":"Grüße"`},
+				"utf16":  {"utf16_table", "é", 1, `"col0":"ümlaut"`},
+			}
+
+			for name, test := range tests {
+				t.Run(name, func(t *testing.T) {
+					body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", test.key),
+						ReadColumns: tu.NewReadColumns("col", test.cols)})
+					tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB029", test.table), string(body),
+						http.StatusOK, test.expected)
+				})
+			}
+
+			// Test. Key that has no mapping in latin1
+			body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", "日本")})
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB029", "latin1_table"), string(body),
+				http.StatusBadRequest, common.ERROR_051())
+		})
+}
+
 func TestDataTypesChar(t *testing.T) {
 	ArrayColumnTest(t, "table1", "DB012", false, 100, true)
 }
@@ -821,6 +1127,39 @@ func TestDataTypesLongVarbinary(t *testing.T) {
 	ArrayColumnTest(t, "table1", "DB018", true, 256, false)
 }
 
+func TestDataTypesTrimPadding(t *testing.T) {
+	trim := func(b bool) *bool { return &b }
+
+	tu.WithDBs(t, [][][]string{common.Database("DB012"), common.Database("DB016")},
+		[]tu.RegisterTestHandler{RegisterPKTestHandler}, func(router *gin.Engine) {
+			tests := map[string]struct {
+				db       string
+				key      string
+				trim     *bool
+				expected string
+			}{
+				"char default": {"DB012", "3", nil, `"col0":"a\nb"`},
+				// RonDB stores CHAR(100) utf8mb4 columns in 400 bytes
+				"char keep": {"DB012", "3", trim(false), `"col0":"a\nb` + strings.Repeat(" ", 397) + `"`},
+				"binary default": {"DB016", tu.Encode("2", true, 100, true), nil,
+					`"col0":"` + tu.Encode("f\x00f", true, 100, true) + `"`},
+				"binary trim": {"DB016", tu.Encode("2", true, 100, true), trim(true),
+					`"col0":"` + tu.Encode("f\x00f", true, 100, false) + `"`},
+			}
+
+			for name, test := range tests {
+				t.Run(name, func(t *testing.T) {
+					readColumns := tu.NewReadColumns("col", 1)
+					(*readColumns)[0].TrimPadding = test.trim
+					body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", test.key),
+						ReadColumns: readColumns})
+					tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL(test.db, "table1"), string(body),
+						http.StatusOK, test.expected)
+				})
+			}
+		})
+}
+
 func ArrayColumnTest(t *testing.T, table string, database string, isBinary bool, colWidth int, padding bool) {
 	t.Helper()
 	testTable := table
@@ -1604,3 +1943,77 @@ func TestDataTypesBitColumn(t *testing.T) {
 	}
 	tu.PkTest(t, tests, true, RegisterPKTestHandler)
 }
+
+func TestDataTypesBitPK(t *testing.T) {
+	testDb := "DB024"
+	testTable := "bit_table2"
+	tests := map[string]ds.PKTestInfo{
+
+		"simple": { // b'101010101010'
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "Cqo="),
+				ReadColumns: tu.NewReadColumns("col", 1),
+			},
+			Table:        testTable,
+			Db:           testDb,
+			HttpCode:     http.StatusOK,
+			BodyContains: `"col0":1`,
+		},
+
+		"leadingZeroBytes": { // b'1' with and without the leading zero byte
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "AAE="),
+				ReadColumns: tu.NewReadColumns("col", 1),
+			},
+			Table:        testTable,
+			Db:           testDb,
+			HttpCode:     http.StatusOK,
+			BodyContains: `"col0":2`,
+		},
+
+		"shortKey": {
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "AQ=="),
+				ReadColumns: tu.NewReadColumns("col", 1),
+			},
+			Table:        testTable,
+			Db:           testDb,
+			HttpCode:     http.StatusOK,
+			BodyContains: `"col0":2`,
+		},
+
+		"notFound": {
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "AAI="),
+				ReadColumns: tu.NewReadColumns("col", 1),
+			},
+			Table:        testTable,
+			Db:           testDb,
+			HttpCode:     http.StatusNotFound,
+			BodyContains: "",
+		},
+
+		"tooManyBits": { // 13 bits for a BIT(12) column
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "H/8="),
+				ReadColumns: tu.NewReadColumns("col", 1),
+			},
+			Table:        testTable,
+			Db:           testDb,
+			HttpCode:     http.StatusBadRequest,
+			BodyContains: common.ERROR_015(),
+		},
+
+		"notBase64": {
+			PkReq: ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", "#$%"),
+				ReadColumns: tu.NewReadColumns("col", 1),
+			},
+			Table:        testTable,
+			Db:           testDb,
+			HttpCode:     http.StatusBadRequest,
+			BodyContains: common.ERROR_015(),
+		},
+	}
+	tu.PkTest(t, tests, false, RegisterPKTestHandler)
+}
@@ -18,9 +18,12 @@
 package pkread
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -283,3 +286,128 @@ func TestPKERROR_013_ERROR_014(t *testing.T) {
 			// no of pk cols matches but the column names are different
 		})
 }
+
+func TestPKReadLockMode(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")},
+		[]tu.RegisterTestHandler{RegisterPKTestHandler}, func(router *gin.Engine) {
+			url := tu.NewPKReadURL("DB025", "table_1")
+
+			// Test. Unsupported lock mode
+			lockMode := "dirty"
+			param := ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", 1),
+				ReadColumns: tu.NewReadColumn("col_0"),
+				LockMode:    &lockMode,
+			}
+			body, _ := json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, url, string(body), http.StatusBadRequest,
+				"Error:Field validation for 'LockMode' failed on the 'oneof' tag")
+
+			// Test. Supported lock modes
+			for _, lm := range []string{ds.LOCK_MODE_COMMITTED, ds.LOCK_MODE_SHARED, ds.LOCK_MODE_EXCLUSIVE} {
+				lockMode = lm
+				body, _ = json.MarshalIndent(param, "", "\t")
+				tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, url, string(body), http.StatusOK, "col_0")
+			}
+		})
+}
+
+func TestPKReadNulls(t *testing.T) {
+	flag := func(b bool) *bool { return &b }
+
+	tu.WithDBs(t, [][][]string{common.Database("DB028")},
+		[]tu.RegisterTestHandler{RegisterPKTestHandler}, func(router *gin.Engine) {
+			url := tu.NewPKReadURL("DB028", "types")
+			tests := map[string]struct {
+				key       int
+				omitNulls *bool
+				nullMask  *bool
+				expected  string
+			}{
+				"inline":      {3, nil, nil, `"data": {"id0":3,"col0":null,"col1":null,"col2":null}`},
+				"omit":        {3, flag(true), nil, `"data": {"id0":3}`},
+				"mask":        {3, nil, flag(true), `"col2":null}, "nullColumns": ["col0", "col1", "col2"]`},
+				"omit mask":   {3, flag(true), flag(true), `"data": {"id0":3}, "nullColumns": ["col0", "col1", "col2"]`},
+				"no nulls":    {1, flag(true), flag(true), `"col2":{"k": [1, 2], "s": "x\"y"}}, "nullColumns": []`},
+				"not omitted": {1, flag(false), nil, `"data": {"id0":1,"col0":"green"`},
+			}
+
+			for name, test := range tests {
+				t.Run(name, func(t *testing.T) {
+					body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", test.key),
+						OmitNulls: test.omitNulls, IncludeNullMask: test.nullMask})
+					tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, url, string(body), http.StatusOK, test.expected)
+				})
+			}
+
+			// Test. Rows that are not found do not have a null mask
+			body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 100), IncludeNullMask: flag(true)})
+			_, res := tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, url, string(body), http.StatusNotFound, "")
+			if strings.Contains(res, "nullColumns") {
+				t.Fatalf("Test failed. Unexpected null mask. Body: %s", res)
+			}
+		})
+}
+
+func TestPKReadBinaryFormats(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB027"), common.Database("DB028")},
+		[]tu.RegisterTestHandler{RegisterPKTestHandler}, func(router *gin.Engine) {
+			artifact := bytes.Repeat([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, 1000)
+			url := tu.NewPKReadURL("DB027", "artifacts")
+
+			for _, format := range []string{ds.MSGPACK_MIME, ds.CBOR_MIME} {
+				t.Run(format, func(t *testing.T) {
+					// Test. Binary columns are returned as raw bytes
+					body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 1, "name", "model"),
+						OperationID: &[]string{"op1"}[0]})
+					res := tu.ProcessBinaryRequest(t, router, ds.PK_HTTP_VERB, url, string(body), format,
+						http.StatusOK).(map[string]interface{})
+					data := res["data"].(map[string]interface{})
+					if res["operationId"] != "op1" || !bytes.Equal(data["data"].([]byte), artifact) ||
+						data["notes"] != "trained on DB026" || fmt.Sprint(data["version"]) != "1" {
+						t.Fatalf("Test failed. Unexpected response: %v", res)
+					}
+
+					// Test. Encoded return types are strings
+					body, _ = json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 2, "name", "empty"),
+						ReadColumns: &[]ds.ReadColumn{{Column: &[]string{"data"}[0],
+							DataReturnType: &[]string{ds.DRT_HEX}[0]}, {Column: &[]string{"notes"}[0]}},
+						IncludeNullMask: &[]bool{true}[0]})
+					res = tu.ProcessBinaryRequest(t, router, ds.PK_HTTP_VERB, url, string(body), format,
+						http.StatusOK).(map[string]interface{})
+					data = res["data"].(map[string]interface{})
+					if data["data"] != "" || data["notes"] != nil || fmt.Sprint(res["nullColumns"]) != "[notes]" {
+						t.Fatalf("Test failed. Unexpected response: %v", res)
+					}
+
+					// Test. SET columns are arrays and JSON columns are JSON text
+					body, _ = json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 1)})
+					res = tu.ProcessBinaryRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB028", "types"),
+						string(body), format, http.StatusOK).(map[string]interface{})
+					data = res["data"].(map[string]interface{})
+					if data["col0"] != "green" || fmt.Sprint(data["col1"]) != "[a c]" ||
+						data["col2"] != `{"k": [1, 2], "s": "x\"y"}` {
+						t.Fatalf("Test failed. Unexpected response: %v", res)
+					}
+
+					// Test. Rows that are not found
+					body, _ = json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 3, "name", "model")})
+					res = tu.ProcessBinaryRequest(t, router, ds.PK_HTTP_VERB, url, string(body), format,
+						http.StatusNotFound).(map[string]interface{})
+					if v, ok := res["data"]; !ok || v != nil {
+						t.Fatalf("Test failed. Unexpected response: %v", res)
+					}
+				})
+			}
+
+			// Test. Errors are JSON
+			body, _ := json.Marshal(ds.PKReadBody{Filters: tu.NewFiltersKVs("id0", 1)})
+			req, _ := http.NewRequest(ds.PK_HTTP_VERB, tu.NewPKReadURL("DB027", "missing"), strings.NewReader(string(body)))
+			req.Header.Set("Accept", ds.MSGPACK_MIME)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+			if resp.Code != http.StatusBadRequest || !strings.Contains(resp.Body.String(), common.ERROR_011()) {
+				t.Fatalf("Test failed. Unexpected response: %d %s", resp.Code, resp.Body)
+			}
+		})
+}
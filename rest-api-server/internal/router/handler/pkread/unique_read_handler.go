@@ -0,0 +1,95 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pkread
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"hopsworks.ai/rdrs/internal/common"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/log"
+)
+
+func RegisterUniqueReadTestHandler(e *gin.Engine) {
+	group := e.Group(ds.DB_OPS_EP_GROUP)
+	group.POST(ds.UNIQUE_READ_OPERATION, UniqueReadHandler)
+}
+
+func UniqueReadHandler(c *gin.Context) {
+	pkReadParams := ds.PKReadParams{}
+
+	err := parseUniqueReadRequest(c, &pkReadParams)
+	if err != nil {
+		if log.IsDebug() {
+			body, _ := ioutil.ReadAll(c.Request.Body)
+			log.Debugf("Unable to parse request. Error: %v. Body: %s\n", err, body)
+		}
+		common.SetResponseError(c, http.StatusBadRequest, common.ErrorResponse{Error: fmt.Sprintf("%-v", err)})
+		return
+	}
+
+	performPKRead(c, &pkReadParams)
+}
+
+func parseUniqueReadRequest(c *gin.Context, pkReadParams *ds.PKReadParams) error {
+
+	body := ds.UniqueReadBody{}
+	pp := ds.PKReadPP{}
+
+	if err := ParseURI(c, &pp); err != nil {
+		return err
+	}
+
+	b := binding.JSON
+	if err := b.Bind(c.Request, &body); err != nil {
+		return err
+	}
+
+	if err := ValidateUniqueReadBody(&body); err != nil {
+		return err
+	}
+
+	pkReadParams.DB = pp.DB
+	pkReadParams.Table = pp.Table
+	pkReadParams.Index = body.Index
+	pkReadParams.Filters = body.Filters
+	pkReadParams.ReadColumns = body.ReadColumns
+	pkReadParams.OperationID = body.OperationID
+	pkReadParams.TxID = body.TxID
+	pkReadParams.LockMode = body.LockMode
+	pkReadParams.BigNumbersAsStrings = body.BigNumbersAsStrings
+	pkReadParams.TemporalFormat = body.TemporalFormat
+	pkReadParams.TimeZone = body.TimeZone
+	pkReadParams.OmitNulls = body.OmitNulls
+	pkReadParams.IncludeNullMask = body.IncludeNullMask
+	return nil
+}
+
+func ValidateUniqueReadBody(params *ds.UniqueReadBody) error {
+	if err := ValidateDBIdentifier(*params.Index); err != nil {
+		return err
+	}
+
+	// the index columns are validated the same way as the primary key columns
+	return ValidateBody(&ds.PKReadBody{Filters: params.Filters, ReadColumns: params.ReadColumns,
+		TimeZone: params.TimeZone})
+}
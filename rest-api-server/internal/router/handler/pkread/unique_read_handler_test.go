@@ -0,0 +1,96 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package pkread
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+	"hopsworks.ai/rdrs/internal/common"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	tu "hopsworks.ai/rdrs/internal/router/handler/utils"
+)
+
+func TestUniqueRead(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterUniqueReadTestHandler},
+		func(router *gin.Engine) {
+			url := tu.NewUniqueReadURL("DB026", "users")
+			index := "email_idx"
+
+			// Test. Read the row using the unique index
+			param := ds.UniqueReadBody{
+				Index:       &index,
+				Filters:     tu.NewFiltersKVs("email", "bob@example.com"),
+				OperationID: tu.NewOperationID(64),
+			}
+			body, _ := json.Marshal(param)
+			_, res := tu.ProcessRequest(t, router, ds.UNIQUE_READ_HTTP_VERB, url, string(body), http.StatusOK,
+				*param.OperationID)
+
+			var resp struct {
+				Data map[string]json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal([]byte(res), &resp); err != nil {
+				t.Fatalf("Test failed. Unable to parse response. Error: %v. Body: %s", err, res)
+			}
+			if string(resp.Data["id0"]) != "2" || string(resp.Data["name"]) != `"bob"` {
+				t.Fatalf("Test failed. Unexpected data. Body: %s", res)
+			}
+
+			// Test. Read columns
+			param.ReadColumns = tu.NewReadColumn("name")
+			body, _ = json.Marshal(param)
+			tu.ProcessRequest(t, router, ds.UNIQUE_READ_HTTP_VERB, url, string(body), http.StatusOK, "bob")
+
+			// Test. Row not found
+			param.Filters = tu.NewFiltersKVs("email", "carol@example.com")
+			body, _ = json.Marshal(param)
+			tu.ProcessRequest(t, router, ds.UNIQUE_READ_HTTP_VERB, url, string(body), http.StatusNotFound, "")
+
+			// Test. Filters must be the columns of the index
+			param.Filters = tu.NewFiltersKVs("id0", 1)
+			body, _ = json.Marshal(param)
+			tu.ProcessRequest(t, router, ds.UNIQUE_READ_HTTP_VERB, url, string(body), http.StatusBadRequest,
+				common.ERROR_014())
+
+			// Test. Unknown index
+			unknown := "idx_x"
+			param.Index = &unknown
+			param.Filters = tu.NewFiltersKVs("email", "bob@example.com")
+			body, _ = json.Marshal(param)
+			tu.ProcessRequest(t, router, ds.UNIQUE_READ_HTTP_VERB, url, string(body), http.StatusBadRequest,
+				common.ERROR_043())
+
+			// Test. Ordered indexes can not be used for unique reads
+			ordered := "device_ts"
+			param.Index = &ordered
+			param.Filters = tu.NewFiltersKVs("device_id", "dev_1", "ts", 100)
+			body, _ = json.Marshal(param)
+			tu.ProcessRequest(t, router, ds.UNIQUE_READ_HTTP_VERB, tu.NewUniqueReadURL("DB026", "readings"),
+				string(body), http.StatusBadRequest, common.ERROR_046())
+
+			// Test. Index is required
+			param.Index = nil
+			body, _ = json.Marshal(param)
+			tu.ProcessRequest(t, router, ds.UNIQUE_READ_HTTP_VERB, url, string(body), http.StatusBadRequest,
+				"Error:Field validation for 'Index'")
+		})
+}
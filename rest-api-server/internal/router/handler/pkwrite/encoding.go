@@ -0,0 +1,210 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+package pkwrite
+
+/*
+#include "./../../../../../data-access-rondb/src/rdrs-const.h"
+#include "./../../../../../data-access-rondb/src/rdrs-dal.h"
+*/
+import "C"
+import (
+	"fmt"
+	"sort"
+	"unsafe"
+
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+)
+
+//  PK WRITE Request
+//  ================
+//
+//  The request uses the same header and body layout as the PK read request,
+//  see internal/router/handler/pkread/encoding.go. Read columns are not set
+//  and the type field contains the write mode. The values are stored the
+//  same way as the primary key columns and are pointed to by the Values
+//  offset in the header. Delete requests use the same layout without values
+//
+//  HEADER
+//  ======
+//  [   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ]
+//    Type     Capacity  Length     DB         Table      PK     Read Cols    Op_ID    TX_ID    Values    Lock
+//                               Offset      Offset    Offset     Offset     Offset    Offset   Offset    Mode
+//
+//  VALUES
+//  ======
+//  [   4B   ][   4B   ]...[   4B   ][   4B   ][   4B   ][   4B   ][   bytes ...  ][ 2B ] [ bytes... ] ....
+//    Count     kv 1          kv n       col       value     value      col          val     val
+//            offset        offset     offset     offset     flags                  size
+//
+//  value offset is 0 if the column is set to NULL. The flags tell if the
+//  value was a JSON string, see RDRS_VF_STRING
+//
+
+func CreateNativeRequest(params *ds.PKWriteParams) (*dal.NativeBuffer, *dal.NativeBuffer, error) {
+	opType, err := writeOpType(params.Mode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := dal.GetBuffer()
+	request := dal.GetBuffer()
+	iBuf := unsafe.Slice((*uint32)(request.Buffer), request.Size)
+
+	// First N bytes are for header
+	var head uint32 = C.PKR_HEADER_END
+
+	dbOffSet := head
+	head, err = common.CopyGoStrToCStr([]byte(*params.DB), request, head)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tableOffSet := head
+	head, err = common.CopyGoStrToCStr([]byte(*params.Table), request, head)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// PK Filters
+	head = common.AlignWord(head)
+	pkOffset := head
+	head, err = pkread.EncodePKFilters(params.Filters, request, head)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Values
+	head = common.AlignWord(head)
+	var valuesOffset uint32 = 0
+	if params.Values != nil && len(*params.Values) > 0 {
+		valuesOffset = head
+		head, err = encodeValues(params, request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Operation ID
+	var opIdOffset uint32 = 0
+	if params.OperationID != nil {
+		opIdOffset = head
+		head, err = common.CopyGoStrToCStr([]byte(*params.OperationID), request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Transaction ID
+	var txIdOffset uint32 = 0
+	if params.TxID != nil {
+		txIdOffset = head
+		head, err = common.CopyGoStrToCStr([]byte(*params.TxID), request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// request buffer header
+	iBuf[C.PKR_OP_TYPE_IDX] = opType
+	iBuf[C.PKR_CAPACITY_IDX] = uint32(request.Size)
+	iBuf[C.PKR_LENGTH_IDX] = uint32(head)
+	iBuf[C.PKR_DB_IDX] = uint32(dbOffSet)
+	iBuf[C.PKR_TABLE_IDX] = uint32(tableOffSet)
+	iBuf[C.PKR_PK_COLS_IDX] = uint32(pkOffset)
+	iBuf[C.PKR_READ_COLS_IDX] = 0
+	iBuf[C.PKR_OP_ID_IDX] = uint32(opIdOffset)
+	iBuf[C.PKR_TX_ID_IDX] = uint32(txIdOffset)
+	iBuf[C.PKR_VALUES_IDX] = uint32(valuesOffset)
+	iBuf[C.PKR_LOCK_MODE_IDX] = 0
+	iBuf[C.PKR_INDEX_IDX] = 0
+	iBuf[C.PKR_TIME_FORMAT_IDX] = C.RDRS_TF_MYSQL
+	iBuf[C.PKR_TIME_ZONE_IDX] = 0
+	iBuf[C.PKR_COL_TYPES_IDX] = 0
+	iBuf[C.PKR_RESP_FORMAT_IDX] = pkread.ResponseFormat(params.ResponseFormat)
+
+	return request, response, nil
+}
+
+func encodeValues(params *ds.PKWriteParams, request *dal.NativeBuffer, head uint32) (uint32, error) {
+	iBuf := unsafe.Slice((*uint32)(request.Buffer), request.Size)
+
+	// sort the columns so that the same request is always encoded the same way
+	cols := make([]string, 0, len(*params.Values))
+	for col := range *params.Values {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	iBuf[head/C.ADDRESS_SIZE] = uint32(len(cols))
+	head += C.ADDRESS_SIZE
+
+	kvi := head / C.ADDRESS_SIZE // index for storing offsets for each column/value pair
+	// skip for N number of offsets one for each column/value pair
+	head = head + (uint32(len(cols)) * C.ADDRESS_SIZE)
+	for _, col := range cols {
+		head = common.AlignWord(head)
+
+		tupleOffset := head
+
+		head = head + 12 //  for column and value offsets and value flags
+		colOffset := head
+		var err error
+		head, err = common.CopyGoStrToCStr([]byte(col), request, head)
+		if err != nil {
+			return 0, err
+		}
+
+		var valueOffset uint32 = 0 // NULL
+		var valueFlags uint32 = 0
+		value := (*params.Values)[col]
+		if value != nil {
+			valueOffset = head
+			if len(*value) > 0 && (*value)[0] == '"' {
+				valueFlags |= C.RDRS_VF_STRING
+			}
+			head, err = common.CopyJSONValueToNDBStr(*value, request, head)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		iBuf[kvi] = tupleOffset
+		kvi++
+		iBuf[tupleOffset/C.ADDRESS_SIZE] = colOffset
+		iBuf[(tupleOffset/C.ADDRESS_SIZE)+1] = valueOffset
+		iBuf[(tupleOffset/C.ADDRESS_SIZE)+2] = valueFlags
+	}
+	return head, nil
+}
+
+func writeOpType(mode *string) (uint32, error) {
+	switch *mode {
+	case ds.PK_WRITE_MODE_INSERT:
+		return C.RDRS_PK_INSERT_REQ_ID, nil
+	case ds.PK_WRITE_MODE_UPDATE:
+		return C.RDRS_PK_UPDATE_REQ_ID, nil
+	case ds.PK_WRITE_MODE_UPSERT:
+		return C.RDRS_PK_UPSERT_REQ_ID, nil
+	case ds.PK_WRITE_MODE_DELETE:
+		return C.RDRS_PK_DELETE_REQ_ID, nil
+	default:
+		return 0, fmt.Errorf("Write mode is not supported. Mode: " + *mode)
+	}
+}
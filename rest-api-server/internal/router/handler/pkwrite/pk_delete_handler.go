@@ -0,0 +1,111 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+package pkwrite
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/log"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+)
+
+func RegisterPKDeleteTestHandler(e *gin.Engine) {
+	group := e.Group(ds.DB_OPS_EP_GROUP)
+	group.POST(ds.PK_DELETE_OPERATION, PkDeleteHandler)
+	group.DELETE(ds.PK_DELETE_OPERATION, PkDeleteHandler)
+}
+
+func PkDeleteHandler(c *gin.Context) {
+	pkDeleteParams := ds.PKWriteParams{}
+
+	err := parseDeleteRequest(c, &pkDeleteParams)
+	if err != nil {
+		if log.IsDebug() {
+			body, _ := ioutil.ReadAll(c.Request.Body)
+			log.Debugf("Unable to parse request. Error: %v. Body: %s\n", err, body)
+		}
+		common.SetResponseError(c, http.StatusBadRequest, common.ErrorResponse{Error: fmt.Sprintf("%-v", err)})
+		return
+	}
+
+	request, response, err := CreateNativeRequest(&pkDeleteParams)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"OK": false, "msg": fmt.Sprintf("%v", err)})
+		return
+	}
+	defer dal.ReturnBuffer(request)
+	defer dal.ReturnBuffer(response)
+
+	dalErr := dal.RonDBPKWrite(request, response)
+
+	var message string
+	if dalErr != nil {
+		// the response buffer is not set if the transaction was not found
+		if dalErr.HttpCode == http.StatusNotFound && !dalErr.TxNotFound {
+			setResponseBodyUnsafe(c, http.StatusNotFound, response)
+		} else {
+			if dalErr.HttpCode >= http.StatusInternalServerError {
+				message = fmt.Sprintf("%v File: %v, Line: %v ", dalErr.Message, dalErr.ErrFileName, dalErr.ErrLineNo)
+			} else {
+				message = fmt.Sprintf("%v", dalErr.Message)
+			}
+			common.SetResponseError(c, dalErr.HttpCode, common.ErrorResponse{Error: message})
+		}
+	} else {
+		setResponseBodyUnsafe(c, http.StatusOK, response)
+	}
+}
+
+func parseDeleteRequest(c *gin.Context, pkDeleteParams *ds.PKWriteParams) error {
+
+	body := ds.PKDeleteBody{}
+	pp := ds.PKReadPP{}
+
+	if err := pkread.ParseURI(c, &pp); err != nil {
+		return err
+	}
+
+	b := binding.JSON
+	if err := b.Bind(c.Request, &body); err != nil {
+		return err
+	}
+
+	if err := ValidateDeleteBody(&body); err != nil {
+		return err
+	}
+
+	mode := ds.PK_WRITE_MODE_DELETE
+	pkDeleteParams.DB = pp.DB
+	pkDeleteParams.Table = pp.Table
+	pkDeleteParams.Filters = body.Filters
+	pkDeleteParams.Mode = &mode
+	pkDeleteParams.OperationID = body.OperationID
+	pkDeleteParams.TxID = body.TxID
+	return nil
+}
+
+func ValidateDeleteBody(params *ds.PKDeleteBody) error {
+	// the primary key is validated the same way as for pk-read
+	return pkread.ValidateBody(&ds.PKReadBody{Filters: params.Filters})
+}
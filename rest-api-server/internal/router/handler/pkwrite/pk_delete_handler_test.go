@@ -0,0 +1,82 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+package pkwrite
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+	"hopsworks.ai/rdrs/internal/common"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+	tu "hopsworks.ai/rdrs/internal/router/handler/utils"
+)
+
+func TestPKDeleteOmitRequired(t *testing.T) {
+	router, err := tu.InitRouter(t, []tu.RegisterTestHandler{RegisterPKDeleteTestHandler})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// Test. Omitting filter should result in 400 error
+	param := ds.PKDeleteBody{
+		Filters:     nil,
+		OperationID: tu.NewOperationID(64),
+	}
+	body, _ := json.MarshalIndent(param, "", "\t")
+	url := tu.NewPKDeleteURL("db", "table")
+	tu.ProcessRequest(t, router, ds.PK_DELETE_HTTP_VERB, url, string(body), http.StatusBadRequest,
+		"Error:Field validation for 'Filters'")
+
+	// Test. Duplicate filters
+	param.Filters = tu.NewFiltersKVs("id0", 1, "id0", 1)
+	body, _ = json.MarshalIndent(param, "", "\t")
+	tu.ProcessRequest(t, router, ds.PK_DELETE_HTTP_VERB, url, string(body), http.StatusBadRequest,
+		"field validation for filter failed on the 'unique' tag")
+}
+
+func TestPKDelete(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")},
+		[]tu.RegisterTestHandler{RegisterPKDeleteTestHandler, pkread.RegisterPKTestHandler},
+		func(router *gin.Engine) {
+			url := tu.NewPKDeleteURL("DB025", "table_1")
+
+			param := ds.PKDeleteBody{
+				Filters:     tu.NewFiltersKVs("id0", 1),
+				OperationID: tu.NewOperationID(64),
+			}
+			body, _ := json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_DELETE_HTTP_VERB, url, string(body), http.StatusOK, *param.OperationID)
+
+			// Test. The row is gone
+			readParam := ds.PKReadBody{Filters: param.Filters}
+			readBody, _ := json.MarshalIndent(readParam, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB025", "table_1"),
+				string(readBody), http.StatusNotFound, "")
+
+			// Test. Deleting the row again using the DELETE verb should return 404
+			tu.ProcessRequest(t, router, http.MethodDelete, url, string(body), http.StatusNotFound, "")
+
+			// Test. Wrong primary key column
+			param.Filters = tu.NewFiltersKVs("col_0", 1)
+			body, _ = json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_DELETE_HTTP_VERB, url, string(body), http.StatusBadRequest, common.ERROR_014())
+		})
+}
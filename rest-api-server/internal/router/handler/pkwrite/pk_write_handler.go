@@ -0,0 +1,156 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+package pkwrite
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/log"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+)
+
+func RegisterPKWriteTestHandler(e *gin.Engine) {
+	group := e.Group(ds.DB_OPS_EP_GROUP)
+	group.POST(ds.PK_WRITE_OPERATION, PkWriteHandler)
+}
+
+func PkWriteHandler(c *gin.Context) {
+	pkWriteParams := ds.PKWriteParams{}
+
+	err := parseRequest(c, &pkWriteParams)
+	if err != nil {
+		if log.IsDebug() {
+			body, _ := ioutil.ReadAll(c.Request.Body)
+			log.Debugf("Unable to parse request. Error: %v. Body: %s\n", err, body)
+		}
+		common.SetResponseError(c, http.StatusBadRequest, common.ErrorResponse{Error: fmt.Sprintf("%-v", err)})
+		return
+	}
+
+	request, response, err := CreateNativeRequest(&pkWriteParams)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"OK": false, "msg": fmt.Sprintf("%v", err)})
+		return
+	}
+	defer dal.ReturnBuffer(request)
+	defer dal.ReturnBuffer(response)
+
+	dalErr := dal.RonDBPKWrite(request, response)
+
+	var message string
+	if dalErr != nil {
+		// the response buffer is not set if the transaction was not found
+		if dalErr.HttpCode == http.StatusNotFound && !dalErr.TxNotFound {
+			setResponseBodyUnsafe(c, http.StatusNotFound, response)
+		} else {
+			if dalErr.HttpCode >= http.StatusInternalServerError {
+				message = fmt.Sprintf("%v File: %v, Line: %v ", dalErr.Message, dalErr.ErrFileName, dalErr.ErrLineNo)
+			} else {
+				message = fmt.Sprintf("%v", dalErr.Message)
+			}
+			common.SetResponseError(c, dalErr.HttpCode, common.ErrorResponse{Error: message})
+		}
+	} else {
+		setResponseBodyUnsafe(c, http.StatusOK, response)
+	}
+}
+
+func setResponseBodyUnsafe(c *gin.Context, code int, resp *dal.NativeBuffer) {
+	c.Writer.WriteHeader(code)
+	c.Writer.Write(([]byte)(common.ProcessResponse(resp.Buffer)))
+}
+
+func parseRequest(c *gin.Context, pkWriteParams *ds.PKWriteParams) error {
+
+	body := ds.PKWriteBody{}
+	pp := ds.PKReadPP{}
+
+	if err := pkread.ParseURI(c, &pp); err != nil {
+		return err
+	}
+
+	if err := ParseBody(c.Request, &body); err != nil {
+		return err
+	}
+
+	pkWriteParams.DB = pp.DB
+	pkWriteParams.Table = pp.Table
+	pkWriteParams.Filters = body.Filters
+	pkWriteParams.Values = body.Values
+	pkWriteParams.Mode = body.Mode
+	pkWriteParams.OperationID = body.OperationID
+	pkWriteParams.TxID = body.TxID
+	return nil
+}
+
+func ParseBody(req *http.Request, params *ds.PKWriteBody) error {
+
+	b := binding.JSON
+	err := b.Bind(req, &params)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateBody(params)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateBody(params *ds.PKWriteBody) error {
+
+	// the primary key is validated the same way as for pk-read
+	err := pkread.ValidateBody(&ds.PKReadBody{Filters: params.Filters})
+	if err != nil {
+		return err
+	}
+
+	if *params.Mode == ds.PK_WRITE_MODE_UPDATE && (params.Values == nil || len(*params.Values) == 0) {
+		return fmt.Errorf("field validation for 'values' failed. Update requires at least one value")
+	}
+
+	if params.Values == nil {
+		return nil
+	}
+
+	// make sure that value columns are valid and do not overlap with the filters
+	existingFilters := make(map[string]bool)
+	for _, filter := range *params.Filters {
+		existingFilters[*filter.Column] = true
+	}
+
+	for col := range *params.Values {
+		if err := pkread.ValidateDBIdentifier(col); err != nil {
+			return err
+		}
+
+		if _, value := existingFilters[col]; value {
+			return fmt.Errorf("field validation for values failed. '%s' already included in filter", col)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+package pkwrite
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+	"hopsworks.ai/rdrs/internal/common"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+	tu "hopsworks.ai/rdrs/internal/router/handler/utils"
+)
+
+func TestPKWriteOmitRequired(t *testing.T) {
+	router, err := tu.InitRouter(t, []tu.RegisterTestHandler{RegisterPKWriteTestHandler})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	url := tu.NewPKWriteURL("db", "table")
+
+	// Test. Omitting filter should result in 400 error
+	mode := ds.PK_WRITE_MODE_UPSERT
+	param := ds.PKWriteBody{
+		Filters:     nil,
+		Values:      tu.NewValuesKVs("col_0", "val"),
+		Mode:        &mode,
+		OperationID: tu.NewOperationID(64),
+	}
+	body, _ := json.MarshalIndent(param, "", "\t")
+	tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusBadRequest,
+		"Error:Field validation for 'Filters'")
+
+	// Test. Omitting mode should result in 400 error
+	param.Filters = tu.NewFiltersKVs("id0", 1)
+	param.Mode = nil
+	body, _ = json.MarshalIndent(param, "", "\t")
+	tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusBadRequest,
+		"Error:Field validation for 'Mode' failed on the 'required' tag")
+
+	// Test. Wrong mode
+	mode = "replace"
+	param.Mode = &mode
+	body, _ = json.MarshalIndent(param, "", "\t")
+	tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusBadRequest,
+		"Error:Field validation for 'Mode' failed on the 'oneof' tag")
+
+	// Test. Update without values
+	mode = ds.PK_WRITE_MODE_UPDATE
+	param.Values = nil
+	body, _ = json.MarshalIndent(param, "", "\t")
+	tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusBadRequest,
+		"Update requires at least one value")
+}
+
+func TestPKWriteInvalidValues(t *testing.T) {
+	router, err := tu.InitRouter(t, []tu.RegisterTestHandler{RegisterPKWriteTestHandler})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	url := tu.NewPKWriteURL("db", "table")
+
+	// Test. Values and filters overlap
+	mode := ds.PK_WRITE_MODE_UPSERT
+	param := ds.PKWriteBody{
+		Filters:     tu.NewFiltersKVs("id0", 1),
+		Values:      tu.NewValuesKVs("id0", 2),
+		Mode:        &mode,
+		OperationID: tu.NewOperationID(64),
+	}
+	body, _ := json.MarshalIndent(param, "", "\t")
+	tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusBadRequest,
+		"field validation for values failed. 'id0' already included in filter")
+
+	// Test. Invalid value column
+	col := "col" + string(rune(0x0000))
+	param.Values = tu.NewValuesKVs(col, 2)
+	body, _ = json.MarshalIndent(param, "", "\t")
+	tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusBadRequest,
+		fmt.Sprintf("field validation failed. Invalid character '%U' ", rune(0x0000)))
+}
+
+func TestPKWriteModes(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")},
+		[]tu.RegisterTestHandler{RegisterPKWriteTestHandler, pkread.RegisterPKTestHandler},
+		func(router *gin.Engine) {
+			url := tu.NewPKWriteURL("DB025", "table_1")
+
+			// Test. Insert a new row
+			mode := ds.PK_WRITE_MODE_INSERT
+			param := ds.PKWriteBody{
+				Filters:     tu.NewFiltersKVs("id0", 2),
+				Values:      tu.NewValuesKVs("col_0", "insert \"data\"", "col_1", 2, "col_2", 2.5, "col_3", "char"),
+				Mode:        &mode,
+				OperationID: tu.NewOperationID(64),
+			}
+			body, _ := json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusOK, "")
+			checkRow(t, router, "DB025", "table_1", tu.NewFiltersKVs("id0", 2), "col_0", "insert \"data\"")
+
+			// Test. Inserting the same row again should fail
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusConflict, "")
+
+			// Test. Update an existing row
+			mode = ds.PK_WRITE_MODE_UPDATE
+			param.Values = tu.NewValuesKVs("col_0", "updated_data")
+			body, _ = json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusOK, "")
+			checkRow(t, router, "DB025", "table_1", tu.NewFiltersKVs("id0", 2), "col_0", "updated_data")
+
+			// Test. Updating a row that does not exist should fail
+			param.Filters = tu.NewFiltersKVs("id0", 100)
+			body, _ = json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusNotFound, "")
+
+			// Test. Upsert a row that does not exist and then set a column to NULL
+			mode = ds.PK_WRITE_MODE_UPSERT
+			param.Values = tu.NewValuesKVs("col_0", "upserted_data")
+			body, _ = json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusOK, "")
+			checkRow(t, router, "DB025", "table_1", tu.NewFiltersKVs("id0", 100), "col_0", "upserted_data")
+
+			param.Values = tu.NewValuesKVs("col_0", nil)
+			body, _ = json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusOK, "")
+			checkRow(t, router, "DB025", "table_1", tu.NewFiltersKVs("id0", 100), "col_0", nil)
+		})
+}
+
+func TestPKWriteCharKey(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")},
+		[]tu.RegisterTestHandler{RegisterPKWriteTestHandler, pkread.RegisterPKTestHandler},
+		func(router *gin.Engine) {
+			url := tu.NewPKWriteURL("DB025", "table_2")
+
+			// Test. A row written by pk-write is found by pk-read
+			mode := ds.PK_WRITE_MODE_INSERT
+			param := ds.PKWriteBody{
+				Filters: tu.NewFiltersKVs("id0", "rdrs"),
+				Values:  tu.NewValuesKVs("col_0", "rdrs_data"),
+				Mode:    &mode,
+			}
+			body, _ := json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusOK, "")
+			checkRow(t, router, "DB025", "table_2", tu.NewFiltersKVs("id0", "rdrs"), "col_0", "rdrs_data")
+
+			// Test. The key is padded as MySQL pads it, so the row inserted by
+			// MySQL is found by pk-write
+			param.Filters = tu.NewFiltersKVs("id0", "mysql")
+			body, _ = json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusConflict, "")
+
+			mode = ds.PK_WRITE_MODE_UPDATE
+			param.Values = tu.NewValuesKVs("col_0", "updated_data")
+			body, _ = json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusOK, "")
+			checkRow(t, router, "DB025", "table_2", tu.NewFiltersKVs("id0", "mysql"), "col_0", "updated_data")
+		})
+}
+
+func TestPKWriteErrors(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")},
+		[]tu.RegisterTestHandler{RegisterPKWriteTestHandler}, func(router *gin.Engine) {
+			url := tu.NewPKWriteURL("DB025", "table_1")
+
+			// Test. Column does not exist
+			mode := ds.PK_WRITE_MODE_UPSERT
+			param := ds.PKWriteBody{
+				Filters:     tu.NewFiltersKVs("id0", 1),
+				Values:      tu.NewValuesKVs("col_x", 1),
+				Mode:        &mode,
+				OperationID: tu.NewOperationID(64),
+			}
+			body, _ := json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusBadRequest, common.ERROR_012())
+
+			// Test. Wrong data type
+			param.Values = tu.NewValuesKVs("col_1", "abc")
+			body, _ = json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusBadRequest, common.ERROR_015())
+
+			// Test. Only strings are written to VARCHAR and CHAR columns
+			for _, value := range []string{`{"a": 1}`, `["a"]`, `1`, `true`} {
+				for _, col := range []string{"col_0", "col_3"} {
+					body := `{"filters": [{"column": "id0", "value": 1}], "mode": "upsert", "values": {"` + col + `": ` + value + `}}`
+					tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, body, http.StatusBadRequest,
+						common.ERROR_015())
+				}
+			}
+
+			// Test. Setting a not nullable column to NULL
+			param.Values = tu.NewValuesKVs("col_1", nil)
+			body, _ = json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusBadRequest, common.ERROR_029())
+
+			// Test. Writing TEXT columns
+			param.Values = tu.NewValuesKVs("col_4", "text")
+			body, _ = json.MarshalIndent(param, "", "\t")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, url, string(body), http.StatusBadRequest, common.ERROR_030())
+		})
+}
+
+// reads the row using pk-read and checks the value of the column
+func checkRow(t *testing.T, router *gin.Engine, db string, table string, filters *[]ds.Filter,
+	col string, expected interface{}) {
+	t.Helper()
+
+	param := ds.PKReadBody{
+		Filters:     filters,
+		ReadColumns: tu.NewReadColumn(col),
+	}
+	body, _ := json.MarshalIndent(param, "", "\t")
+	url := tu.NewPKReadURL(db, table)
+	_, resp := tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, url, string(body), http.StatusOK, "")
+
+	var result struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		t.Fatalf("Failed to parse response. Error: %v. Body: %s", err, resp)
+	}
+
+	if got := result.Data[col]; got != expected {
+		t.Fatalf("The value for column %s does not match. Expected: %v, Got: %v", col, expected, got)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hopsworks.ai/rdrs/internal/arrow"
+	"hopsworks.ai/rdrs/internal/common"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+)
+
+// arrowPage is a page of an Arrow scan as returned by the data access
+// layer. The rows are MessagePack maps and the schema has the Arrow types
+// of the read columns
+type arrowPage struct {
+	OperationID   *string                  `json:"operationId"`
+	Schema        []arrow.Field            `json:"schema"`
+	Data          []map[string]interface{} `json:"data"`
+	NextPageToken []struct {
+		Column string      `json:"column"`
+		Value  interface{} `json:"value"`
+	} `json:"nextPageToken"`
+}
+
+// arrowScan returns a page of the scan as an Arrow IPC stream with a
+// single record batch. The operation ID and the token of the next page
+// are stored in the metadata of the schema
+func arrowScan(c *gin.Context, scanParams *ds.ScanParams) {
+	scanParams.ResponseFormat = ds.ARROW_MIME
+	resp, code, errResp := scanPage(scanParams)
+	if errResp != nil {
+		common.SetResponseError(c, code, *errResp)
+		return
+	}
+
+	stream, err := arrowStream(*scanParams.DB, *scanParams.Table, resp.body)
+	if err != nil {
+		common.SetResponseError(c, http.StatusInternalServerError,
+			common.ErrorResponse{Error: fmt.Sprintf("Failed to create the Arrow response. Error: %v", err)})
+		return
+	}
+	common.SetFormattedResponse(c, http.StatusOK, ds.ARROW_MIME, string(stream))
+}
+
+func arrowStream(db string, table string, resp string) ([]byte, error) {
+	page := arrowPage{}
+	if err := common.DecodeMsgPackResponse(resp, &page); err != nil {
+		return nil, err
+	}
+
+	builder, err := arrow.NewRecordBuilder(page.Schema)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(page.Schema))
+	for _, row := range page.Data {
+		for i, field := range page.Schema {
+			values[i] = row[field.Name]
+		}
+		if err := builder.Append(values); err != nil {
+			return nil, err
+		}
+	}
+
+	metadata := map[string]string{}
+	if page.OperationID != nil {
+		metadata["operationId"] = *page.OperationID
+	}
+	if page.NextPageToken != nil {
+		// the key is signed as JSON, like the keys of JSON responses. Binary
+		// values are encoded as base64 strings
+		key, err := json.Marshal(page.NextPageToken)
+		if err != nil {
+			return nil, err
+		}
+		metadata["nextPageToken"] = pageToken(db, table, key)
+	}
+	return builder.Stream(metadata), nil
+}
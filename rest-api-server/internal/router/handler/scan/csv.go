@@ -0,0 +1,89 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scan
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/csv"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+)
+
+// csvStream sends the rows as comma separated values. The pages are read
+// as Arrow pages, as these have the read columns in order even if there
+// are no rows. The header record is sent with the first page
+type csvStream struct {
+	headerSent bool
+}
+
+func (s *csvStream) prepare(scanParams *ds.ScanParams) {
+	scanParams.ResponseFormat = ds.CSV_MIME
+}
+
+func (s *csvStream) start(c *gin.Context) {
+	common.StartStreamedResponse(c, ds.CSV_MIME)
+}
+
+func (s *csvStream) rows(resp *scanResponse) (string, uint32, *[]ds.Filter, error) {
+	page := arrowPage{}
+	if err := common.DecodeMsgPackResponse(resp.body, &page); err != nil {
+		return "", 0, nil, err
+	}
+
+	w := csv.Writer{}
+	if !s.headerSent {
+		names := make([]string, len(page.Schema))
+		for i, field := range page.Schema {
+			names[i] = field.Name
+		}
+		w.WriteHeader(names)
+		s.headerSent = true
+	}
+
+	values := make([]interface{}, len(page.Schema))
+	for _, row := range page.Data {
+		for i, field := range page.Schema {
+			values[i] = row[field.Name]
+		}
+		if err := w.Write(values); err != nil {
+			return "", 0, nil, err
+		}
+	}
+
+	if page.NextPageToken == nil {
+		return w.String(), uint32(len(page.Data)), nil, nil
+	}
+	// binary key columns are base64 encoded, as in the keys of JSON pages
+	key := make([]ds.Filter, len(page.NextPageToken))
+	for i, keyColumn := range page.NextPageToken {
+		value, err := json.Marshal(keyColumn.Value)
+		if err != nil {
+			return "", 0, nil, err
+		}
+		column := keyColumn.Column
+		rawValue := json.RawMessage(value)
+		key[i] = ds.Filter{Column: &column, Value: &rawValue}
+	}
+	return w.String(), uint32(len(page.Data)), &key, nil
+}
+
+func (s *csvStream) fail(c *gin.Context, resp common.ErrorResponse) {
+	common.AbortStreamedResponse(c, resp)
+}
@@ -0,0 +1,365 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scan
+
+/*
+#include "./../../../../../data-access-rondb/src/rdrs-const.h"
+#include "./../../../../../data-access-rondb/src/rdrs-dal.h"
+*/
+import "C"
+import (
+	"fmt"
+	"unsafe"
+
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/mysql"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+)
+
+//  SCAN Request
+//  ============
+//
+//  The request uses the same header as the PK read request, see
+//  internal/router/handler/pkread/encoding.go. The PK, TX_ID, Values and
+//  Lock Mode fields are not used. Read columns and the operation ID are
+//  stored the same way as for the PK read request.
+//
+//  HEADER
+//  ======
+//  [   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ]
+//    Type     Capacity  Length     DB         Table      PK     Read Cols    Op_ID    TX_ID    Values    Lock     Filter    Limit
+//                               Offset      Offset    Offset     Offset     Offset    Offset   Offset    Mode     Offset
+//
+//  [   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ][   4B   ]
+//    Index     Lower     Upper      Scan      Blob     Response    Time      Time     Column   Response
+//    Offset    Bound     Bound      Flags     Offset    Flags     Format     Zone     Types     Format
+//              Offset    Offset                                                      Offset
+//
+//  Limit is 0 if all the rows are returned. The index fields are only
+//  set for ordered index scans. The bounds are stored the same way as
+//  the primary key columns of the PK read request. Table scans set the
+//  paginate flag and use the primary key index with a lower bound to
+//  resume the scan from a page token, see page_token.go. The NDJSON flag
+//  writes one row per line instead of a JSON object, see streamScan.
+//  Blob offset is only used by the blob read request. The response flags
+//  and the temporal format are the same as for the PK read request. Bound
+//  and filter values of temporal columns are read in the temporal format.
+//  Column types are stored the same way as for the PK read request.
+//  Arrow scans set the response format, the rows are then returned as
+//  MessagePack with the Arrow types of the columns, see arrowScan
+//
+//  FILTER
+//  ======
+//  The filter tree is stored in pre-order. Every node is three words
+//
+//  [   4B   ][   4B   ][   4B   ][   4B   ] ... [   4B   ][   4B   ][   4B   ][ bytes ... ]
+//    Count      Type      Arg 1     Arg 2          Type      Arg 1     Arg 2
+//
+//  and/or/not:  Arg 1 is the number of children. The children follow the node
+//  comparisons: Arg 1 is the column name offset and Arg 2 is the value offset
+//  isnull:      Arg 1 is the column name offset
+//
+//  Column names are null terminated and values are stored as
+//  [ 2B size ][ bytes ... ] similar to the primary key values
+//
+//  RESPONSE
+//  ========
+//  [   4B   ][   4B   ][   4B   ][   4B   ][ bytes ... ]
+//    Length    Next Key  Next Key             response
+//              Offset    Length
+//
+//  The next key is the primary key of the first row of the next page in
+//  JSON and NDJSON responses, see page_token.go. Its offset is relative to
+//  the start of the response and its length is 0 if there is no next page
+//
+
+const filterNodeSize = 3
+
+func CreateNativeRequest(params *ds.ScanParams) (*dal.NativeBuffer, *dal.NativeBuffer, error) {
+	tzOffset, err := pkread.TimeZoneOffset(params.TimeZone)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := dal.GetBuffer()
+	request := dal.GetBuffer()
+	iBuf := unsafe.Slice((*uint32)(request.Buffer), request.Size)
+
+	// First N bytes are for header
+	var head uint32 = C.PKR_HEADER_END
+
+	dbOffSet := head
+	head, err = common.CopyGoStrToCStr([]byte(*params.DB), request, head)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tableOffSet := head
+	head, err = common.CopyGoStrToCStr([]byte(*params.Table), request, head)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Filter
+	head = common.AlignWord(head)
+	var filterOffset uint32 = 0
+	if params.Filter != nil {
+		filterOffset = head
+		head, err = encodeFilter(params.Filter, request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Index and bounds
+	var indexOffset uint32 = 0
+	var lowerBoundOffset uint32 = 0
+	var upperBoundOffset uint32 = 0
+	if params.Index != nil {
+		indexOffset = head
+		head, err = common.CopyGoStrToCStr([]byte(*params.Index), request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if params.LowerBound != nil {
+			head = common.AlignWord(head)
+			lowerBoundOffset = head
+			head, err = pkread.EncodePKFilters(params.LowerBound.Values, request, head)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if params.UpperBound != nil {
+			head = common.AlignWord(head)
+			upperBoundOffset = head
+			head, err = pkread.EncodePKFilters(params.UpperBound.Values, request, head)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	// Read Columns
+	head = common.AlignWord(head)
+	var readColsOffset uint32 = 0
+	if params.ReadColumns != nil {
+		readColsOffset = head
+		head, err = pkread.EncodeReadColumns(params.ReadColumns, request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Operation ID
+	var opIdOffset uint32 = 0
+	if params.OperationID != nil {
+		opIdOffset = head
+		head, err = common.CopyGoStrToCStr([]byte(*params.OperationID), request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var limit uint32 = 0
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	// Column types
+	head = common.AlignWord(head)
+	var colTypesOffset uint32 = 0
+	colTypes, err := mysql.ColumnTypes(*params.DB, *params.Table)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(colTypes) > 0 {
+		colTypesOffset = head
+		head, err = pkread.EncodeColumnTypes(colTypes, request, head)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// request buffer header
+	opType := uint32(C.RDRS_SCAN_REQ_ID)
+	if params.Index != nil {
+		opType = C.RDRS_INDEX_SCAN_REQ_ID
+	}
+
+	iBuf[C.PKR_OP_TYPE_IDX] = opType
+	iBuf[C.PKR_CAPACITY_IDX] = uint32(request.Size)
+	iBuf[C.PKR_LENGTH_IDX] = uint32(head)
+	iBuf[C.PKR_DB_IDX] = uint32(dbOffSet)
+	iBuf[C.PKR_TABLE_IDX] = uint32(tableOffSet)
+	iBuf[C.PKR_PK_COLS_IDX] = 0
+	iBuf[C.PKR_READ_COLS_IDX] = uint32(readColsOffset)
+	iBuf[C.PKR_OP_ID_IDX] = uint32(opIdOffset)
+	iBuf[C.PKR_TX_ID_IDX] = 0
+	iBuf[C.PKR_VALUES_IDX] = 0
+	iBuf[C.PKR_LOCK_MODE_IDX] = 0
+	iBuf[C.PKR_FILTER_IDX] = uint32(filterOffset)
+	iBuf[C.PKR_LIMIT_IDX] = limit
+	iBuf[C.PKR_INDEX_IDX] = indexOffset
+	iBuf[C.PKR_LOWER_BOUND_IDX] = lowerBoundOffset
+	iBuf[C.PKR_UPPER_BOUND_IDX] = upperBoundOffset
+	iBuf[C.PKR_SCAN_FLAGS_IDX] = scanFlags(params)
+	iBuf[C.PKR_RESP_FLAGS_IDX] = pkread.ResponseFlags(params.BigNumbersAsStrings, params.OmitNulls, nil)
+	iBuf[C.PKR_TIME_FORMAT_IDX] = pkread.TemporalFormat(params.TemporalFormat)
+	iBuf[C.PKR_TIME_ZONE_IDX] = uint32(tzOffset)
+	iBuf[C.PKR_COL_TYPES_IDX] = colTypesOffset
+	iBuf[C.PKR_RESP_FORMAT_IDX] = pkread.ResponseFormat(params.ResponseFormat)
+
+	return request, response, nil
+}
+
+// encodeFilter writes the filter tree at the given word aligned location
+// in the request buffer
+func encodeFilter(filter *ds.ScanFilter, request *dal.NativeBuffer, head uint32) (uint32, error) {
+	iBuf := unsafe.Slice((*uint32)(request.Buffer), request.Size)
+
+	nodes := flattenFilter(filter, nil)
+	if head+uint32(1+len(nodes)*filterNodeSize)*C.ADDRESS_SIZE > request.Size {
+		return 0, fmt.Errorf("Trying to write more data than the buffer capacity")
+	}
+
+	iBuf[head/C.ADDRESS_SIZE] = uint32(len(nodes))
+	head += C.ADDRESS_SIZE
+
+	ni := head / C.ADDRESS_SIZE // index of the first node
+	head += uint32(len(nodes)*filterNodeSize) * C.ADDRESS_SIZE
+
+	for _, node := range nodes {
+		nodeType, err := filterNodeType(node.Op)
+		if err != nil {
+			return 0, err
+		}
+		iBuf[ni] = nodeType
+
+		switch *node.Op {
+		case ds.SCAN_FILTER_AND, ds.SCAN_FILTER_OR, ds.SCAN_FILTER_NOT:
+			iBuf[ni+1] = uint32(len(*node.Filters))
+			iBuf[ni+2] = 0
+		default:
+			iBuf[ni+1] = head
+			head, err = common.CopyGoStrToCStr([]byte(*node.Column), request, head)
+			if err != nil {
+				return 0, err
+			}
+
+			iBuf[ni+2] = 0
+			if node.Value != nil {
+				iBuf[ni+2] = head
+				head, err = common.CopyJSONValueToNDBStr(*node.Value, request, head)
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+		ni += filterNodeSize
+	}
+	return head, nil
+}
+
+func scanFlags(params *ds.ScanParams) uint32 {
+	var flags uint32 = 0
+	if params.LowerBound == nil || params.LowerBound.Inclusive == nil || *params.LowerBound.Inclusive {
+		flags |= C.RDRS_ISF_LOWER_INCLUSIVE
+	}
+	if params.UpperBound == nil || params.UpperBound.Inclusive == nil || *params.UpperBound.Inclusive {
+		flags |= C.RDRS_ISF_UPPER_INCLUSIVE
+	}
+	if params.Order != nil && *params.Order == ds.SCAN_ORDER_DESC {
+		flags |= C.RDRS_ISF_DESCENDING
+	}
+	if params.Paginate {
+		flags |= C.RDRS_ISF_PAGINATE
+	}
+	if params.NDJSON {
+		flags |= C.RDRS_ISF_NDJSON
+	}
+	return flags
+}
+
+// flattenFilter returns the nodes of the filter tree in pre-order
+func flattenFilter(filter *ds.ScanFilter, nodes []*ds.ScanFilter) []*ds.ScanFilter {
+	nodes = append(nodes, filter)
+	if filter.Filters != nil {
+		for i := range *filter.Filters {
+			nodes = flattenFilter(&(*filter.Filters)[i], nodes)
+		}
+	}
+	return nodes
+}
+
+func filterNodeType(op *string) (uint32, error) {
+	switch *op {
+	case ds.SCAN_FILTER_AND:
+		return C.RDRS_SF_AND, nil
+	case ds.SCAN_FILTER_OR:
+		return C.RDRS_SF_OR, nil
+	case ds.SCAN_FILTER_NOT:
+		return C.RDRS_SF_NOT, nil
+	case ds.SCAN_FILTER_EQ:
+		return C.RDRS_SF_EQ, nil
+	case ds.SCAN_FILTER_NE:
+		return C.RDRS_SF_NE, nil
+	case ds.SCAN_FILTER_LT:
+		return C.RDRS_SF_LT, nil
+	case ds.SCAN_FILTER_LE:
+		return C.RDRS_SF_LE, nil
+	case ds.SCAN_FILTER_GT:
+		return C.RDRS_SF_GT, nil
+	case ds.SCAN_FILTER_GE:
+		return C.RDRS_SF_GE, nil
+	case ds.SCAN_FILTER_ISNULL:
+		return C.RDRS_SF_ISNULL, nil
+	default:
+		return 0, fmt.Errorf("Filter operator is not supported. Operator: %s", *op)
+	}
+}
+
+// scanResponse is a page of a scan as returned by the data access layer
+type scanResponse struct {
+	body string
+	// location of the primary key of the next page in JSON and NDJSON
+	// bodies. keyLength is 0 if there is no next page
+	keyOffset uint32
+	keyLength uint32
+}
+
+// readResponse returns the response that follows the header of the
+// response buffer
+func readResponse(response *dal.NativeBuffer, format string) *scanResponse {
+	header := unsafe.Slice((*uint32)(response.Buffer), C.SCAN_RESP_HEADER_END/4)
+	body := unsafe.Add(response.Buffer, C.SCAN_RESP_HEADER_END)
+
+	resp := scanResponse{
+		keyOffset: header[C.SCAN_RESP_NEXT_KEY_OFFSET_IDX],
+		keyLength: header[C.SCAN_RESP_NEXT_KEY_LENGTH_IDX],
+	}
+	if format == "" {
+		resp.body = C.GoStringN((*C.char)(body), C.int(header[C.SCAN_RESP_LENGTH_IDX]))
+	} else {
+		resp.body = common.ProcessFormattedResponse(body, format)
+	}
+	return &resp
+}
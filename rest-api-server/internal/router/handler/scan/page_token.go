@@ -0,0 +1,152 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scan
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"hopsworks.ai/rdrs/internal/config"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/log"
+)
+
+// The data access layer ends the response of a paginated scan with the
+// primary key of the first row of the next page, i.e.,
+//
+//   ..., "nextPageToken": [{"column": "id0", "value": 5}]}
+//
+// The location of the key is set in the header of the response. The key
+// is replaced by an opaque token. The token is the base64 encoded key and
+// its HMAC, which also covers the database and the table, so that clients
+// can neither forge keys nor use a token on another table.
+
+var tokenKey []byte
+var tokenKeyOnce sync.Once
+
+func pageTokenKey() []byte {
+	tokenKeyOnce.Do(func() {
+		key := config.Configuration().RestServer.ScanPageTokenKey
+		if key != "" {
+			tokenKey = []byte(key)
+			return
+		}
+
+		tokenKey = make([]byte, 32)
+		if _, err := rand.Read(tokenKey); err != nil {
+			log.Panicf("Failed to generate the scan page token key. Error: %v", err)
+		}
+	})
+	return tokenKey
+}
+
+func pageTokenMAC(db string, table string, key []byte) []byte {
+	mac := hmac.New(sha256.New, pageTokenKey())
+	mac.Write([]byte(db))
+	mac.Write([]byte{0})
+	mac.Write([]byte(table))
+	mac.Write([]byte{0})
+	mac.Write(key)
+	return mac.Sum(nil)
+}
+
+// signNextPageToken replaces the key at the end of the response with the signed token
+func signNextPageToken(db string, table string, resp *scanResponse) string {
+	if resp.keyLength == 0 {
+		return resp.body
+	}
+
+	start := resp.keyOffset
+	end := resp.keyOffset + resp.keyLength
+	key := []byte(resp.body[start:end])
+	return resp.body[:start] + `"` + pageToken(db, table, key) + `"` + resp.body[end:]
+}
+
+// pageToken returns the signed token of the JSON encoded key
+func pageToken(db string, table string, key []byte) string {
+	return base64.RawURLEncoding.EncodeToString(key) + "." +
+		base64.RawURLEncoding.EncodeToString(pageTokenMAC(db, table, key))
+}
+
+// parsePageToken verifies the token and returns the primary key of the first row of the page
+func parsePageToken(db string, table string, token string) (*[]ds.Filter, error) {
+	invalid := fmt.Errorf("field validation for 'PageToken' failed. Invalid page token")
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, invalid
+	}
+
+	key, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, invalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, invalid
+	}
+
+	if !hmac.Equal(mac, pageTokenMAC(db, table, key)) {
+		return nil, invalid
+	}
+
+	filters, err := decodePageKey(key)
+	if err != nil {
+		return nil, invalid
+	}
+	return filters, nil
+}
+
+// splitNextPageKey removes the primary key of the next page from an NDJSON
+// page. The key is sent as the last line, i.e.,
+//
+//	{"nextPageToken": [{"column": "id0", "value": 5}]}
+//
+// The rows are JSON objects, so they do not contain line breaks
+func splitNextPageKey(page *scanResponse) (string, *[]ds.Filter, error) {
+	if page.keyLength == 0 {
+		return page.body, nil, nil
+	}
+
+	key := page.body[page.keyOffset : page.keyOffset+page.keyLength]
+	filters, err := decodePageKey([]byte(key))
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to read the next page key. Error: %v", err)
+	}
+	lineStart := strings.LastIndex(page.body[:page.keyOffset], "\n") + 1
+	return page.body[:lineStart], filters, nil
+}
+
+func decodePageKey(key []byte) (*[]ds.Filter, error) {
+	filters := []ds.Filter{}
+	decoder := json.NewDecoder(bytes.NewReader(key))
+	if err := decoder.Decode(&filters); err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("empty key")
+	}
+	return &filters, nil
+}
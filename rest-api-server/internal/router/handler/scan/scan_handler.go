@@ -0,0 +1,427 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scan
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/log"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+)
+
+func RegisterScanTestHandler(e *gin.Engine) {
+	group := e.Group(ds.DB_OPS_EP_GROUP)
+	group.POST(ds.SCAN_OPERATION, ScanHandler)
+	group.POST(ds.INDEX_SCAN_EP, IndexScanHandler)
+}
+
+func ScanHandler(c *gin.Context) {
+	scanParams := ds.ScanParams{}
+
+	err := parseRequest(c, &scanParams)
+	if err != nil {
+		if log.IsDebug() {
+			body, _ := ioutil.ReadAll(c.Request.Body)
+			log.Debugf("Unable to parse request. Error: %v. Body: %s\n", err, body)
+		}
+		common.SetResponseError(c, http.StatusBadRequest, common.ErrorResponse{Error: fmt.Sprintf("%-v", err)})
+		return
+	}
+
+	performScan(c, &scanParams)
+}
+
+func IndexScanHandler(c *gin.Context) {
+	scanParams := ds.ScanParams{}
+
+	err := parseIndexScanRequest(c, &scanParams)
+	if err != nil {
+		if log.IsDebug() {
+			body, _ := ioutil.ReadAll(c.Request.Body)
+			log.Debugf("Unable to parse request. Error: %v. Body: %s\n", err, body)
+		}
+		common.SetResponseError(c, http.StatusBadRequest, common.ErrorResponse{Error: fmt.Sprintf("%-v", err)})
+		return
+	}
+
+	performScan(c, &scanParams)
+}
+
+// maximum number of rows read per page when the rows are streamed. If it
+// is 0 then a page contains as many rows as fit in the response buffer
+var streamPageRows uint32 = 0
+
+// performScan runs the table or index scan and writes the response
+func performScan(c *gin.Context, scanParams *ds.ScanParams) {
+	if common.AcceptsArrow(c) {
+		arrowScan(c, scanParams)
+		return
+	}
+
+	if common.AcceptsCSV(c) {
+		streamScan(c, scanParams, &csvStream{})
+		return
+	}
+
+	if common.AcceptsNDJSON(c) {
+		// index scans are not paginated. The rows of an index key are not
+		// ordered by the primary key, so the scan can not be resumed and the
+		// rows are read in one page. The limit bounds the rows of the page
+		if scanParams.Index != nil && scanParams.Limit == nil {
+			common.SetResponseError(c, http.StatusNotAcceptable, common.ErrorResponse{
+				Error: "Index scans without a limit can not be streamed as NDJSON. Set the limit and move the bound to the last row read"})
+			return
+		}
+		streamScan(c, scanParams, ndjsonStream{})
+		return
+	}
+
+	resp, code, errResp := scanPage(scanParams)
+	if errResp != nil {
+		common.SetResponseError(c, code, *errResp)
+		return
+	}
+
+	c.Writer.WriteHeader(http.StatusOK)
+	c.Writer.Write(([]byte)(signNextPageToken(*scanParams.DB, *scanParams.Table, resp)))
+}
+
+// pageStream converts the pages of a streamed scan to the format of the
+// response
+type pageStream interface {
+	// prepare sets the format of the pages returned by the data access layer
+	prepare(scanParams *ds.ScanParams)
+	// start sends the headers of the response
+	start(c *gin.Context)
+	// rows returns the rows of a page in the format of the response, the
+	// number of rows and the primary key of the next page, if any
+	rows(page *scanResponse) (string, uint32, *[]ds.Filter, error)
+	// fail ends the response with an error after the first page was sent
+	fail(c *gin.Context, resp common.ErrorResponse)
+}
+
+// ndjsonStream sends the rows as newline delimited JSON
+type ndjsonStream struct{}
+
+func (ndjsonStream) prepare(scanParams *ds.ScanParams) {
+	scanParams.NDJSON = true
+}
+
+func (ndjsonStream) start(c *gin.Context) {
+	common.StartStreamedResponse(c, ds.NDJSON_MIME)
+}
+
+func (ndjsonStream) rows(page *scanResponse) (string, uint32, *[]ds.Filter, error) {
+	rows, key, err := splitNextPageKey(page)
+	return rows, uint32(strings.Count(rows, "\n")), key, err
+}
+
+func (ndjsonStream) fail(c *gin.Context, resp common.ErrorResponse) {
+	common.SetNDJSONError(c, resp)
+}
+
+// streamScan sends the rows in the format of the stream. Paginated scans
+// are read page by page. A page is sent to the client before the next
+// page is read, so that only one response buffer is used for the whole
+// scan. Index scans can not be resumed, so they are read in one page. The
+// limit, if any, is the total number of rows
+func streamScan(c *gin.Context, scanParams *ds.ScanParams, stream pageStream) {
+	stream.prepare(scanParams)
+
+	var limit uint32 = 0
+	if scanParams.Limit != nil {
+		limit = *scanParams.Limit
+	}
+	resumable := scanParams.Index == nil
+
+	var rows uint32 = 0
+	started := false
+	for {
+		var pageRows uint32 = 0
+		if limit != 0 {
+			pageRows = limit - rows
+		}
+		if resumable && streamPageRows != 0 && (pageRows == 0 || streamPageRows < pageRows) {
+			pageRows = streamPageRows
+		}
+		scanParams.Limit = &pageRows
+
+		resp, code, errResp := scanPage(scanParams)
+		var page string
+		var key *[]ds.Filter
+		var n uint32
+		if errResp == nil {
+			var err error
+			page, n, key, err = stream.rows(resp)
+			if err != nil {
+				code = http.StatusInternalServerError
+				errResp = &common.ErrorResponse{Error: fmt.Sprintf("%v", err)}
+			}
+		}
+
+		if errResp != nil {
+			if started {
+				stream.fail(c, *errResp)
+			} else {
+				common.SetResponseError(c, code, *errResp)
+			}
+			return
+		}
+
+		if !started {
+			stream.start(c)
+			started = true
+		}
+		common.WriteStreamed(c, page)
+
+		rows += n
+		if key == nil || (limit != 0 && rows >= limit) {
+			return
+		}
+
+		// continue from the first row of the next page
+		index := ds.PRIMARY_INDEX
+		inclusive := true
+		scanParams.Index = &index
+		scanParams.LowerBound = &ds.IndexBound{Values: key, Inclusive: &inclusive}
+	}
+}
+
+// scanPage runs the scan and returns the response. The native buffers are
+// returned to the pool before the response is sent to the client
+func scanPage(scanParams *ds.ScanParams) (*scanResponse, int, *common.ErrorResponse) {
+	request, response, err := CreateNativeRequest(scanParams)
+	if err != nil {
+		return nil, http.StatusInternalServerError, &common.ErrorResponse{Error: fmt.Sprintf("%v", err)}
+	}
+	defer dal.ReturnBuffer(request)
+	defer dal.ReturnBuffer(response)
+
+	dalErr := dal.RonDBScan(request, response)
+	if dalErr != nil {
+		var message string
+		if dalErr.HttpCode >= http.StatusInternalServerError {
+			message = fmt.Sprintf("%v File: %v, Line: %v ", dalErr.Message, dalErr.ErrFileName, dalErr.ErrLineNo)
+		} else {
+			message = fmt.Sprintf("%v", dalErr.Message)
+		}
+		return nil, dalErr.HttpCode, &common.ErrorResponse{Error: message}
+	}
+
+	return readResponse(response, scanParams.ResponseFormat), http.StatusOK, nil
+}
+
+func parseRequest(c *gin.Context, scanParams *ds.ScanParams) error {
+
+	body := ds.ScanBody{}
+	pp := ds.PKReadPP{}
+
+	if err := pkread.ParseURI(c, &pp); err != nil {
+		return err
+	}
+
+	b := binding.JSON
+	if err := b.Bind(c.Request, &body); err != nil {
+		return err
+	}
+
+	if err := ValidateBody(&body); err != nil {
+		return err
+	}
+
+	scanParams.DB = pp.DB
+	scanParams.Table = pp.Table
+	scanParams.Filter = body.Filter
+	scanParams.ReadColumns = body.ReadColumns
+	scanParams.Limit = body.Limit
+	scanParams.OperationID = body.OperationID
+	scanParams.BigNumbersAsStrings = body.BigNumbersAsStrings
+	scanParams.TemporalFormat = body.TemporalFormat
+	scanParams.TimeZone = body.TimeZone
+	scanParams.OmitNulls = body.OmitNulls
+	scanParams.Paginate = true
+
+	// the next page starts at the key stored in the token
+	if body.PageToken != nil {
+		key, err := parsePageToken(*pp.DB, *pp.Table, *body.PageToken)
+		if err != nil {
+			return err
+		}
+
+		index := ds.PRIMARY_INDEX
+		inclusive := true
+		scanParams.Index = &index
+		scanParams.LowerBound = &ds.IndexBound{Values: key, Inclusive: &inclusive}
+	}
+	return nil
+}
+
+func parseIndexScanRequest(c *gin.Context, scanParams *ds.ScanParams) error {
+
+	body := ds.IndexScanBody{}
+	pp := ds.IndexScanPP{}
+
+	if err := c.ShouldBindUri(&pp); err != nil {
+		return err
+	}
+
+	for _, identifier := range []string{*pp.DB, *pp.Table, *pp.Index} {
+		if err := pkread.ValidateDBIdentifier(identifier); err != nil {
+			return err
+		}
+	}
+
+	b := binding.JSON
+	if err := b.Bind(c.Request, &body); err != nil {
+		return err
+	}
+
+	if err := ValidateIndexScanBody(&body); err != nil {
+		return err
+	}
+
+	scanParams.DB = pp.DB
+	scanParams.Table = pp.Table
+	scanParams.Index = pp.Index
+	scanParams.LowerBound = body.LowerBound
+	scanParams.UpperBound = body.UpperBound
+	scanParams.Order = body.Order
+	scanParams.Filter = body.Filter
+	scanParams.ReadColumns = body.ReadColumns
+	scanParams.Limit = body.Limit
+	scanParams.OperationID = body.OperationID
+	scanParams.BigNumbersAsStrings = body.BigNumbersAsStrings
+	scanParams.TemporalFormat = body.TemporalFormat
+	scanParams.TimeZone = body.TimeZone
+	scanParams.OmitNulls = body.OmitNulls
+	return nil
+}
+
+func ValidateBody(params *ds.ScanBody) error {
+	// rows are objects in an array, so there is no place for a mask next
+	// to a row. omitNulls leaves the NULL columns out instead
+	if params.IncludeNullMask != nil {
+		return fmt.Errorf("field validation failed. 'includeNullMask' is not supported by scans. Use 'omitNulls' instead")
+	}
+
+	if params.Filter != nil {
+		if err := validateFilter(params.Filter); err != nil {
+			return err
+		}
+	}
+
+	if _, err := pkread.TimeZoneOffset(params.TimeZone); err != nil {
+		return err
+	}
+
+	return validateReadColumns(params.ReadColumns)
+}
+
+func ValidateIndexScanBody(params *ds.IndexScanBody) error {
+	for _, bound := range []*ds.IndexBound{params.LowerBound, params.UpperBound} {
+		if bound == nil {
+			continue
+		}
+
+		// the data access layer checks that the columns are a prefix of the index columns
+		existingCols := make(map[string]bool)
+		for _, value := range *bound.Values {
+			if err := pkread.ValidateDBIdentifier(*value.Column); err != nil {
+				return err
+			}
+
+			if _, ok := existingCols[*value.Column]; ok {
+				return fmt.Errorf("field validation for bound failed on the 'unique' tag")
+			}
+			existingCols[*value.Column] = true
+		}
+	}
+
+	return ValidateBody(&ds.ScanBody{Filter: params.Filter, ReadColumns: params.ReadColumns,
+		TimeZone: params.TimeZone, IncludeNullMask: params.IncludeNullMask})
+}
+
+// make sure read columns are valid and unique
+func validateReadColumns(readColumns *[]ds.ReadColumn) error {
+	if readColumns != nil {
+		existingCols := make(map[string]bool)
+		for i := range *readColumns {
+			col := &(*readColumns)[i]
+			if err := pkread.ValidateDBIdentifier(*col.Column); err != nil {
+				return err
+			}
+			if err := pkread.ValidateDataReturnType(col); err != nil {
+				return err
+			}
+
+			if _, value := existingCols[*col.Column]; value {
+				return fmt.Errorf("field validation for 'ReadColumns' failed on the 'unique' tag.")
+			} else {
+				existingCols[*col.Column] = true
+			}
+		}
+	}
+	return nil
+}
+
+func validateFilter(filter *ds.ScanFilter) error {
+	switch *filter.Op {
+	case ds.SCAN_FILTER_AND, ds.SCAN_FILTER_OR, ds.SCAN_FILTER_NOT:
+		if filter.Column != nil || filter.Value != nil {
+			return fmt.Errorf("field validation for filter failed. '%s' filter does not take a column or a value", *filter.Op)
+		}
+		if filter.Filters == nil {
+			return fmt.Errorf("field validation for filter failed. '%s' filter requires nested filters", *filter.Op)
+		}
+		if *filter.Op == ds.SCAN_FILTER_NOT && len(*filter.Filters) != 1 {
+			return fmt.Errorf("field validation for filter failed. '%s' filter takes exactly one nested filter", *filter.Op)
+		}
+		for i := range *filter.Filters {
+			if err := validateFilter(&(*filter.Filters)[i]); err != nil {
+				return err
+			}
+		}
+	default:
+		if filter.Filters != nil {
+			return fmt.Errorf("field validation for filter failed. '%s' filter does not take nested filters", *filter.Op)
+		}
+		if filter.Column == nil {
+			return fmt.Errorf("field validation for filter failed. '%s' filter requires a column", *filter.Op)
+		}
+		if err := pkread.ValidateDBIdentifier(*filter.Column); err != nil {
+			return err
+		}
+
+		if *filter.Op == ds.SCAN_FILTER_ISNULL {
+			if filter.Value != nil {
+				return fmt.Errorf("field validation for filter failed. '%s' filter does not take a value", *filter.Op)
+			}
+		} else if filter.Value == nil || string(*filter.Value) == "null" {
+			return fmt.Errorf("field validation for filter failed. '%s' filter requires a non null value", *filter.Op)
+		}
+	}
+	return nil
+}
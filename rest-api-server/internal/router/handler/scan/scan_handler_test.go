@@ -0,0 +1,533 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package scan
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+	"hopsworks.ai/rdrs/internal/common"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	tu "hopsworks.ai/rdrs/internal/router/handler/utils"
+)
+
+func TestScanValidation(t *testing.T) {
+	router, err := tu.InitRouter(t, []tu.RegisterTestHandler{RegisterScanTestHandler})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	url := tu.NewScanURL("db", "table")
+
+	tests := map[string]struct {
+		body string
+		msg  string
+	}{
+		"unknown op":         {`{"filter": {"op": "like", "column": "c", "value": 1}}`, "Error:Field validation for 'Op'"},
+		"missing column":     {`{"filter": {"op": "eq", "value": 1}}`, "requires a column"},
+		"missing value":      {`{"filter": {"op": "lt", "column": "c"}}`, "requires a non null value"},
+		"null value":         {`{"filter": {"op": "eq", "column": "c", "value": null}}`, "requires a non null value"},
+		"isnull with value":  {`{"filter": {"op": "isnull", "column": "c", "value": 1}}`, "does not take a value"},
+		"and without nested": {`{"filter": {"op": "and"}}`, "requires nested filters"},
+		"and with column":    {`{"filter": {"op": "and", "column": "c", "filters": [{"op": "isnull", "column": "c"}]}}`, "does not take a column"},
+		"not with two":       {`{"filter": {"op": "not", "filters": [{"op": "isnull", "column": "c"}, {"op": "isnull", "column": "d"}]}}`, "exactly one nested filter"},
+		"nested in cmp":      {`{"filter": {"op": "eq", "column": "c", "value": 1, "filters": [{"op": "isnull", "column": "c"}]}}`, "does not take nested filters"},
+		"zero limit":         {`{"limit": 0}`, "Error:Field validation for 'Limit'"},
+		"duplicate columns":  {`{"readColumns": [{"column": "c"}, {"column": "c"}]}`, "Error:Field validation for 'ReadColumns'"},
+		"temporal format":    {`{"temporalFormat": "iso8601"}`, "Error:Field validation for 'TemporalFormat'"},
+		"time zone":          {`{"timeZone": "Europe/Stockholm"}`, "time zone is not supported"},
+		"null mask":          {`{"includeNullMask": true}`, "'includeNullMask' is not supported by scans"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, url, test.body, http.StatusBadRequest, test.msg)
+		})
+	}
+
+	// Test. Index scans can not be resumed, so they are only streamed with a limit
+	tu.ProcessNDJSONRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewIndexScanURL("db", "table", "idx"), `{}`,
+		http.StatusNotAcceptable, "Index scans without a limit can not be streamed as NDJSON")
+}
+
+func TestScan(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterScanTestHandler},
+		func(router *gin.Engine) {
+			// Test. Full table scan
+			scan(t, router, `{}`, []int{1, 2, 3, 4, 5})
+
+			// Test. Comparisons
+			scan(t, router, `{"filter": {"op": "eq", "column": "col_0", "value": "red"}}`, []int{1, 4})
+			scan(t, router, `{"filter": {"op": "ne", "column": "col_1", "value": 10}}`, []int{2, 3, 4})
+			scan(t, router, `{"filter": {"op": "lt", "column": "col_1", "value": 30}}`, []int{1, 2})
+			scan(t, router, `{"filter": {"op": "le", "column": "col_1", "value": 30}}`, []int{1, 2, 3})
+			scan(t, router, `{"filter": {"op": "gt", "column": "col_2", "value": 2.5}}`, []int{3, 4})
+			scan(t, router, `{"filter": {"op": "ge", "column": "col_3", "value": "c"}}`, []int{3, 4})
+			scan(t, router, `{"filter": {"op": "isnull", "column": "col_0"}}`, []int{5})
+
+			// Test. Logical operators
+			scan(t, router, `{"filter": {"op": "and", "filters": [
+				{"op": "eq", "column": "col_0", "value": "red"},
+				{"op": "gt", "column": "col_1", "value": 10}]}}`, []int{4})
+			scan(t, router, `{"filter": {"op": "or", "filters": [
+				{"op": "eq", "column": "col_0", "value": "blue"},
+				{"op": "isnull", "column": "col_1"}]}}`, []int{3, 5})
+			scan(t, router, `{"filter": {"op": "not", "filters": [
+				{"op": "isnull", "column": "col_0"}]}}`, []int{1, 2, 3, 4})
+
+			// Test. Limit
+			_, res := tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB026", "table_1"),
+				`{"limit": 2, "operationId": "op_1"}`, http.StatusOK, `"operationId": "op_1"`)
+			if rows := scanRows(t, res); len(rows) != 2 {
+				t.Fatalf("Test failed. Expected 2 rows, got %d. Body: %s", len(rows), res)
+			}
+
+			// Test. Read columns
+			_, res = tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB026", "table_1"),
+				`{"filter": {"op": "eq", "column": "id0", "value": 2}, "readColumns": [{"column": "col_0"}]}`,
+				http.StatusOK, "green")
+			rows := scanRows(t, res)
+			if len(rows) != 1 || len(rows[0]) != 1 {
+				t.Fatalf("Test failed. Expected one row with one column. Body: %s", res)
+			}
+
+			// Test. Unknown column
+			tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB026", "table_1"),
+				`{"filter": {"op": "eq", "column": "col_x", "value": 1}}`, http.StatusBadRequest, common.ERROR_012())
+
+			// Test. Filtering on TEXT columns is not supported
+			tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB026", "table_2"),
+				`{"filter": {"op": "isnull", "column": "col_0"}, "readColumns": [{"column": "id0"}]}`,
+				http.StatusBadRequest, common.ERROR_040())
+
+			// Test. TEXT columns are returned as strings
+			_, res = tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB026", "table_2"), `{}`,
+				http.StatusOK, "")
+			rows = scanRows(t, res)
+			if len(rows) != 1 || string(rows[0]["col_0"]) != `"text_data"` {
+				t.Fatalf("Test failed. Unexpected TEXT column. Body: %s", res)
+			}
+
+			// Test. Unknown table
+			tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB026", "table_x"),
+				`{}`, http.StatusBadRequest, common.ERROR_011())
+		})
+}
+
+func TestScanTemporalFormats(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB022")}, []tu.RegisterTestHandler{RegisterScanTestHandler},
+		func(router *gin.Engine) {
+			tests := map[string]struct {
+				body     string
+				expected string
+			}{
+				// filter values are accepted in all formats
+				"mysql": {`{"filter": {"op": "eq", "column": "col0", "value": "2022-11-11 11:11:11.123"}}`,
+					`"2022-11-11 11:11:11.123"`},
+				"rfc3339 filter": {`{"filter": {"op": "eq", "column": "col0", "value": "2022-11-11T16:41:11.123+05:30"}}`,
+					`"2022-11-11 11:11:11.123"`},
+				"rfc3339": {`{"filter": {"op": "eq", "column": "id0", "value": "2022-11-11 11:11:11.123"},
+					"temporalFormat": "rfc3339", "timeZone": "+05:30"}`, `"2022-11-11T16:41:11.123+05:30"`},
+				"epoch millis": {`{"filter": {"op": "eq", "column": "col0", "value": 1668165071123},
+					"temporalFormat": "epochMillis"}`, `1668165071123`},
+				"time zone": {`{"filter": {"op": "eq", "column": "col0", "value": "2022-11-11 06:11:11.123"},
+					"timeZone": "-05:00"}`, `"2022-11-11 06:11:11.123"`},
+			}
+
+			for name, test := range tests {
+				t.Run(name, func(t *testing.T) {
+					_, res := tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB022", "ts_table3"),
+						test.body, http.StatusOK, "")
+					rows := scanRows(t, res)
+					if len(rows) != 1 || string(rows[0]["col0"]) != test.expected {
+						t.Fatalf("Test failed. Expected col0 %s. Body: %s", test.expected, res)
+					}
+				})
+			}
+		})
+}
+
+func TestScanMySQLTypes(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB028")}, []tu.RegisterTestHandler{RegisterScanTestHandler},
+		func(router *gin.Engine) {
+			_, res := tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB028", "types"),
+				`{"filter": {"op": "eq", "column": "id0", "value": 1}}`, http.StatusOK, "")
+			rows := scanRows(t, res)
+			if len(rows) != 1 || string(rows[0]["col0"]) != `"green"` || string(rows[0]["col1"]) != `["a","c"]` ||
+				string(rows[0]["col2"]) != `{"k": [1, 2], "s": "x\"y"}` {
+				t.Fatalf("Test failed. Unexpected row. Body: %s", res)
+			}
+		})
+}
+
+func TestScanCharsets(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB029")}, []tu.RegisterTestHandler{RegisterScanTestHandler},
+		func(router *gin.Engine) {
+			_, res := tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB029", "latin1_table"),
+				`{"filter": {"op": "eq", "column": "col0", "value": "naïve"}}`, http.StatusOK, "")
+			rows := scanRows(t, res)
+			if len(rows) != 1 || string(rows[0]["id0"]) != `"café"` || string(rows[0]["col1"]) != `"Grüße"` {
+				t.Fatalf("Test failed. Unexpected rows. Body: %s", res)
+			}
+		})
+}
+
+func TestScanOmitNulls(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB028")}, []tu.RegisterTestHandler{RegisterScanTestHandler},
+		func(router *gin.Engine) {
+			_, res := tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB028", "types"),
+				`{"filter": {"op": "eq", "column": "id0", "value": 3}, "omitNulls": true}`, http.StatusOK, "")
+			rows := scanRows(t, res)
+			if len(rows) != 1 || len(rows[0]) != 1 || string(rows[0]["id0"]) != "3" {
+				t.Fatalf("Test failed. Expected only id0. Body: %s", res)
+			}
+		})
+}
+
+func TestScanPagination(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterScanTestHandler},
+		func(router *gin.Engine) {
+			url := tu.NewScanURL("DB026", "table_1")
+
+			// Test. Page through the table two rows at a time
+			ids := []int{}
+			body := `{"limit": 2}`
+			for pages := 0; ; pages++ {
+				if pages > 3 {
+					t.Fatalf("Test failed. Too many pages")
+				}
+
+				_, res := tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, url, body, http.StatusOK, "")
+				for _, row := range scanRows(t, res) {
+					var id int
+					json.Unmarshal(row["id0"], &id)
+					ids = append(ids, id)
+				}
+
+				token := nextPageToken(t, res)
+				if token == "" {
+					break
+				}
+				body = `{"limit": 2, "pageToken": "` + token + `"}`
+			}
+			checkIDs(t, ids, []int{1, 2, 3, 4, 5})
+
+			// Test. Tampered token
+			_, res := tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, url, `{"limit": 2}`, http.StatusOK, "nextPageToken")
+			token := nextPageToken(t, res)
+			tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, url, `{"pageToken": "x`+token+`"}`,
+				http.StatusBadRequest, "Invalid page token")
+
+			// Test. Tokens can not be used for other tables
+			tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewScanURL("DB026", "users"),
+				`{"pageToken": "`+token+`"}`, http.StatusBadRequest, "Invalid page token")
+
+			// Test. The filter is applied to all pages
+			_, res = tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, url,
+				`{"limit": 1, "filter": {"op": "eq", "column": "col_0", "value": "red"}}`, http.StatusOK, "")
+			token = nextPageToken(t, res)
+			_, res = tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, url,
+				`{"limit": 1, "filter": {"op": "eq", "column": "col_0", "value": "red"}, "pageToken": "`+token+`"}`,
+				http.StatusOK, "")
+			rows := scanRows(t, res)
+			if len(rows) != 1 || string(rows[0]["id0"]) != "4" || nextPageToken(t, res) != "" {
+				t.Fatalf("Test failed. Unexpected last page. Body: %s", res)
+			}
+		})
+}
+
+func TestNextPageKey(t *testing.T) {
+	key := `[{"column": "id0", "value": "a\"]}"}]`
+	body := `{"data": [{"id0":"x"}], "nextPageToken": ` + key + `}`
+	resp := &scanResponse{body: body, keyOffset: uint32(strings.Index(body, "[{\"column")),
+		keyLength: uint32(len(key))}
+
+	// Test. The key is replaced by the signed token
+	signed := signNextPageToken("db", "table", resp)
+	token := pageToken("db", "table", []byte(key))
+	if expected := `{"data": [{"id0":"x"}], "nextPageToken": "` + token + `"}`; signed != expected {
+		t.Fatalf("Expecting %s. Got: %s", expected, signed)
+	}
+	if _, err := parsePageToken("db", "table", token); err != nil {
+		t.Fatalf("Failed to parse the token. Error: %v", err)
+	}
+
+	// Test. The key line is removed from NDJSON pages
+	page := "{\"id0\":\"x\"}\n{\"nextPageToken\": " + key + "}\n"
+	resp = &scanResponse{body: page, keyOffset: uint32(strings.Index(page, "[")), keyLength: uint32(len(key))}
+	rows, filters, err := splitNextPageKey(resp)
+	if err != nil || rows != "{\"id0\":\"x\"}\n" || filters == nil || *(*filters)[0].Column != "id0" {
+		t.Fatalf("Unexpected rows %q and key %v. Error: %v", rows, filters, err)
+	}
+
+	// Test. The last page has no key
+	resp.keyLength = 0
+	if signed := signNextPageToken("db", "table", resp); signed != page {
+		t.Fatalf("Expecting the page unchanged. Got: %s", signed)
+	}
+}
+
+func TestScanNDJSON(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterScanTestHandler},
+		func(router *gin.Engine) {
+			// read two rows per page so that the rows are streamed in several pages
+			streamPageRows = 2
+			defer func() { streamPageRows = 0 }()
+
+			url := tu.NewScanURL("DB026", "table_1")
+
+			// Test. All the rows are streamed in primary key order
+			checkIDs(t, streamIDs(t, router, url, `{}`), []int{1, 2, 3, 4, 5})
+
+			// Test. The limit is the total number of rows
+			checkIDs(t, streamIDs(t, router, url, `{"limit": 3}`), []int{1, 2, 3})
+
+			// Test. The filter is applied to all pages
+			checkIDs(t, streamIDs(t, router, url, `{"filter": {"op": "eq", "column": "col_0", "value": "red"}}`),
+				[]int{1, 4})
+
+			// Test. Start from a page token
+			_, res := tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, url, `{"limit": 2}`, http.StatusOK, "nextPageToken")
+			checkIDs(t, streamIDs(t, router, url, `{"pageToken": "`+nextPageToken(t, res)+`"}`), []int{3, 4, 5})
+
+			// Test. Range reads are streamed in one page, even if it has more
+			// rows than streamPageRows
+			device := `{"values": [{"column": "device_id", "value": "dev_1"}]}`
+			checkIDs(t, streamIDs(t, router, tu.NewIndexScanURL("DB026", "readings", "device_ts"),
+				`{"lowerBound": `+device+`, "upperBound": `+device+`, "order": "desc", "limit": 3}`), []int{4, 3, 2})
+
+			// Test. Errors before the first row are returned as usual
+			tu.ProcessNDJSONRequest(t, router, ds.SCAN_HTTP_VERB, url,
+				`{"filter": {"op": "eq", "column": "col_x", "value": 1}}`, http.StatusBadRequest, common.ERROR_012())
+		})
+}
+
+func TestScanArrow(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterScanTestHandler},
+		func(router *gin.Engine) {
+			url := tu.NewScanURL("DB026", "table_1")
+			readColumns := `"readColumns": [{"column": "id0"}, {"column": "col_0"}, {"column": "col_1"}, {"column": "col_2"}]`
+
+			// Test. All the rows in one record batch. Nulls are set in the validity buffers
+			tu.ProcessArrowRequest(t, router, ds.SCAN_HTTP_VERB, url, `{`+readColumns+`}`, http.StatusOK, nil,
+				[]tu.ArrowColumn{
+					{Field: "id0: int32", Values: `[1 2 3 4 5]`},
+					{Field: "col_0: utf8", Values: `["red" "green" "blue" "red" (null)]`},
+					{Field: "col_1: int32", Values: `[10 20 30 40 (null)]`},
+					{Field: "col_2: float64", Values: `[1.5 2.5 3.5 4.5 (null)]`},
+				})
+
+			// Test. The operation ID and the token of the next page are in the metadata of the schema
+			metadata := map[string]string{
+				"operationId":   "op_1",
+				"nextPageToken": pageToken("DB026", "table_1", []byte(`[{"column":"id0","value":3}]`)),
+			}
+			tu.ProcessArrowRequest(t, router, ds.SCAN_HTTP_VERB, url,
+				`{"limit": 2, "operationId": "op_1", `+readColumns+`}`, http.StatusOK, metadata,
+				[]tu.ArrowColumn{
+					{Field: "id0: int32", Values: `[1 2]`},
+					{Field: "col_0: utf8", Values: `["red" "green"]`},
+					{Field: "col_1: int32", Values: `[10 20]`},
+					{Field: "col_2: float64", Values: `[1.5 2.5]`},
+				})
+		})
+}
+
+func TestScanCSV(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterScanTestHandler},
+		func(router *gin.Engine) {
+			// read two rows per page so that the rows are streamed in several pages
+			streamPageRows = 2
+			defer func() { streamPageRows = 0 }()
+
+			url := tu.NewScanURL("DB026", "table_1")
+			readColumns := `"readColumns": [{"column": "id0"}, {"column": "col_0"}, {"column": "col_2"}]`
+
+			// Test. The header is only sent once. Nulls are empty fields
+			res := tu.ProcessCSVRequest(t, router, ds.SCAN_HTTP_VERB, url, `{`+readColumns+`}`, http.StatusOK)
+			expected := "id0,col_0,col_2\r\n1,red,1.5\r\n2,green,2.5\r\n3,blue,3.5\r\n4,red,4.5\r\n5,,\r\n"
+			if res != expected {
+				t.Fatalf("Test failed. Expected: %q, Got: %q", expected, res)
+			}
+
+			// Test. The limit is the total number of rows
+			res = tu.ProcessCSVRequest(t, router, ds.SCAN_HTTP_VERB, url,
+				`{"limit": 3, "filter": {"op": "not", "filters": [{"op": "isnull", "column": "col_0"}]}, `+readColumns+`}`, http.StatusOK)
+			expected = "id0,col_0,col_2\r\n1,red,1.5\r\n2,green,2.5\r\n3,blue,3.5\r\n"
+			if res != expected {
+				t.Fatalf("Test failed. Expected: %q, Got: %q", expected, res)
+			}
+
+			// Test. The header is sent even if there are no rows
+			res = tu.ProcessCSVRequest(t, router, ds.SCAN_HTTP_VERB, url,
+				`{"filter": {"op": "eq", "column": "col_0", "value": "black"}, `+readColumns+`}`, http.StatusOK)
+			if res != "id0,col_0,col_2\r\n" {
+				t.Fatalf("Test failed. Expected only the header, Got: %q", res)
+			}
+
+			// Test. Errors before the first row are returned as JSON
+			res = tu.ProcessCSVRequest(t, router, ds.SCAN_HTTP_VERB, url,
+				`{"filter": {"op": "eq", "column": "col_x", "value": 1}}`, http.StatusBadRequest)
+			if !strings.Contains(res, common.ERROR_012()) {
+				t.Fatalf("Test failed. Response body does not contain %s. Body: %s", common.ERROR_012(), res)
+			}
+		})
+}
+
+func TestIndexScan(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB026")}, []tu.RegisterTestHandler{RegisterScanTestHandler},
+		func(router *gin.Engine) {
+			// Test. Without bounds the whole index is read in order
+			indexScan(t, router, `{}`, []int{1, 2, 3, 4, 5, 6})
+			indexScan(t, router, `{"order": "desc"}`, []int{6, 5, 4, 3, 2, 1})
+
+			// Test. Last two readings of a device
+			device := `{"values": [{"column": "device_id", "value": "dev_1"}]}`
+			indexScan(t, router, `{"lowerBound": `+device+`, "upperBound": `+device+`, "order": "desc", "limit": 2}`,
+				[]int{4, 3})
+
+			// Test. Inclusive and exclusive bounds
+			indexScan(t, router, `{
+				"lowerBound": {"values": [{"column": "device_id", "value": "dev_1"}, {"column": "ts", "value": 200}]},
+				"upperBound": {"values": [{"column": "device_id", "value": "dev_1"}, {"column": "ts", "value": 400}], "inclusive": false}}`,
+				[]int{2, 3})
+			indexScan(t, router, `{
+				"lowerBound": {"values": [{"column": "device_id", "value": "dev_1"}, {"column": "ts", "value": 200}], "inclusive": false},
+				"upperBound": {"values": [{"column": "device_id", "value": "dev_1"}, {"column": "ts", "value": 400}]}}`,
+				[]int{3, 4})
+
+			// Test. Open upper bound
+			indexScan(t, router, `{"lowerBound": {"values": [{"column": "device_id", "value": "dev_2"}]}}`,
+				[]int{5, 6})
+
+			// Test. Bounds and filter
+			indexScan(t, router, `{"lowerBound": `+device+`, "upperBound": `+device+`,
+				"filter": {"op": "gt", "column": "reading", "value": 2.5}}`, []int{3, 4})
+
+			url := tu.NewIndexScanURL("DB026", "readings", "device_ts")
+
+			// Test. Bound columns must be a prefix of the index columns
+			tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, url,
+				`{"lowerBound": {"values": [{"column": "ts", "value": 100}]}}`, http.StatusBadRequest, common.ERROR_045())
+
+			// Test. Invalid order
+			tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, url, `{"order": "up"}`, http.StatusBadRequest,
+				"Error:Field validation for 'Order'")
+
+			// Test. Unknown index
+			tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, tu.NewIndexScanURL("DB026", "readings", "idx_x"),
+				`{}`, http.StatusBadRequest, common.ERROR_043())
+		})
+}
+
+// scan runs the table scan and checks the primary keys of the returned rows
+func scan(t *testing.T, router *gin.Engine, body string, expected []int) {
+	t.Helper()
+
+	ids := scanIDs(t, router, tu.NewScanURL("DB026", "table_1"), body)
+	sort.Ints(ids)
+	checkIDs(t, ids, expected)
+}
+
+// indexScan runs the index scan and checks the primary keys of the returned rows in order
+func indexScan(t *testing.T, router *gin.Engine, body string, expected []int) {
+	t.Helper()
+
+	ids := scanIDs(t, router, tu.NewIndexScanURL("DB026", "readings", "device_ts"), body)
+	checkIDs(t, ids, expected)
+}
+
+func scanIDs(t *testing.T, router *gin.Engine, url string, body string) []int {
+	t.Helper()
+
+	_, res := tu.ProcessRequest(t, router, ds.SCAN_HTTP_VERB, url, body, http.StatusOK, "")
+
+	ids := []int{}
+	for _, row := range scanRows(t, res) {
+		var id int
+		if err := json.Unmarshal(row["id0"], &id); err != nil {
+			t.Fatalf("Test failed. Unable to read id0. Body: %s", res)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// streamIDs runs the scan with a newline delimited JSON response and
+// returns the primary keys of the returned rows
+func streamIDs(t *testing.T, router *gin.Engine, url string, body string) []int {
+	t.Helper()
+
+	_, lines := tu.ProcessNDJSONRequest(t, router, ds.SCAN_HTTP_VERB, url, body, http.StatusOK, "")
+
+	ids := []int{}
+	for _, line := range lines {
+		row := map[string]json.RawMessage{}
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			t.Fatalf("Test failed. Unable to parse row. Error: %v. Line: %s", err, line)
+		}
+
+		var id int
+		if err := json.Unmarshal(row["id0"], &id); err != nil {
+			t.Fatalf("Test failed. Unable to read id0. Line: %s", line)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func checkIDs(t *testing.T, ids []int, expected []int) {
+	t.Helper()
+
+	if len(ids) != len(expected) {
+		t.Fatalf("Test failed. Expected rows: %v, got: %v", expected, ids)
+	}
+	for i := range ids {
+		if ids[i] != expected[i] {
+			t.Fatalf("Test failed. Expected rows: %v, got: %v", expected, ids)
+		}
+	}
+}
+
+func nextPageToken(t *testing.T, res string) string {
+	t.Helper()
+
+	var resp struct {
+		NextPageToken string `json:"nextPageToken"`
+	}
+	if err := json.Unmarshal([]byte(res), &resp); err != nil {
+		t.Fatalf("Test failed. Unable to parse response. Error: %v. Body: %s", err, res)
+	}
+	return resp.NextPageToken
+}
+
+func scanRows(t *testing.T, res string) []map[string]json.RawMessage {
+	t.Helper()
+
+	var resp struct {
+		Data []map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(res), &resp); err != nil {
+		t.Fatalf("Test failed. Unable to parse response. Error: %v. Body: %s", err, res)
+	}
+	return resp.Data
+}
@@ -0,0 +1,82 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tx
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+)
+
+func RegisterTxTestHandler(e *gin.Engine) {
+	group := e.Group(ds.TX_EP_GROUP)
+	group.POST(ds.TX_BEGIN_OPERATION, TxBeginHandler)
+	group.POST(":"+ds.TX_ID_PP+"/"+ds.TX_COMMIT_OPERATION, TxCommitHandler)
+	group.POST(":"+ds.TX_ID_PP+"/"+ds.TX_ABORT_OPERATION, TxAbortHandler)
+}
+
+func TxBeginHandler(c *gin.Context) {
+	txID, dalErr := dal.RonDBTxBegin()
+	if dalErr != nil {
+		setDalError(c, dalErr)
+		return
+	}
+	c.JSON(http.StatusOK, ds.TxResponse{TxID: txID})
+}
+
+func TxCommitHandler(c *gin.Context) {
+	pp := ds.TxPP{}
+	if err := c.ShouldBindUri(&pp); err != nil {
+		common.SetResponseError(c, http.StatusBadRequest, common.ErrorResponse{Error: fmt.Sprintf("%-v", err)})
+		return
+	}
+
+	if dalErr := dal.RonDBTxCommit(*pp.TxID); dalErr != nil {
+		setDalError(c, dalErr)
+		return
+	}
+	c.JSON(http.StatusOK, ds.TxResponse{TxID: *pp.TxID})
+}
+
+func TxAbortHandler(c *gin.Context) {
+	pp := ds.TxPP{}
+	if err := c.ShouldBindUri(&pp); err != nil {
+		common.SetResponseError(c, http.StatusBadRequest, common.ErrorResponse{Error: fmt.Sprintf("%-v", err)})
+		return
+	}
+
+	if dalErr := dal.RonDBTxAbort(*pp.TxID); dalErr != nil {
+		setDalError(c, dalErr)
+		return
+	}
+	c.JSON(http.StatusOK, ds.TxResponse{TxID: *pp.TxID})
+}
+
+func setDalError(c *gin.Context, dalErr *dal.DalError) {
+	var message string
+	if dalErr.HttpCode >= http.StatusInternalServerError {
+		message = fmt.Sprintf("%v File: %v, Line: %v ", dalErr.Message, dalErr.ErrFileName, dalErr.ErrLineNo)
+	} else {
+		message = fmt.Sprintf("%v", dalErr.Message)
+	}
+	common.SetResponseError(c, dalErr.HttpCode, common.ErrorResponse{Error: message})
+}
@@ -0,0 +1,284 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package tx
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/go-sql-driver/mysql"
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/config"
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+	"hopsworks.ai/rdrs/internal/router/handler/pkwrite"
+	tu "hopsworks.ai/rdrs/internal/router/handler/utils"
+)
+
+var handlers = []tu.RegisterTestHandler{RegisterTxTestHandler, pkread.RegisterPKTestHandler,
+	pkwrite.RegisterPKWriteTestHandler}
+
+func TestTxCommit(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")}, handlers, func(router *gin.Engine) {
+		txID := beginTx(t, router)
+
+		insert(t, router, &txID, 10, "tx data", http.StatusOK)
+
+		// the row is visible within the transaction only
+		read(t, router, &txID, 10, http.StatusOK)
+		read(t, router, nil, 10, http.StatusNotFound)
+
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxCommitURL(txID), "", http.StatusOK, "")
+		read(t, router, nil, 10, http.StatusOK)
+
+		// the transaction is closed after commit
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxCommitURL(txID), "",
+			http.StatusNotFound, common.ERROR_034())
+	})
+}
+
+func TestTxAbort(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")}, handlers, func(router *gin.Engine) {
+		txID := beginTx(t, router)
+
+		insert(t, router, &txID, 11, "tx data", http.StatusOK)
+		read(t, router, &txID, 11, http.StatusOK)
+
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxAbortURL(txID), "", http.StatusOK, "")
+		read(t, router, nil, 11, http.StatusNotFound)
+	})
+}
+
+func TestTxFailedOperation(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")}, handlers, func(router *gin.Engine) {
+		txID := beginTx(t, router)
+
+		insert(t, router, &txID, 14, "tx data", http.StatusOK)
+
+		// Test. The value of col_1 can not be converted. The key of the operation
+		// is already set in the transaction
+		mode := ds.PK_WRITE_MODE_INSERT
+		param := ds.PKWriteBody{
+			Filters: tu.NewFiltersKVs("id0", 15),
+			Values:  tu.NewValuesKVs("col_0", "tx data", "col_1", "not a number"),
+			Mode:    &mode,
+			TxID:    &txID,
+		}
+		body, _ := json.Marshal(param)
+		tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, tu.NewPKWriteURL("DB025", "table_1"), string(body),
+			http.StatusBadRequest, "")
+
+		// the transaction is rolled back, so nothing is written
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxCommitURL(txID), "",
+			http.StatusNotFound, common.ERROR_034())
+		read(t, router, nil, 14, http.StatusNotFound)
+		read(t, router, nil, 15, http.StatusNotFound)
+	})
+}
+
+func TestTxLockedRead(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")}, handlers, func(router *gin.Engine) {
+		txID := beginTx(t, router)
+
+		// read-then-write. The exclusive lock is held until the transaction is committed
+		lockMode := ds.LOCK_MODE_EXCLUSIVE
+		param := ds.PKReadBody{
+			Filters:     tu.NewFiltersKVs("id0", 1),
+			ReadColumns: tu.NewReadColumn("col_1"),
+			TxID:        &txID,
+			LockMode:    &lockMode,
+		}
+		body, _ := json.Marshal(param)
+		tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB025", "table_1"), string(body),
+			http.StatusOK, "col_1")
+
+		mode := ds.PK_WRITE_MODE_UPDATE
+		wParam := ds.PKWriteBody{
+			Filters: tu.NewFiltersKVs("id0", 1),
+			Values:  tu.NewValuesKVs("col_1", 100),
+			Mode:    &mode,
+			TxID:    &txID,
+		}
+		body, _ = json.Marshal(wParam)
+		tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, tu.NewPKWriteURL("DB025", "table_1"), string(body),
+			http.StatusOK, "")
+
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxCommitURL(txID), "", http.StatusOK, "")
+
+		param.TxID = nil
+		param.LockMode = nil
+		body, _ = json.Marshal(param)
+		tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB025", "table_1"), string(body),
+			http.StatusOK, "100")
+	})
+}
+
+func TestTxLockModes(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")}, handlers, func(router *gin.Engine) {
+		for i, lockMode := range []string{ds.LOCK_MODE_COMMITTED, ds.LOCK_MODE_SHARED, ds.LOCK_MODE_EXCLUSIVE} {
+			txID := beginTx(t, router)
+
+			param := ds.PKReadBody{
+				Filters:     tu.NewFiltersKVs("id0", 1),
+				ReadColumns: tu.NewReadColumn("col_1"),
+				TxID:        &txID,
+				LockMode:    &lockMode,
+			}
+			body, _ := json.Marshal(param)
+			tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB025", "table_1"), string(body),
+				http.StatusOK, "col_1")
+
+			// Test. Committed reads do not lock the row. Shared and exclusive
+			// locks are held until the end of the transaction, so a concurrent
+			// write times out waiting for the lock
+			mode := ds.PK_WRITE_MODE_UPDATE
+			wParam := ds.PKWriteBody{
+				Filters: tu.NewFiltersKVs("id0", 1),
+				Values:  tu.NewValuesKVs("col_1", 200+i),
+				Mode:    &mode,
+			}
+			body, _ = json.Marshal(wParam)
+			if lockMode == ds.LOCK_MODE_COMMITTED {
+				tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, tu.NewPKWriteURL("DB025", "table_1"),
+					string(body), http.StatusOK, "")
+			} else {
+				tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, tu.NewPKWriteURL("DB025", "table_1"),
+					string(body), http.StatusInternalServerError, common.ERROR_009())
+			}
+
+			// the write succeeds once the lock is released
+			tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxCommitURL(txID), "", http.StatusOK, "")
+			tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, tu.NewPKWriteURL("DB025", "table_1"),
+				string(body), http.StatusOK, "")
+		}
+
+		// Test. A shared lock does not block other shared reads
+		lockMode := ds.LOCK_MODE_SHARED
+		txID := beginTx(t, router)
+		param := ds.PKReadBody{
+			Filters:     tu.NewFiltersKVs("id0", 1),
+			ReadColumns: tu.NewReadColumn("col_1"),
+			TxID:        &txID,
+			LockMode:    &lockMode,
+		}
+		body, _ := json.Marshal(param)
+		tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB025", "table_1"), string(body),
+			http.StatusOK, "col_1")
+
+		param.TxID = nil
+		body, _ = json.Marshal(param)
+		tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB025", "table_1"), string(body),
+			http.StatusOK, "col_1")
+
+		// Test. An exclusive lock blocks the other lock modes
+		lockMode = ds.LOCK_MODE_EXCLUSIVE
+		body, _ = json.Marshal(param)
+		tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB025", "table_1"), string(body),
+			http.StatusInternalServerError, common.ERROR_009())
+
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxAbortURL(txID), "", http.StatusOK, "")
+	})
+}
+
+func TestTxNotFound(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")}, handlers, func(router *gin.Engine) {
+		txID := "0123456789abcdef0123456789abcdef"
+
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxCommitURL(txID), "",
+			http.StatusNotFound, common.ERROR_034())
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxAbortURL(txID), "",
+			http.StatusNotFound, common.ERROR_034())
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, ds.TX_EP_GROUP+"abc/"+ds.TX_COMMIT_OPERATION, "",
+			http.StatusBadRequest, "")
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, ds.TX_EP_GROUP+"1/"+ds.TX_COMMIT_OPERATION, "",
+			http.StatusBadRequest, "")
+
+		// operations can not use unknown transactions
+		insert(t, router, &txID, 12, "tx data", http.StatusNotFound)
+		read(t, router, &txID, 1, http.StatusNotFound)
+	})
+}
+
+func TestTxLimits(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB025")}, handlers, func(router *gin.Engine) {
+		dal.ConfigureTransactions(1, 100)
+		defer dal.ConfigureTransactions(config.Configuration().RestServer.MaxOpenTransactions,
+			config.Configuration().RestServer.TransactionIdleTimeoutMS)
+
+		txID := beginTx(t, router)
+
+		// Test. Max number of open transactions
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxBeginURL(), "",
+			http.StatusTooManyRequests, common.ERROR_035())
+
+		// Test. Idle transactions are aborted
+		insert(t, router, &txID, 13, "tx data", http.StatusOK)
+		time.Sleep(2 * time.Second)
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxCommitURL(txID), "",
+			http.StatusNotFound, common.ERROR_034())
+		read(t, router, nil, 13, http.StatusNotFound)
+
+		// the aborted transaction no longer counts against the limit
+		txID = beginTx(t, router)
+		tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxAbortURL(txID), "", http.StatusOK, "")
+	})
+}
+
+func beginTx(t *testing.T, router *gin.Engine) string {
+	t.Helper()
+	_, resp := tu.ProcessRequest(t, router, ds.TX_HTTP_VERB, tu.NewTxBeginURL(), "", http.StatusOK, "txId")
+
+	var txResp ds.TxResponse
+	if err := json.Unmarshal([]byte(resp), &txResp); err != nil {
+		t.Fatalf("Failed to parse response. Error: %v", err)
+	}
+	if len(txResp.TxID) != 32 {
+		t.Fatalf("Invalid transaction ID '%s'", txResp.TxID)
+	}
+	return txResp.TxID
+}
+
+func insert(t *testing.T, router *gin.Engine, txID *string, id int, val string, expectedStatus int) {
+	t.Helper()
+	mode := ds.PK_WRITE_MODE_INSERT
+	param := ds.PKWriteBody{
+		Filters: tu.NewFiltersKVs("id0", id),
+		Values:  tu.NewValuesKVs("col_0", val),
+		Mode:    &mode,
+		TxID:    txID,
+	}
+	body, _ := json.Marshal(param)
+	tu.ProcessRequest(t, router, ds.PK_WRITE_HTTP_VERB, tu.NewPKWriteURL("DB025", "table_1"), string(body),
+		expectedStatus, "")
+}
+
+func read(t *testing.T, router *gin.Engine, txID *string, id int, expectedStatus int) {
+	t.Helper()
+	param := ds.PKReadBody{
+		Filters:     tu.NewFiltersKVs("id0", id),
+		ReadColumns: tu.NewReadColumn("col_0"),
+		TxID:        txID,
+	}
+	body, _ := json.Marshal(param)
+	tu.ProcessRequest(t, router, ds.PK_HTTP_VERB, tu.NewPKReadURL("DB025", "table_1"), string(body),
+		expectedStatus, "")
+}
@@ -17,6 +17,7 @@
 package utils
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/base64"
 	"encoding/json"
@@ -24,14 +25,17 @@ import (
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	neturl "net/url"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/apache/arrow/go/arrow/ipc"
 	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/ugorji/go/codec"
 	"hopsworks.ai/rdrs/internal/common"
 	"hopsworks.ai/rdrs/internal/config"
 	"hopsworks.ai/rdrs/internal/dal"
@@ -69,6 +73,190 @@ func ProcessRequest(t testing.TB, router *gin.Engine, httpVerb string,
 	return resp.Code, string(resp.Body.Bytes())
 }
 
+// ProcessNDJSONRequest sends a request that accepts a newline delimited
+// JSON response and returns the lines of the response
+func ProcessNDJSONRequest(t testing.TB, router *gin.Engine, httpVerb string,
+	url string, body string, expectedStatus int, expectedMsg string) (int, []string) {
+
+	t.Helper()
+	req, _ := http.NewRequest(httpVerb, url, strings.NewReader(body))
+	req.Header.Set("Accept", ds.NDJSON_MIME)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != expectedStatus {
+		t.Fatalf("Test failed. Expected: %d, Got: %d. Complete Response Body: %v ", expectedStatus, resp.Code, resp.Body)
+	}
+	if !strings.Contains(resp.Body.String(), expectedMsg) {
+		t.Fatalf("Test failed. Response body does not contain %s. Body: %s", expectedMsg, resp.Body)
+	}
+
+	if resp.Code != http.StatusOK {
+		return resp.Code, nil
+	}
+
+	if contentType := resp.Header().Get("Content-Type"); contentType != ds.NDJSON_MIME {
+		t.Fatalf("Test failed. Expected content type: %s, Got: %s", ds.NDJSON_MIME, contentType)
+	}
+
+	lines := strings.Split(resp.Body.String(), "\n")
+	if lines[len(lines)-1] != "" {
+		t.Fatalf("Test failed. The last line is not terminated. Body: %s", resp.Body)
+	}
+	return resp.Code, lines[:len(lines)-1]
+}
+
+// ProcessBinaryRequest sends a request that accepts a MessagePack or CBOR
+// response and returns the decoded response. Maps are decoded as
+// map[string]interface{}, strings as string and binary values as []byte
+func ProcessBinaryRequest(t testing.TB, router *gin.Engine, httpVerb string,
+	url string, body string, format string, expectedStatus int) interface{} {
+
+	t.Helper()
+	req, _ := http.NewRequest(httpVerb, url, strings.NewReader(body))
+	req.Header.Set("Accept", format)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != expectedStatus {
+		t.Fatalf("Test failed. Expected: %d, Got: %d. Complete Response Body: %v ", expectedStatus, resp.Code, resp.Body)
+	}
+	if contentType := resp.Header().Get("Content-Type"); contentType != format {
+		t.Fatalf("Test failed. Expected content type: %s, Got: %s", format, contentType)
+	}
+
+	var handle codec.Handle
+	mapType := reflect.TypeOf(map[string]interface{}(nil))
+	if format == ds.CBOR_MIME {
+		cbor := &codec.CborHandle{}
+		cbor.MapType = mapType
+		handle = cbor
+	} else {
+		// the new spec distinguishes strings and binary values
+		msgpack := &codec.MsgpackHandle{WriteExt: true}
+		msgpack.MapType = mapType
+		handle = msgpack
+	}
+
+	var result interface{}
+	if err := codec.NewDecoderBytes(resp.Body.Bytes(), handle).Decode(&result); err != nil {
+		t.Fatalf("Test failed. Failed to decode the response. Error: %v", err)
+	}
+	return result
+}
+
+// ArrowColumn is an expected column of an Arrow record batch. Field is the
+// name and the type of the column as printed by the Arrow Go library, e.g.
+// "id: int32", and Values are the printed values, e.g. `[1 (null) 3]`
+type ArrowColumn struct {
+	Field  string
+	Values string
+}
+
+// ProcessArrowRequest sends a request that accepts an Arrow IPC stream.
+// The stream is read with the Arrow Go library and must have one record
+// batch with the expected columns. The schema metadata must match metadata
+func ProcessArrowRequest(t testing.TB, router *gin.Engine, httpVerb string,
+	url string, body string, expectedStatus int, metadata map[string]string, columns []ArrowColumn) {
+
+	t.Helper()
+	req, _ := http.NewRequest(httpVerb, url, strings.NewReader(body))
+	req.Header.Set("Accept", ds.ARROW_MIME)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != expectedStatus {
+		t.Fatalf("Test failed. Expected: %d, Got: %d. Complete Response Body: %v ", expectedStatus, resp.Code, resp.Body)
+	}
+	if contentType := resp.Header().Get("Content-Type"); contentType != ds.ARROW_MIME {
+		t.Fatalf("Test failed. Expected content type: %s, Got: %s", ds.ARROW_MIME, contentType)
+	}
+
+	reader, err := ipc.NewReader(bytes.NewReader(resp.Body.Bytes()))
+	if err != nil {
+		t.Fatalf("Test failed. Unable to read the Arrow stream. Error: %v", err)
+	}
+	defer reader.Release()
+
+	schema := reader.Schema()
+	gotMetadata := map[string]string{}
+	for i, key := range schema.Metadata().Keys() {
+		gotMetadata[key] = schema.Metadata().Values()[i]
+	}
+	if len(gotMetadata) != len(metadata) {
+		t.Fatalf("Test failed. Expected metadata: %v, Got: %v", metadata, gotMetadata)
+	}
+	for key, value := range metadata {
+		if gotMetadata[key] != value {
+			t.Fatalf("Test failed. Expected metadata: %v, Got: %v", metadata, gotMetadata)
+		}
+	}
+
+	if len(schema.Fields()) != len(columns) {
+		t.Fatalf("Test failed. Expected %d fields, Got: %v", len(columns), schema)
+	}
+	for i, field := range schema.Fields() {
+		if got := fmt.Sprintf("%s: %v", field.Name, field.Type); got != columns[i].Field || !field.Nullable {
+			t.Fatalf("Test failed. Expected the nullable field %s, Got: %s", columns[i].Field, got)
+		}
+	}
+
+	if !reader.Next() {
+		t.Fatalf("Test failed. The stream has no record batch. Error: %v", reader.Err())
+	}
+	record := reader.Record()
+	for i, column := range columns {
+		if got := fmt.Sprint(record.Column(i)); got != column.Values {
+			t.Fatalf("Test failed. Column %s. Expected: %s, Got: %s", column.Field, column.Values, got)
+		}
+	}
+	if reader.Next() || reader.Err() != nil {
+		t.Fatalf("Test failed. Expected the end of the stream. Error: %v", reader.Err())
+	}
+}
+
+// ProcessCSVRequest sends a request that accepts comma separated values
+// and returns the records
+func ProcessCSVRequest(t testing.TB, router *gin.Engine, httpVerb string,
+	url string, body string, expectedStatus int) string {
+
+	t.Helper()
+	req, _ := http.NewRequest(httpVerb, url, strings.NewReader(body))
+	req.Header.Set("Accept", ds.CSV_MIME)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != expectedStatus {
+		t.Fatalf("Test failed. Expected: %d, Got: %d. Complete Response Body: %v ", expectedStatus, resp.Code, resp.Body)
+	}
+	if contentType := resp.Header().Get("Content-Type"); resp.Code == http.StatusOK && contentType != ds.CSV_MIME {
+		t.Fatalf("Test failed. Expected content type: %s, Got: %s", ds.CSV_MIME, contentType)
+	}
+	return resp.Body.String()
+}
+
+// ProcessBlobRequest reads a BLOB/TEXT column. The Range header is set
+// if rng is not empty
+func ProcessBlobRequest(t testing.TB, router *gin.Engine, url string, rng string,
+	expectedStatus int, expectedMsg string) *httptest.ResponseRecorder {
+
+	t.Helper()
+	req, _ := http.NewRequest(ds.BLOB_HTTP_VERB, url, nil)
+	if rng != "" {
+		req.Header.Set("Range", rng)
+	}
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != expectedStatus {
+		t.Fatalf("Test failed. Expected: %d, Got: %d. Complete Response Body: %v ", expectedStatus, resp.Code, resp.Body)
+	}
+	if !strings.Contains(resp.Body.String(), expectedMsg) {
+		t.Fatalf("Test failed. Response body does not contain %s. Body: %s", expectedMsg, resp.Body)
+	}
+	return resp
+}
+
 func ValidateResArrayData(t testing.TB, testInfo ds.PKTestInfo, resp string, isBinaryData bool) {
 	t.Helper()
 
@@ -247,6 +435,77 @@ func NewPKReadURL(db string, table string) string {
 	return url
 }
 
+func NewUniqueReadURL(db string, table string) string {
+	url := fmt.Sprintf("%s%s", ds.DB_OPS_EP_GROUP, ds.UNIQUE_READ_OPERATION)
+	url = strings.Replace(url, ":"+ds.DB_PP, db, 1)
+	url = strings.Replace(url, ":"+ds.TABLE_PP, table, 1)
+	return url
+}
+
+func NewPKWriteURL(db string, table string) string {
+	url := fmt.Sprintf("%s%s", ds.DB_OPS_EP_GROUP, ds.PK_WRITE_OPERATION)
+	url = strings.Replace(url, ":"+ds.DB_PP, db, 1)
+	url = strings.Replace(url, ":"+ds.TABLE_PP, table, 1)
+	return url
+}
+
+func NewPKDeleteURL(db string, table string) string {
+	url := fmt.Sprintf("%s%s", ds.DB_OPS_EP_GROUP, ds.PK_DELETE_OPERATION)
+	url = strings.Replace(url, ":"+ds.DB_PP, db, 1)
+	url = strings.Replace(url, ":"+ds.TABLE_PP, table, 1)
+	return url
+}
+
+func NewScanURL(db string, table string) string {
+	url := fmt.Sprintf("%s%s", ds.DB_OPS_EP_GROUP, ds.SCAN_OPERATION)
+	url = strings.Replace(url, ":"+ds.DB_PP, db, 1)
+	url = strings.Replace(url, ":"+ds.TABLE_PP, table, 1)
+	return url
+}
+
+func NewIndexScanURL(db string, table string, index string) string {
+	url := fmt.Sprintf("%s%s", ds.DB_OPS_EP_GROUP, ds.INDEX_SCAN_EP)
+	url = strings.Replace(url, ":"+ds.DB_PP, db, 1)
+	url = strings.Replace(url, ":"+ds.TABLE_PP, table, 1)
+	url = strings.Replace(url, ":"+ds.INDEX_PP, index, 1)
+	return url
+}
+
+func NewBlobURL(db string, table string, column string, key string) string {
+	url := fmt.Sprintf("%s%s", ds.DB_OPS_EP_GROUP, ds.BLOB_EP)
+	url = strings.Replace(url, ":"+ds.DB_PP, db, 1)
+	url = strings.Replace(url, ":"+ds.TABLE_PP, table, 1)
+	url = strings.Replace(url, ":"+ds.BLOB_COLUMN_PP, column, 1)
+	return url + "?" + ds.BLOB_KEY_PARAM_NAME + "=" + neturl.QueryEscape(key)
+}
+
+func NewTxBeginURL() string {
+	return ds.TX_EP_GROUP + ds.TX_BEGIN_OPERATION
+}
+
+func NewTxCommitURL(txID string) string {
+	return fmt.Sprintf("%s%s/%s", ds.TX_EP_GROUP, txID, ds.TX_COMMIT_OPERATION)
+}
+
+func NewTxAbortURL(txID string) string {
+	return fmt.Sprintf("%s%s/%s", ds.TX_EP_GROUP, txID, ds.TX_ABORT_OPERATION)
+}
+
+// creates the values map for write operations from column/value pairs
+func NewValuesKVs(vals ...interface{}) *map[string]*json.RawMessage {
+	if len(vals)%2 != 0 {
+		log.Panic("Expecting key value pairs")
+	}
+
+	values := make(map[string]*json.RawMessage)
+	for i := 0; i < len(vals); i += 2 {
+		c := fmt.Sprintf("%v", vals[i])
+		v := RawBytes(vals[i+1])
+		values[c] = &v
+	}
+	return &values
+}
+
 func NewBatchReadURL() string {
 	return "/" + version.API_VERSION + "/" + ds.BATCH_OPERATION
 }
@@ -383,6 +642,11 @@ func InitRouter(t testing.TB, registerHandlers []RegisterTestHandler) (*gin.Engi
 	for _, handler := range registerHandlers {
 		handler(router)
 	}
+	dal.ConfigureTransactions(config.Configuration().RestServer.MaxOpenTransactions,
+		config.Configuration().RestServer.TransactionIdleTimeoutMS)
+	dal.ConfigureBlobs(config.Configuration().RestServer.MaxBlobReadSize)
+	dal.ConfigurePadding(config.Configuration().RestServer.TrimCharPadding,
+		config.Configuration().RestServer.TrimBinaryPadding)
 	if !dal.BuffersInitialized() {
 		dal.InitializeBuffers()
 	}
@@ -447,12 +711,15 @@ func BatchTest(t *testing.T, tests map[string]ds.BatchOperationTestInfo, isBinar
 				subOps = append(subOps, op.SubOperation)
 			}
 			batch := ds.BatchOperation{Operations: &subOps}
+			if testInfo.Atomic {
+				batch.Atomic = &testInfo.Atomic
+			}
 
 			WithDBs(t, dbs, registerHandlers, func(router *gin.Engine) {
 				url := NewBatchReadURL()
 				body, _ := json.MarshalIndent(batch, "", "\t")
 				httpCode, res := ProcessRequest(t, router, ds.BATCH_HTTP_VERB, url,
-					string(body), testInfo.HttpCode, "")
+					string(body), testInfo.HttpCode, testInfo.BodyContains)
 				if httpCode == http.StatusOK {
 					validateBatchResponse(t, testInfo, res, isBinaryData)
 				}
@@ -0,0 +1,350 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package respsrv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"hopsworks.ai/rdrs/internal/arrow"
+	"hopsworks.ai/rdrs/internal/common"
+	"hopsworks.ai/rdrs/internal/dal"
+	ds "hopsworks.ai/rdrs/internal/datastructs"
+	"hopsworks.ai/rdrs/internal/router/handler/batchops"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+	"hopsworks.ai/rdrs/internal/router/handler/pkwrite"
+)
+
+// The values are read in the Arrow format as it returns the type of the
+// value column, see ReadValueType. Binary columns are returned as bytes,
+// and are written as base64 strings, the same as in JSON requests
+
+// arrowBinaryType is the Arrow type of BINARY, VARBINARY and BLOB columns
+const arrowBinaryType = "z"
+
+// readResponse is the response of a read in the Arrow format
+type readResponse struct {
+	Schema []arrow.Field          `json:"schema"`
+	Data   map[string]interface{} `json:"data"`
+}
+
+// batchReadResponse is the response of a read of a batch
+type batchReadResponse struct {
+	Code int64        `json:"code"`
+	Body readResponse `json:"body"`
+}
+
+// get reads a key with a pk-read operation
+func (s *Server) get(key []byte, w replyWriter) {
+	params, err := s.readParams(key)
+	if err != nil {
+		w.error(err.Error())
+		return
+	}
+
+	request, response, err := pkread.CreateNativeRequest(params)
+	if err != nil {
+		w.error(err.Error())
+		return
+	}
+	defer dal.ReturnBuffer(request)
+	defer dal.ReturnBuffer(response)
+
+	dalErr := dal.RonDBPKRead(request, response)
+	if dalErr != nil {
+		if dalErr.HttpCode == http.StatusNotFound {
+			w.null()
+		} else {
+			w.error(batchops.DalErrorMessage(dalErr))
+		}
+		return
+	}
+
+	resp := readResponse{}
+	if err := common.DecodeMsgPackResponse(common.ProcessFormattedResponse(response.Buffer, ds.ARROW_MIME), &resp); err != nil {
+		w.error(fmt.Sprintf("failed to read the response. %v", err))
+		return
+	}
+	writeValue(resp.Data[s.valueColumn], w)
+}
+
+// mget reads the keys in a batch. Missing keys are null
+func (s *Server) mget(keys [][]byte, w replyWriter) {
+	values, err := s.readValues(keys)
+	if err != nil {
+		w.error(err.Error())
+		return
+	}
+
+	w.array(len(values))
+	for _, value := range values {
+		writeValue(value, w)
+	}
+}
+
+// readValues reads the values of the keys in a batch. The values of
+// missing keys are nil
+func (s *Server) readValues(keys [][]byte) ([]interface{}, error) {
+	responses, err := s.readBatch(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, resp := range responses {
+		if resp.Code == http.StatusOK {
+			values[i] = resp.Body.Data[s.valueColumn]
+		}
+	}
+	return values, nil
+}
+
+// ReadValueType reads the type of the value column from the table. It
+// must be called before the server is started. The schema of the Arrow
+// responses is also returned for missing keys, so any key can be read
+func (s *Server) ReadValueType() error {
+	responses, err := s.readBatch([][]byte{{}})
+	if err != nil {
+		return fmt.Errorf("unable to read the RESP table %s.%s. %v", s.db, s.table, err)
+	}
+
+	resp := responses[0]
+	if resp.Code != http.StatusOK && resp.Code != http.StatusNotFound {
+		return fmt.Errorf("unable to read the RESP table %s.%s. Code: %d", s.db, s.table, resp.Code)
+	}
+	for _, field := range resp.Body.Schema {
+		if field.Name == s.valueColumn {
+			s.valueType = field.Type
+			return nil
+		}
+	}
+	return fmt.Errorf("column '%s' not found in the RESP table %s.%s", s.valueColumn, s.db, s.table)
+}
+
+// readBatch reads the keys with dal.RonDBBatchedPKRead
+func (s *Server) readBatch(keys [][]byte) ([]batchReadResponse, error) {
+	noOps := uint32(len(keys))
+	reqPtrs := make([]*dal.NativeBuffer, noOps)
+	respPtrs := make([]*dal.NativeBuffer, noOps)
+
+	for i, key := range keys {
+		params, err := s.readParams(key)
+		if err != nil {
+			return nil, err
+		}
+		reqPtrs[i], respPtrs[i], err = pkread.CreateNativeRequest(params)
+		defer dal.ReturnBuffer(reqPtrs[i])
+		defer dal.ReturnBuffer(respPtrs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dalErr := dal.RonDBBatchedPKRead(noOps, reqPtrs, respPtrs)
+	if dalErr != nil {
+		return nil, fmt.Errorf("%s", batchops.DalErrorMessage(dalErr))
+	}
+
+	responses := make([]batchReadResponse, noOps)
+	for i := range respPtrs {
+		err := common.DecodeMsgPackResponse(common.ProcessFormattedResponse(respPtrs[i].Buffer, ds.ARROW_MIME), &responses[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the response of key %d. %v", i, err)
+		}
+	}
+	return responses, nil
+}
+
+// set writes a key with a pk-write operation. NX only inserts new keys
+// and XX only updates existing keys. The reply is null if the key was not
+// written because of them
+func (s *Server) set(key []byte, value []byte, options [][]byte, w replyWriter) {
+	mode := ds.PK_WRITE_MODE_UPSERT
+	for _, option := range options {
+		switch strings.ToLower(string(option)) {
+		case "nx":
+			if mode == ds.PK_WRITE_MODE_UPDATE {
+				w.error("syntax error")
+				return
+			}
+			mode = ds.PK_WRITE_MODE_INSERT
+		case "xx":
+			if mode == ds.PK_WRITE_MODE_INSERT {
+				w.error("syntax error")
+				return
+			}
+			mode = ds.PK_WRITE_MODE_UPDATE
+		default:
+			w.error(fmt.Sprintf("unsupported SET option '%s'", option))
+			return
+		}
+	}
+
+	params, err := s.writeParams(key, value, mode)
+	if err != nil {
+		w.error(err.Error())
+		return
+	}
+
+	request, response, err := pkwrite.CreateNativeRequest(params)
+	if err != nil {
+		w.error(err.Error())
+		return
+	}
+	defer dal.ReturnBuffer(request)
+	defer dal.ReturnBuffer(response)
+
+	dalErr := dal.RonDBPKWrite(request, response)
+	switch {
+	case dalErr == nil:
+		w.simpleString("OK")
+	case mode == ds.PK_WRITE_MODE_INSERT && dalErr.HttpCode == http.StatusConflict,
+		mode == ds.PK_WRITE_MODE_UPDATE && dalErr.HttpCode == http.StatusNotFound:
+		w.null()
+	default:
+		w.error(batchops.DalErrorMessage(dalErr))
+	}
+}
+
+// del deletes the keys in a non-atomic batch of pk-delete operations and
+// replies with the number of deleted keys
+func (s *Server) del(keys [][]byte, w replyWriter) {
+	pkOperations := make([]batchops.SubOperation, len(keys))
+	for i, key := range keys {
+		filters, err := s.keyFilters(key)
+		if err != nil {
+			w.error(err.Error())
+			return
+		}
+
+		method := http.MethodPost
+		relativeURL := s.db + "/" + s.table + "/" + ds.PK_DELETE_OPERATION
+		operation := ds.BatchSubOperation{
+			Method:      &method,
+			RelativeURL: &relativeURL,
+			Body:        &ds.BatchSubOperationBody{Filters: filters},
+		}
+		if err := batchops.ParseOperation(&operation, &pkOperations[i]); err != nil {
+			w.error(err.Error())
+			return
+		}
+	}
+
+	responses, dalErr, err := batchops.ExecuteBatch(pkOperations, false, "")
+	if err != nil {
+		w.error(err.Error())
+		return
+	}
+	if dalErr != nil {
+		w.error(batchops.DalErrorMessage(dalErr))
+		return
+	}
+
+	deleted := 0
+	for _, resp := range responses {
+		opResp := struct {
+			Code int `json:"code"`
+		}{}
+		if err := json.Unmarshal([]byte(resp), &opResp); err != nil {
+			w.error(fmt.Sprintf("failed to read the response. %v", err))
+			return
+		}
+		if opResp.Code == http.StatusOK {
+			deleted++
+		}
+	}
+	w.integer(deleted)
+}
+
+func (s *Server) readParams(key []byte) (*ds.PKReadParams, error) {
+	filters, err := s.keyFilters(key)
+	if err != nil {
+		return nil, err
+	}
+	return &ds.PKReadParams{
+		DB:             &s.db,
+		Table:          &s.table,
+		Filters:        filters,
+		ReadColumns:    &[]ds.ReadColumn{{Column: &s.valueColumn}},
+		ResponseFormat: ds.ARROW_MIME,
+	}, nil
+}
+
+// writeParams returns the parameters of the pk-write operation. Binary
+// values are written as base64 strings, see ReadValueType
+func (s *Server) writeParams(key []byte, value []byte, mode string) (*ds.PKWriteParams, error) {
+	filters, err := s.keyFilters(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var jsonValue []byte
+	if s.valueType == arrowBinaryType {
+		jsonValue, err = json.Marshal(value)
+	} else {
+		if !utf8.Valid(value) {
+			return nil, fmt.Errorf("the value is not valid UTF-8. Column '%s' is not binary", s.valueColumn)
+		}
+		jsonValue, err = json.Marshal(string(value))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rawValue := json.RawMessage(jsonValue)
+	values := map[string]*json.RawMessage{s.valueColumn: &rawValue}
+	return &ds.PKWriteParams{
+		DB:      &s.db,
+		Table:   &s.table,
+		Filters: filters,
+		Values:  &values,
+		Mode:    &mode,
+	}, nil
+}
+
+// keyFilters returns the primary key filter of a key. The key column is
+// a CHAR or VARCHAR column, so the key must be valid UTF-8
+func (s *Server) keyFilters(key []byte) (*[]ds.Filter, error) {
+	if !utf8.Valid(key) {
+		return nil, fmt.Errorf("the key is not valid UTF-8")
+	}
+	jsonKey, err := json.Marshal(string(key))
+	if err != nil {
+		return nil, err
+	}
+	rawKey := json.RawMessage(jsonKey)
+	return &[]ds.Filter{{Column: &s.keyColumn, Value: &rawKey}}, nil
+}
+
+// writeValue replies with a value as a bulk string. Numbers and other
+// types are sent as text, as Redis does
+func writeValue(value interface{}, w replyWriter) {
+	switch v := value.(type) {
+	case nil:
+		w.null()
+	case []byte:
+		w.bulk(v)
+	case string:
+		w.bulk([]byte(v))
+	default:
+		w.bulk([]byte(fmt.Sprint(v)))
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package respsrv
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Clients send commands as arrays of bulk strings, e.g.,
+//
+//   *2\r\n$3\r\nGET\r\n$3\r\nkey\r\n
+//
+// Commands can also be sent inline, i.e., as a line of words separated
+// by spaces, which is what telnet and netcat users do. See
+// https://redis.io/docs/reference/protocol-spec/
+
+const (
+	// the size of the read buffer is the max length of a line
+	maxLineLength = 64 * 1024
+	// a command and as many keys as a batch has operations
+	maxArgs = 4096 + 1
+	// the total length of the keys of a command, e.g., MGET. The value of
+	// SET is limited by RestServer.BufferSize
+	maxKeysLength = 4 * 1024 * 1024
+)
+
+// protocolError is a malformed command. The connection is closed after
+// the error is sent as the rest of the input can not be parsed
+type protocolError struct {
+	msg string
+}
+
+func (e *protocolError) Error() string {
+	return "Protocol error: " + e.msg
+}
+
+// readCommand returns the arguments of the next command. Empty commands,
+// including null and empty arrays, have no arguments. maxBulkLength is the
+// max length of an argument and maxCommandLength the max total length of
+// the arguments
+func readCommand(r *bufio.Reader, maxBulkLength int, maxCommandLength int) ([][]byte, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		args := [][]byte{}
+		for _, word := range strings.Fields(string(line)) {
+			args = append(args, []byte(word))
+		}
+		return args, nil
+	}
+
+	count, err := strconv.Atoi(string(line[1:]))
+	if err != nil || count > maxArgs {
+		return nil, &protocolError{"invalid multibulk length"}
+	}
+	if count <= 0 {
+		return [][]byte{}, nil
+	}
+
+	args := make([][]byte, 0, count)
+	commandLength := 0
+	for i := 0; i < count; i++ {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, &protocolError{fmt.Sprintf("expected '$', got '%s'", printable(line))}
+		}
+
+		length, err := strconv.Atoi(string(line[1:]))
+		if err != nil || length < 0 || length > maxBulkLength {
+			return nil, &protocolError{"invalid bulk length"}
+		}
+		commandLength += length
+		if commandLength > maxCommandLength {
+			return nil, &protocolError{"too big command"}
+		}
+
+		arg := make([]byte, length+2)
+		if _, err := io.ReadFull(r, arg); err != nil {
+			return nil, err
+		}
+		if !bytes.HasSuffix(arg, []byte("\r\n")) {
+			return nil, &protocolError{"expected CRLF after bulk string"}
+		}
+		args = append(args, arg[:length])
+	}
+	return args, nil
+}
+
+// readLine returns a line without the line break. Inline commands may end
+// with LF only
+func readLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		return nil, &protocolError{"too big request line"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	line = bytes.TrimSuffix(line[:len(line)-1], []byte("\r"))
+	return line, nil
+}
+
+func printable(line []byte) string {
+	if len(line) > 16 {
+		line = line[:16]
+	}
+	return strconv.Quote(string(line))
+}
+
+// replyWriter writes the replies of the commands. The replies are
+// buffered, the caller flushes them
+type replyWriter struct {
+	w *bufio.Writer
+}
+
+func (r replyWriter) simpleString(s string) {
+	r.w.WriteString("+" + s + "\r\n")
+}
+
+// error sends an error reply. Line breaks would end the reply, so they
+// are replaced by spaces
+func (r replyWriter) error(msg string) {
+	msg = strings.NewReplacer("\r", " ", "\n", " ").Replace(msg)
+	r.w.WriteString("-ERR " + msg + "\r\n")
+}
+
+func (r replyWriter) integer(n int) {
+	r.w.WriteString(":" + strconv.Itoa(n) + "\r\n")
+}
+
+func (r replyWriter) bulk(b []byte) {
+	r.w.WriteString("$" + strconv.Itoa(len(b)) + "\r\n")
+	r.w.Write(b)
+	r.w.WriteString("\r\n")
+}
+
+// null is the reply of missing keys
+func (r replyWriter) null() {
+	r.w.WriteString("$-1\r\n")
+}
+
+// array starts an array reply of n elements
+func (r replyWriter) array(n int) {
+	r.w.WriteString("*" + strconv.Itoa(n) + "\r\n")
+}
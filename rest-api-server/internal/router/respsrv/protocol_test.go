@@ -0,0 +1,108 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package respsrv
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadCommand(t *testing.T) {
+	r := bufio.NewReaderSize(strings.NewReader(
+		"*2\r\n$3\r\nGET\r\n$5\r\na\r\nb \r\n"+ // bulk strings can contain line breaks
+			"*3\r\n$3\r\nSET\r\n$0\r\n\r\n$1\r\nv\r\n"+
+			"  ping   hello \r\n"+
+			"\r\n"+
+			"*-1\r\n"+ // null and empty arrays are empty commands
+			"*0\r\n"+
+			"echo x\n"), maxLineLength)
+
+	expected := [][]string{
+		{"GET", "a\r\nb "},
+		{"SET", "", "v"},
+		{"ping", "hello"},
+		{},
+		{},
+		{},
+		{"echo", "x"},
+	}
+	for i, exp := range expected {
+		args, err := readCommand(r, 1024, 4096)
+		if err != nil {
+			t.Fatalf("command %d failed. %v", i, err)
+		}
+		got := []string{}
+		for _, arg := range args {
+			got = append(got, string(arg))
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Fatalf("command %d. Expecting %q. Got: %q", i, exp, got)
+		}
+	}
+	if _, err := readCommand(r, 1024, 4096); err != io.EOF {
+		t.Fatalf("expecting EOF. Got: %v", err)
+	}
+
+	// Test. Malformed commands
+	tests := map[string]string{
+		"*x\r\n":             "invalid multibulk length",
+		"*5000\r\n":          "invalid multibulk length",
+		"*1\r\n:1\r\n":       "expected '$', got '\":1\"'",
+		"*1\r\n$-1\r\n":      "invalid bulk length",
+		"*1\r\n$5\r\n":       "invalid bulk length",
+		"*1\r\n$1\r\nab\r\n": "expected CRLF after bulk string",
+		"*3\r\n$4\r\nabcd\r\n$4\r\nabcd\r\n$2\r\n": "too big command",
+		strings.Repeat("a", 70000):                 "too big request line",
+	}
+	for input, msg := range tests {
+		r := bufio.NewReaderSize(strings.NewReader(input), maxLineLength)
+		_, err := readCommand(r, 4, 9)
+		if _, ok := err.(*protocolError); !ok || !strings.Contains(err.Error(), msg) {
+			t.Fatalf("input %s. Expecting a protocol error '%s'. Got: %v", printable([]byte(input)), msg, err)
+		}
+	}
+
+	// Test. Truncated commands are not protocol errors
+	r = bufio.NewReaderSize(strings.NewReader("*1\r\n$3\r\nGE"), maxLineLength)
+	if _, err := readCommand(r, 1024, 4096); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expecting an unexpected EOF. Got: %v", err)
+	}
+}
+
+func TestReplyWriter(t *testing.T) {
+	buf := bytes.Buffer{}
+	w := replyWriter{bufio.NewWriter(&buf)}
+
+	w.simpleString("OK")
+	w.error("line\r\nbreak")
+	w.integer(-2)
+	w.bulk([]byte("a\r\nb"))
+	w.bulk([]byte{})
+	w.null()
+	w.array(2)
+	w.w.Flush()
+
+	expected := "+OK\r\n-ERR line  break\r\n:-2\r\n$4\r\na\r\nb\r\n$0\r\n\r\n$-1\r\n*2\r\n"
+	if buf.String() != expected {
+		t.Fatalf("expecting %q. Got: %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,217 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package respsrv serves a key/value table over the Redis protocol (RESP),
+// so that Redis clients can read and write it. The keys are the primary
+// key column of the table and the values are a second column. The
+// commands use the pk-read and pk-write operations
+package respsrv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+
+	"hopsworks.ai/rdrs/internal/config"
+	"hopsworks.ai/rdrs/internal/log"
+	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+)
+
+// Server is a RESP listener for a key/value table. The connections are
+// served concurrently, the commands of a connection in order
+type Server struct {
+	db          string
+	table       string
+	keyColumn   string
+	valueColumn string
+	// the Arrow type of the value column, see ReadValueType
+	valueType string
+
+	mutex    sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]bool
+	closed   bool
+}
+
+var batchNameRegexp = regexp.MustCompile("^[a-zA-Z0-9$_]+$")
+
+// NewServer returns a server for the table, i.e., database.table
+func NewServer(table string, keyColumn string, valueColumn string) (*Server, error) {
+	parts := strings.Split(table, ".")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid RESP table '%s'. Expecting database.table", table)
+	}
+	for _, identifier := range []string{parts[0], parts[1], keyColumn, valueColumn} {
+		if err := pkread.ValidateDBIdentifier(identifier); err != nil {
+			return nil, fmt.Errorf("invalid RESP table '%s'. %v", table, err)
+		}
+	}
+	// DEL uses a batch, which only supports these names
+	if !batchNameRegexp.MatchString(parts[0]) || !batchNameRegexp.MatchString(parts[1]) {
+		return nil, fmt.Errorf("invalid RESP table '%s'. The names can only contain letters, digits, '$' and '_'", table)
+	}
+
+	return &Server{
+		db:          parts[0],
+		table:       parts[1],
+		keyColumn:   keyColumn,
+		valueColumn: valueColumn,
+		conns:       make(map[net.Conn]bool),
+	}, nil
+}
+
+// Serve accepts connections until the server is stopped
+func (s *Server) Serve(listener net.Listener) error {
+	s.mutex.Lock()
+	s.listener = listener
+	s.mutex.Unlock()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			s.mutex.Lock()
+			closed := s.closed
+			s.mutex.Unlock()
+			if closed {
+				return nil
+			}
+			return err
+		}
+
+		s.mutex.Lock()
+		if s.closed {
+			s.mutex.Unlock()
+			conn.Close()
+			return nil
+		}
+		s.conns[conn] = true
+		s.mutex.Unlock()
+
+		go s.serveConn(conn)
+	}
+}
+
+// Stop closes the listener and the open connections
+func (s *Server) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+// serveConn runs the commands of a connection in order. The replies of
+// pipelined commands are sent together
+func (s *Server) serveConn(conn net.Conn) {
+	defer func() {
+		s.mutex.Lock()
+		delete(s.conns, conn)
+		s.mutex.Unlock()
+		conn.Close()
+	}()
+
+	r := bufio.NewReaderSize(conn, maxLineLength)
+	w := replyWriter{bufio.NewWriter(conn)}
+	maxBulkLength := config.Configuration().RestServer.BufferSize
+	maxCommandLength := maxBulkLength + maxKeysLength
+
+	for {
+		args, err := readCommand(r, maxBulkLength, maxCommandLength)
+		if err != nil {
+			if perr, ok := err.(*protocolError); ok {
+				w.error(perr.Error())
+				w.w.Flush()
+			} else if err != io.EOF {
+				log.Debugf("RESP connection from %s closed. Error: %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+
+		if len(args) > 0 && !s.execute(args, w) {
+			w.w.Flush()
+			return
+		}
+		if r.Buffered() == 0 {
+			if err := w.w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// execute runs a command and writes its reply. It returns false if the
+// connection must be closed
+func (s *Server) execute(args [][]byte, w replyWriter) bool {
+	name := strings.ToLower(string(args[0]))
+	arity, ok := commandArity[name]
+	if !ok {
+		w.error(fmt.Sprintf("unknown command '%s'", args[0]))
+		return true
+	}
+	if (arity > 0 && len(args) != arity) || (arity < 0 && len(args) < -arity) {
+		w.error(fmt.Sprintf("wrong number of arguments for '%s' command", name))
+		return true
+	}
+	if name == "ping" && len(args) > 2 {
+		w.error("wrong number of arguments for 'ping' command")
+		return true
+	}
+
+	switch name {
+	case "ping":
+		if len(args) == 2 {
+			w.bulk(args[1])
+		} else {
+			w.simpleString("PONG")
+		}
+	case "echo":
+		w.bulk(args[1])
+	case "quit":
+		w.simpleString("OK")
+		return false
+	case "get":
+		s.get(args[1], w)
+	case "mget":
+		s.mget(args[1:], w)
+	case "set":
+		s.set(args[1], args[2], args[3:], w)
+	case "del":
+		s.del(args[1:], w)
+	}
+	return true
+}
+
+// commandArity is the number of arguments of the commands, including the
+// name. Negative numbers are the min number of arguments
+var commandArity = map[string]int{
+	"ping": -1,
+	"echo": 2,
+	"quit": 1,
+	"get":  2,
+	"mget": -2,
+	"set":  -3,
+	"del":  -2,
+}
@@ -0,0 +1,258 @@
+/*
+ * This file is part of the RonDB REST API Server
+ * Copyright (c) 2022 Hopsworks AB
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, version 3.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+ * General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program. If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package respsrv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"hopsworks.ai/rdrs/internal/common"
+	tu "hopsworks.ai/rdrs/internal/router/handler/utils"
+)
+
+// client sends commands to the server and reads the replies
+type client struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// newServer returns a server for the table with the type of the value
+// column
+func newServer(t *testing.T, table string) *Server {
+	t.Helper()
+
+	server, err := NewServer(table, "key", "value")
+	if err != nil {
+		t.Fatalf("failed to create the server. %v", err)
+	}
+	if err := server.ReadValueType(); err != nil {
+		t.Fatalf("failed to read the type of the value column. %v", err)
+	}
+	return server
+}
+
+// withClient serves the table on a local port
+func withClient(t *testing.T, server *Server, fn func(c *client)) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen. %v", err)
+	}
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to connect to the RESP server. %v", err)
+	}
+	defer conn.Close()
+
+	fn(&client{t: t, conn: conn, r: bufio.NewReader(conn)})
+}
+
+// do sends a command and returns its reply. Simple strings and bulk
+// strings are strings, errors are errorReply, integers are int64, null is
+// nil and arrays are []interface{}
+func (c *client) do(args ...string) interface{} {
+	c.t.Helper()
+
+	cmd := fmt.Sprintf("*%d\r\n", len(args))
+	for _, arg := range args {
+		cmd += fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := c.conn.Write([]byte(cmd)); err != nil {
+		c.t.Fatalf("failed to send the command. %v", err)
+	}
+	return c.reply()
+}
+
+type errorReply string
+
+func (c *client) reply() interface{} {
+	c.t.Helper()
+
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		c.t.Fatalf("failed to read the reply. %v", err)
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+
+	switch line[0] {
+	case '+':
+		return line[1:]
+	case '-':
+		return errorReply(line[1:])
+	case ':':
+		n, _ := strconv.ParseInt(line[1:], 10, 64)
+		return n
+	case '$':
+		length, _ := strconv.Atoi(line[1:])
+		if length < 0 {
+			return nil
+		}
+		buf := make([]byte, length+2)
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			c.t.Fatalf("failed to read the reply. %v", err)
+		}
+		return string(buf[:length])
+	case '*':
+		n, _ := strconv.Atoi(line[1:])
+		elems := make([]interface{}, n)
+		for i := range elems {
+			elems[i] = c.reply()
+		}
+		return elems
+	default:
+		c.t.Fatalf("unexpected reply '%s'", line)
+		return nil
+	}
+}
+
+func (c *client) check(expected interface{}, args ...string) {
+	c.t.Helper()
+	if reply := c.do(args...); !reflect.DeepEqual(reply, expected) {
+		c.t.Fatalf("%q. Expecting %#v. Got: %#v", args, expected, reply)
+	}
+}
+
+func (c *client) checkError(msg string, args ...string) {
+	c.t.Helper()
+	reply, ok := c.do(args...).(errorReply)
+	if !ok || !strings.Contains(string(reply), msg) {
+		c.t.Fatalf("%q. Expecting an error '%s'. Got: %#v", args, msg, reply)
+	}
+}
+
+func TestNewServer(t *testing.T) {
+	tests := map[string]string{
+		"table":     "Expecting database.table",
+		"db.t.x":    "Expecting database.table",
+		"db.":       "invalid RESP table 'db.'",
+		"db.ta ble": "can only contain letters",
+		"db.table":  "",
+	}
+	for table, msg := range tests {
+		_, err := NewServer(table, "key", "value")
+		if (msg == "" && err != nil) || (msg != "" && (err == nil || !strings.Contains(err.Error(), msg))) {
+			t.Fatalf("table '%s'. Expecting error '%s'. Got: %v", table, msg, err)
+		}
+	}
+}
+
+func TestRESPCommands(t *testing.T) {
+	tu.WithDBs(t, [][][]string{common.Database("DB030")},
+		[]tu.RegisterTestHandler{}, func(router *gin.Engine) {
+			withClient(t, newServer(t, "DB030.kv"), func(c *client) {
+				c.check("PONG", "PING")
+				c.check("hi", "ping", "hi")
+				c.check("x y", "ECHO", "x y")
+				c.checkError("wrong number of arguments for 'ping'", "PING", "a", "b")
+				c.checkError("wrong number of arguments for 'get'", "GET")
+				c.checkError("unknown command 'INCR'", "INCR", "k1")
+
+				// Test. Binary values are returned as is, NULL values are null
+				c.check("v1", "GET", "k1")
+				c.check("\x00\xff", "GET", "k2")
+				c.check(nil, "GET", "k3")
+				c.check(nil, "GET", "missing")
+				c.checkError("the key is not valid UTF-8", "GET", "\xff")
+
+				c.check([]interface{}{"v1", nil, "\x00\xff", nil}, "MGET", "k1", "missing", "k2", "k3")
+
+				// Test. NX and XX
+				c.check("OK", "SET", "k4", "\r\n\x00")
+				c.check("\r\n\x00", "GET", "k4")
+				c.check(nil, "SET", "k4", "v", "NX")
+				c.check("OK", "SET", "k4", "v4", "XX")
+				c.check(nil, "SET", "k5", "v", "XX")
+				c.check("OK", "SET", "k5", "v5", "nx")
+				c.check([]interface{}{"v4", "v5"}, "MGET", "k4", "k5")
+				c.checkError("syntax error", "SET", "k5", "v", "NX", "XX")
+				c.checkError("unsupported SET option 'EX'", "SET", "k5", "v", "EX", "10")
+
+				// Test. DEL returns the number of deleted keys
+				c.check(int64(2), "DEL", "k4", "missing", "k5")
+				c.check([]interface{}{nil, nil}, "MGET", "k4", "k5")
+
+				// Test. Pipelined commands
+				if _, err := c.conn.Write([]byte("GET k1\r\nPING\r\n")); err != nil {
+					t.Fatalf("failed to send the commands. %v", err)
+				}
+				if reply := c.reply(); reply != "v1" {
+					t.Fatalf("expecting v1. Got: %#v", reply)
+				}
+				if reply := c.reply(); reply != "PONG" {
+					t.Fatalf("expecting PONG. Got: %#v", reply)
+				}
+
+				c.check("OK", "QUIT")
+			})
+
+			withClient(t, newServer(t, "DB030.kv_text"), func(c *client) {
+				// Test. Text values must be valid UTF-8
+				c.checkError("the value is not valid UTF-8", "SET", "k2", "\xff")
+				c.check("OK", "SET", "k2", "ü")
+				c.check("ü", "GET", "k2")
+			})
+
+			withClient(t, newServer(t, "DB030.kv_blob"), func(c *client) {
+				// Test. BLOB columns can not be written
+				c.checkError("", "SET", "k1", "v")
+			})
+
+			// Test. The table is read when the server starts
+			server, err := NewServer("DB030.missing", "key", "value")
+			if err != nil {
+				t.Fatalf("failed to create the server. %v", err)
+			}
+			if err := server.ReadValueType(); err == nil || !strings.Contains(err.Error(), "Database/Table does not exist") {
+				t.Fatalf("expecting an error for a missing table. Got: %v", err)
+			}
+		})
+}
+
+func TestRESPProtocolError(t *testing.T) {
+	// the commands are not run, so the table is not read
+	server, err := NewServer("DB030.kv", "key", "value")
+	if err != nil {
+		t.Fatalf("failed to create the server. %v", err)
+	}
+	withClient(t, server, func(c *client) {
+		if _, err := c.conn.Write([]byte("*1\r\n:1\r\n")); err != nil {
+			t.Fatalf("failed to send the command. %v", err)
+		}
+		reply, ok := c.reply().(errorReply)
+		if !ok || !strings.Contains(string(reply), "Protocol error: expected '$'") {
+			t.Fatalf("expecting a protocol error. Got: %#v", reply)
+		}
+
+		// the connection is closed
+		if _, err := c.r.ReadByte(); err != io.EOF {
+			t.Fatalf("expecting the connection to be closed. Got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,273 @@
+//
+// This file is part of the RonDB REST API Server
+// Copyright (c) 2022 Hopsworks AB
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, version 3.
+//
+// This program is distributed in the hope that it will be useful, but
+// WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the GNU
+// General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program. If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: rdrs.proto
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RDRS_PKRead_FullMethodName   = "/rdrs.RDRS/PKRead"
+	RDRS_PKWrite_FullMethodName  = "/rdrs.RDRS/PKWrite"
+	RDRS_PKDelete_FullMethodName = "/rdrs.RDRS/PKDelete"
+	RDRS_Batch_FullMethodName    = "/rdrs.RDRS/Batch"
+	RDRS_Stat_FullMethodName     = "/rdrs.RDRS/Stat"
+)
+
+// RDRSClient is the client API for RDRS service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RDRSClient interface {
+	PKRead(ctx context.Context, in *PKReadRequest, opts ...grpc.CallOption) (*OperationResponse, error)
+	PKWrite(ctx context.Context, in *PKWriteRequest, opts ...grpc.CallOption) (*OperationResponse, error)
+	PKDelete(ctx context.Context, in *PKDeleteRequest, opts ...grpc.CallOption) (*OperationResponse, error)
+	Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error)
+}
+
+type rDRSClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRDRSClient(cc grpc.ClientConnInterface) RDRSClient {
+	return &rDRSClient{cc}
+}
+
+func (c *rDRSClient) PKRead(ctx context.Context, in *PKReadRequest, opts ...grpc.CallOption) (*OperationResponse, error) {
+	out := new(OperationResponse)
+	err := c.cc.Invoke(ctx, RDRS_PKRead_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rDRSClient) PKWrite(ctx context.Context, in *PKWriteRequest, opts ...grpc.CallOption) (*OperationResponse, error) {
+	out := new(OperationResponse)
+	err := c.cc.Invoke(ctx, RDRS_PKWrite_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rDRSClient) PKDelete(ctx context.Context, in *PKDeleteRequest, opts ...grpc.CallOption) (*OperationResponse, error) {
+	out := new(OperationResponse)
+	err := c.cc.Invoke(ctx, RDRS_PKDelete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rDRSClient) Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, RDRS_Batch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rDRSClient) Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error) {
+	out := new(StatResponse)
+	err := c.cc.Invoke(ctx, RDRS_Stat_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RDRSServer is the server API for RDRS service.
+// All implementations must embed UnimplementedRDRSServer
+// for forward compatibility
+type RDRSServer interface {
+	PKRead(context.Context, *PKReadRequest) (*OperationResponse, error)
+	PKWrite(context.Context, *PKWriteRequest) (*OperationResponse, error)
+	PKDelete(context.Context, *PKDeleteRequest) (*OperationResponse, error)
+	Batch(context.Context, *BatchRequest) (*BatchResponse, error)
+	Stat(context.Context, *StatRequest) (*StatResponse, error)
+	mustEmbedUnimplementedRDRSServer()
+}
+
+// UnimplementedRDRSServer must be embedded to have forward compatible implementations.
+type UnimplementedRDRSServer struct {
+}
+
+func (UnimplementedRDRSServer) PKRead(context.Context, *PKReadRequest) (*OperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PKRead not implemented")
+}
+func (UnimplementedRDRSServer) PKWrite(context.Context, *PKWriteRequest) (*OperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PKWrite not implemented")
+}
+func (UnimplementedRDRSServer) PKDelete(context.Context, *PKDeleteRequest) (*OperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PKDelete not implemented")
+}
+func (UnimplementedRDRSServer) Batch(context.Context, *BatchRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Batch not implemented")
+}
+func (UnimplementedRDRSServer) Stat(context.Context, *StatRequest) (*StatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
+}
+func (UnimplementedRDRSServer) mustEmbedUnimplementedRDRSServer() {}
+
+// UnsafeRDRSServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RDRSServer will
+// result in compilation errors.
+type UnsafeRDRSServer interface {
+	mustEmbedUnimplementedRDRSServer()
+}
+
+func RegisterRDRSServer(s grpc.ServiceRegistrar, srv RDRSServer) {
+	s.RegisterService(&RDRS_ServiceDesc, srv)
+}
+
+func _RDRS_PKRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PKReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RDRSServer).PKRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RDRS_PKRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RDRSServer).PKRead(ctx, req.(*PKReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RDRS_PKWrite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PKWriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RDRSServer).PKWrite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RDRS_PKWrite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RDRSServer).PKWrite(ctx, req.(*PKWriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RDRS_PKDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PKDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RDRSServer).PKDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RDRS_PKDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RDRSServer).PKDelete(ctx, req.(*PKDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RDRS_Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RDRSServer).Batch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RDRS_Batch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RDRSServer).Batch(ctx, req.(*BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RDRS_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RDRSServer).Stat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RDRS_Stat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RDRSServer).Stat(ctx, req.(*StatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RDRS_ServiceDesc is the grpc.ServiceDesc for RDRS service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RDRS_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rdrs.RDRS",
+	HandlerType: (*RDRSServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PKRead",
+			Handler:    _RDRS_PKRead_Handler,
+		},
+		{
+			MethodName: "PKWrite",
+			Handler:    _RDRS_PKWrite_Handler,
+		},
+		{
+			MethodName: "PKDelete",
+			Handler:    _RDRS_PKDelete_Handler,
+		},
+		{
+			MethodName: "Batch",
+			Handler:    _RDRS_Batch_Handler,
+		},
+		{
+			MethodName: "Stat",
+			Handler:    _RDRS_Stat_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rdrs.proto",
+}
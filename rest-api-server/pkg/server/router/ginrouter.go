@@ -18,16 +18,26 @@
 package router
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
 	"hopsworks.ai/rdrs/internal/config"
 	"hopsworks.ai/rdrs/internal/dal"
 	ds "hopsworks.ai/rdrs/internal/datastructs"
 	"hopsworks.ai/rdrs/internal/log"
+	"hopsworks.ai/rdrs/internal/router/grpcsrv"
 	"hopsworks.ai/rdrs/internal/router/handler/batchops"
+	"hopsworks.ai/rdrs/internal/router/handler/blob"
 	"hopsworks.ai/rdrs/internal/router/handler/pkread"
+	"hopsworks.ai/rdrs/internal/router/handler/pkwrite"
+	"hopsworks.ai/rdrs/internal/router/handler/scan"
 	"hopsworks.ai/rdrs/internal/router/handler/stat"
+	"hopsworks.ai/rdrs/internal/router/handler/tx"
+	"hopsworks.ai/rdrs/internal/router/respsrv"
 	// _ "github.com/ianlancetaylor/cgosymbolizer" // enable this for stack trace for c layer
 )
 
@@ -37,6 +47,17 @@ type RouterConext struct {
 	ServerPort uint16
 	APIVersion string
 	Engine     *gin.Engine
+	HTTPServer *http.Server
+
+	// gRPC Server. nil if the port is 0
+	GRPCServerIP   string
+	GRPCServerPort uint16
+	GRPCServer     *grpc.Server
+
+	// RESP Server. nil if the port is 0
+	RESPServerIP   string
+	RESPServerPort uint16
+	RESPServer     *respsrv.Server
 
 	// RonDB
 	DBIP   string
@@ -51,7 +72,27 @@ func (rc *RouterConext) SetupRouter() error {
 
 	rc.Engine.GET("/"+rc.APIVersion+"/"+ds.STAT_OPERATION, stat.StatHandler)
 	rc.Engine.POST("/"+rc.APIVersion+"/:db/:table/"+ds.PK_DB_OPERATION, pkread.PkReadHandler)
+	rc.Engine.POST("/"+rc.APIVersion+"/:db/:table/"+ds.UNIQUE_READ_OPERATION, pkread.UniqueReadHandler)
+	rc.Engine.POST("/"+rc.APIVersion+"/:db/:table/"+ds.PK_WRITE_OPERATION, pkwrite.PkWriteHandler)
+	rc.Engine.POST("/"+rc.APIVersion+"/:db/:table/"+ds.PK_DELETE_OPERATION, pkwrite.PkDeleteHandler)
+	rc.Engine.DELETE("/"+rc.APIVersion+"/:db/:table/"+ds.PK_DELETE_OPERATION, pkwrite.PkDeleteHandler)
+	rc.Engine.POST("/"+rc.APIVersion+"/:db/:table/"+ds.SCAN_OPERATION, scan.ScanHandler)
+	rc.Engine.POST("/"+rc.APIVersion+"/:db/:table/"+ds.INDEX_SCAN_EP, scan.IndexScanHandler)
+	rc.Engine.GET("/"+rc.APIVersion+"/:db/:table/"+ds.BLOB_EP, blob.BlobHandler)
 	rc.Engine.POST("/"+rc.APIVersion+"/"+ds.BATCH_OPERATION, batchops.BatchOpsHandler)
+	rc.Engine.POST("/"+rc.APIVersion+"/tx/"+ds.TX_BEGIN_OPERATION, tx.TxBeginHandler)
+	rc.Engine.POST("/"+rc.APIVersion+"/tx/:"+ds.TX_ID_PP+"/"+ds.TX_COMMIT_OPERATION, tx.TxCommitHandler)
+	rc.Engine.POST("/"+rc.APIVersion+"/tx/:"+ds.TX_ID_PP+"/"+ds.TX_ABORT_OPERATION, tx.TxAbortHandler)
+
+	rc.HTTPServer = &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", rc.ServerIP, rc.ServerPort),
+		Handler: rc.Engine,
+	}
+
+	if rc.GRPCServerPort != 0 {
+		rc.GRPCServer = grpc.NewServer()
+		grpcsrv.Register(rc.GRPCServer)
+	}
 
 	// connect to RonDB
 	dal.InitializeBuffers()
@@ -59,29 +100,95 @@ func (rc *RouterConext) SetupRouter() error {
 	if err != nil {
 		return err
 	}
+	dal.ConfigureTransactions(config.Configuration().RestServer.MaxOpenTransactions,
+		config.Configuration().RestServer.TransactionIdleTimeoutMS)
+	dal.ConfigureBlobs(config.Configuration().RestServer.MaxBlobReadSize)
+	dal.ConfigurePadding(config.Configuration().RestServer.TrimCharPadding,
+		config.Configuration().RestServer.TrimBinaryPadding)
+
+	// the RESP server reads the type of the value column from RonDB
+	if rc.RESPServerPort != 0 {
+		respServer, err := respsrv.NewServer(config.Configuration().RestServer.RESPTable,
+			config.Configuration().RestServer.RESPKeyColumn,
+			config.Configuration().RestServer.RESPValueColumn)
+		if err != nil {
+			return err
+		}
+		if err := respServer.ReadValueType(); err != nil {
+			return err
+		}
+		rc.RESPServer = respServer
+	}
 
 	return nil
 }
 
 func (rc *RouterConext) StartRouter() error {
 
-	address := fmt.Sprintf("%s:%d", rc.ServerIP, rc.ServerPort)
-	log.Infof("Listening on %s\n", address)
-	err := rc.Engine.Run(address)
-	if err != nil {
+	if rc.GRPCServer != nil {
+		grpcAddress := fmt.Sprintf("%s:%d", rc.GRPCServerIP, rc.GRPCServerPort)
+		listener, err := net.Listen("tcp", grpcAddress)
+		if err != nil {
+			return err
+		}
+		log.Infof("gRPC server listening on %s\n", grpcAddress)
+		go func() {
+			if err := rc.GRPCServer.Serve(listener); err != nil {
+				log.Errorf("gRPC server stopped. Error: %v", err)
+			}
+		}()
+	}
+
+	if rc.RESPServer != nil {
+		respAddress := fmt.Sprintf("%s:%d", rc.RESPServerIP, rc.RESPServerPort)
+		listener, err := net.Listen("tcp", respAddress)
+		if err != nil {
+			return err
+		}
+		log.Infof("RESP server listening on %s\n", respAddress)
+		go func() {
+			if err := rc.RESPServer.Serve(listener); err != nil {
+				log.Errorf("RESP server stopped. Error: %v", err)
+			}
+		}()
+	}
+
+	log.Infof("Listening on %s\n", rc.HTTPServer.Addr)
+	err := rc.HTTPServer.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
 		return err
 	}
 
 	return nil
 }
 
+// StopRouter stops the servers. The gRPC calls and REST requests that are
+// running are finished first. The RESP connections are closed
+func (rc *RouterConext) StopRouter() error {
+	if rc.GRPCServer != nil {
+		rc.GRPCServer.GracefulStop()
+	}
+	if rc.RESPServer != nil {
+		rc.RESPServer.Stop()
+	}
+
+	return rc.HTTPServer.Shutdown(context.Background())
+}
+
 func CreateRouterContext() Router {
 	router := RouterConext{
 		ServerIP:   config.Configuration().RestServer.IP,
 		ServerPort: config.Configuration().RestServer.Port,
 		APIVersion: config.Configuration().RestServer.APIVersion,
-		DBIP:       config.Configuration().RonDBConfig.IP,
-		DBPort:     config.Configuration().RonDBConfig.Port,
+
+		GRPCServerIP:   config.Configuration().RestServer.GRPCIP,
+		GRPCServerPort: config.Configuration().RestServer.GRPCPort,
+
+		RESPServerIP:   config.Configuration().RestServer.RESPIP,
+		RESPServerPort: config.Configuration().RestServer.RESPPort,
+
+		DBIP:   config.Configuration().RonDBConfig.IP,
+		DBPort: config.Configuration().RonDBConfig.Port,
 	}
 	return &router
 }
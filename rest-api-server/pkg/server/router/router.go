@@ -20,4 +20,5 @@ package router
 type Router interface {
 	SetupRouter() error
 	StartRouter() error
+	StopRouter() error
 }